@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `Prints a completion script for your shell. Besides commands and flags it
+completes Odoo versions, the files 'docker edit' opens and the local modules
+'docker install' can install.
+
+Bash (needs the bash-completion package):
+  source <(odooctl completion bash)
+  # Load for every session:
+  odooctl completion bash > ~/.local/share/bash-completion/completions/odooctl
+
+Zsh:
+  # Enable completion once, if it isn't already:
+  echo "autoload -U compinit; compinit" >> ~/.zshrc
+  odooctl completion zsh > "${fpath[1]}/_odooctl"
+
+Fish:
+  odooctl completion fish > ~/.config/fish/completions/odooctl.fish
+
+PowerShell:
+  odooctl completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		default:
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
@@ -3,19 +3,29 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/odoo"
 	"github.com/mart337i/odooctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var flagConfigJSON bool
 
+const validConfigKeys = "ssh-key-path, github-token, cache-from, compose-parallelism, interactive-ports, default-odoo-version, default-author"
+
 type globalConfigReport struct {
 	SSHKeyPath  string `json:"ssh_key_path"`
 	GitHubToken string `json:"github_token"`
+	CacheFrom   string `json:"cache_from"`
+	// ComposeParallelism is 0 when compose's default applies
+	ComposeParallelism int    `json:"compose_parallelism"`
+	InteractivePorts   bool   `json:"interactive_ports"`
+	DefaultOdooVersion string `json:"default_odoo_version"`
+	DefaultAuthor      string `json:"default_author"`
 }
 
 type configValueReport struct {
@@ -37,13 +47,31 @@ var configCmd = &cobra.Command{
 Available keys:
   ssh-key-path    Path to your SSH private key (e.g. ~/.ssh/id_ed25519)
   github-token    GitHub Personal Access Token for Odoo Enterprise access
+  cache-from      Default image to seed Docker builds from (e.g. ghcr.io/acme/odoo-dev:17.0)
+  compose-parallelism
+                  Max services docker compose builds, pulls or starts at once
+                  (COMPOSE_PARALLEL_LIMIT; unset uses compose's default)
+  interactive-ports
+                  true to choose ports yourself when 'docker run' finds a
+                  conflict on a terminal (default: move to free ports)
+  default-odoo-version
+                  Version 'docker create' uses when none is passed or detected
+                  from the branch, instead of asking
+  default-author  Author of modules created by 'module scaffold'
 
 Examples:
   odooctl config show                          # Show all saved settings
   odooctl config set ssh-key-path ~/.ssh/id_ed25519
   odooctl config set github-token <token>
+  odooctl config set cache-from ghcr.io/acme/odoo-dev:17.0
+  odooctl config set compose-parallelism 1     # Build one service at a time
+  odooctl config set interactive-ports true
+  odooctl config set default-odoo-version 17.0
+  odooctl config set default-author "Acme Inc."
   odooctl config get ssh-key-path
-  odooctl config unset github-token`,
+  odooctl config unset github-token
+  odooctl config export odooctl-config.json    # Copy settings to another machine
+  odooctl config import odooctl-config.json`,
 }
 
 var configSetCmd = &cobra.Command{
@@ -79,6 +107,9 @@ func init() {
 	configGetCmd.Flags().BoolVar(&flagConfigJSON, "json", false, "Print JSON output")
 	configUnsetCmd.Flags().BoolVar(&flagConfigJSON, "json", false, "Print JSON output")
 	configShowCmd.Flags().BoolVar(&flagConfigJSON, "json", false, "Print JSON output")
+	configSetCmd.ValidArgsFunction = completeConfigSet
+	configGetCmd.ValidArgsFunction = completeConfigKey
+	configUnsetCmd.ValidArgsFunction = completeConfigKey
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configUnsetCmd)
@@ -86,6 +117,34 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 }
 
+// completeConfigKey completes the first argument with a config key
+func completeConfigKey(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return strings.Split(validConfigKeys, ", "), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigSet completes the key, then values for keys with a known set
+// of them. Paths complete as files.
+func completeConfigSet(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch {
+	case len(args) == 0:
+		return completeConfigKey(cmd, args, toComplete)
+	case len(args) > 1:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	switch args[0] {
+	case "default-odoo-version":
+		return odoo.OdooVersions, cobra.ShellCompDirectiveNoFileComp
+	case "interactive-ports":
+		return []string{"true", "false"}, cobra.ShellCompDirectiveNoFileComp
+	case "ssh-key-path":
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 func runConfigSet(cmd *cobra.Command, args []string) error {
 	key, value := args[0], args[1]
 
@@ -94,45 +153,92 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	warning, err := setConfigValue(cfg, key, value)
+	if err != nil {
+		return err
+	}
+	if warning != "" && !flagConfigJSON {
+		fmt.Printf("%s %s\n", color.YellowString("⚠"), warning)
+	}
+
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	if flagConfigJSON {
+		return output.PrintJSON(configMutationReport{Key: key, Value: configValueForKey(cfg, key), Set: true})
+	}
+	if key == "github-token" {
+		fmt.Printf("%s github-token saved\n", color.GreenString("✓"))
+	} else {
+		fmt.Printf("%s %s set to: %s\n", color.GreenString("✓"), key, configValueForKey(cfg, key))
+	}
+	return nil
+}
+
+// setConfigValue validates value and stores it in cfg under key. The warning
+// is set for values that are stored although they look wrong.
+func setConfigValue(cfg *config.GlobalConfig, key, value string) (warning string, err error) {
 	switch key {
 	case "ssh-key-path":
 		// Expand ~ and validate the path exists
 		expanded, err := config.ExpandPath(value)
 		if err != nil {
-			return err
+			return "", err
 		}
 		if _, err := os.Stat(expanded); err != nil {
-			return fmt.Errorf("SSH key file not found: %s", expanded)
+			return "", fmt.Errorf("SSH key file not found: %s", expanded)
 		}
 		cfg.SSHKeyPath = expanded
-		if !flagConfigJSON {
-			fmt.Printf("%s ssh-key-path set to: %s\n", color.GreenString("✓"), expanded)
-		}
 
 	case "github-token":
 		token := strings.TrimSpace(value)
 		if token == "" {
-			return fmt.Errorf("token cannot be empty")
+			return "", fmt.Errorf("token cannot be empty")
 		}
-		if !strings.HasPrefix(token, "ghp_") && !strings.HasPrefix(token, "github_pat_") && !flagConfigJSON {
-			fmt.Printf("%s Token doesn't match expected format (ghp_ or github_pat_), saving anyway\n", color.YellowString("⚠"))
+		if !strings.HasPrefix(token, "ghp_") && !strings.HasPrefix(token, "github_pat_") {
+			warning = "Token doesn't match expected format (ghp_ or github_pat_), saving anyway"
 		}
 		cfg.GitHubToken = token
-		if !flagConfigJSON {
-			fmt.Printf("%s github-token saved\n", color.GreenString("✓"))
+
+	case "cache-from":
+		ref := strings.TrimSpace(value)
+		if err := config.ValidateImageRef(ref); err != nil {
+			return "", err
 		}
+		cfg.CacheFrom = ref
 
-	default:
-		return fmt.Errorf("unknown config key: %s\nValid keys: ssh-key-path, github-token", key)
-	}
+	case "compose-parallelism":
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || limit < 1 {
+			return "", fmt.Errorf("compose-parallelism must be a positive integer, got %q", value)
+		}
+		cfg.ComposeParallelism = limit
 
-	if err := cfg.Save(); err != nil {
-		return err
-	}
-	if flagConfigJSON {
-		return output.PrintJSON(configMutationReport{Key: key, Value: configValueForKey(cfg, key), Set: true})
+	case "interactive-ports":
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("interactive-ports must be true or false, got %q", value)
+		}
+		cfg.InteractivePorts = enabled
+
+	case "default-odoo-version":
+		version := strings.TrimSpace(value)
+		if !odoo.IsValidVersion(version) {
+			return "", fmt.Errorf("unsupported Odoo version %q (supported: %s)", version, odoo.VersionsString())
+		}
+		cfg.DefaultOdooVersion = version
+
+	case "default-author":
+		author := strings.TrimSpace(value)
+		if author == "" {
+			return "", fmt.Errorf("author cannot be empty")
+		}
+		cfg.DefaultAuthor = author
+
+	default:
+		return "", fmt.Errorf("unknown config key: %s\nValid keys: %s", key, validConfigKeys)
 	}
-	return nil
+	return warning, nil
 }
 
 func runConfigGet(cmd *cobra.Command, args []string) error {
@@ -162,8 +268,41 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		} else {
 			fmt.Println(config.MaskToken(cfg.GitHubToken))
 		}
+	case "cache-from":
+		if flagConfigJSON {
+			return output.PrintJSON(configValueReport{Key: key, Value: cfg.CacheFrom})
+		}
+		if cfg.CacheFrom == "" {
+			fmt.Println("(not set)")
+		} else {
+			fmt.Println(cfg.CacheFrom)
+		}
+	case "compose-parallelism":
+		if flagConfigJSON {
+			return output.PrintJSON(configValueReport{Key: key, Value: configValueForKey(cfg, key)})
+		}
+		if cfg.ComposeParallelism == 0 {
+			fmt.Println("(not set)")
+		} else {
+			fmt.Println(cfg.ComposeParallelism)
+		}
+	case "interactive-ports":
+		if flagConfigJSON {
+			return output.PrintJSON(configValueReport{Key: key, Value: configValueForKey(cfg, key)})
+		}
+		fmt.Println(cfg.InteractivePorts)
+	case "default-odoo-version", "default-author":
+		value := configValueForKey(cfg, key)
+		if flagConfigJSON {
+			return output.PrintJSON(configValueReport{Key: key, Value: value})
+		}
+		if value == "" {
+			fmt.Println("(not set)")
+		} else {
+			fmt.Println(value)
+		}
 	default:
-		return fmt.Errorf("unknown config key: %s\nValid keys: ssh-key-path, github-token", key)
+		return fmt.Errorf("unknown config key: %s\nValid keys: %s", key, validConfigKeys)
 	}
 
 	return nil
@@ -182,8 +321,18 @@ func runConfigUnset(cmd *cobra.Command, args []string) error {
 		cfg.SSHKeyPath = ""
 	case "github-token":
 		cfg.GitHubToken = ""
+	case "cache-from":
+		cfg.CacheFrom = ""
+	case "compose-parallelism":
+		cfg.ComposeParallelism = 0
+	case "interactive-ports":
+		cfg.InteractivePorts = false
+	case "default-odoo-version":
+		cfg.DefaultOdooVersion = ""
+	case "default-author":
+		cfg.DefaultAuthor = ""
 	default:
-		return fmt.Errorf("unknown config key: %s\nValid keys: ssh-key-path, github-token", key)
+		return fmt.Errorf("unknown config key: %s\nValid keys: %s", key, validConfigKeys)
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -202,7 +351,7 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if flagConfigJSON {
-		return output.PrintJSON(globalConfigReport{SSHKeyPath: cfg.SSHKeyPath, GitHubToken: configValueForKey(cfg, "github-token")})
+		return output.PrintJSON(newGlobalConfigReport(cfg))
 	}
 
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -213,21 +362,52 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("\n%s Global configuration (%s)\n\n", green("⚙"), configPath)
 
 	if cfg.SSHKeyPath == "" {
-		fmt.Printf("  ssh-key-path:  %s\n", yellow("(not set)"))
+		fmt.Printf("  ssh-key-path:         %s\n", yellow("(not set)"))
 	} else {
-		fmt.Printf("  ssh-key-path:  %s\n", cyan(cfg.SSHKeyPath))
+		fmt.Printf("  ssh-key-path:         %s\n", cyan(cfg.SSHKeyPath))
 	}
 
 	if cfg.GitHubToken == "" {
-		fmt.Printf("  github-token:  %s\n", yellow("(not set)"))
+		fmt.Printf("  github-token:         %s\n", yellow("(not set)"))
+	} else {
+		fmt.Printf("  github-token:         %s\n", cyan(config.MaskToken(cfg.GitHubToken)))
+	}
+
+	if cfg.CacheFrom == "" {
+		fmt.Printf("  cache-from:           %s\n", yellow("(not set)"))
+	} else {
+		fmt.Printf("  cache-from:           %s\n", cyan(cfg.CacheFrom))
+	}
+
+	if cfg.ComposeParallelism == 0 {
+		fmt.Printf("  compose-parallelism:  %s\n", yellow("(not set)"))
+	} else {
+		fmt.Printf("  compose-parallelism:  %s\n", cyan(strconv.Itoa(cfg.ComposeParallelism)))
+	}
+
+	fmt.Printf("  interactive-ports:    %s\n", cyan(strconv.FormatBool(cfg.InteractivePorts)))
+
+	if cfg.DefaultOdooVersion == "" {
+		fmt.Printf("  default-odoo-version: %s\n", yellow("(not set)"))
+	} else {
+		fmt.Printf("  default-odoo-version: %s\n", cyan(cfg.DefaultOdooVersion))
+	}
+
+	if cfg.DefaultAuthor == "" {
+		fmt.Printf("  default-author:       %s\n", yellow("(not set)"))
 	} else {
-		fmt.Printf("  github-token:  %s\n", cyan(config.MaskToken(cfg.GitHubToken)))
+		fmt.Printf("  default-author:       %s\n", cyan(cfg.DefaultAuthor))
 	}
 
 	fmt.Println()
 	return nil
 }
 
+// newGlobalConfigReport reports the global config with the token masked
+func newGlobalConfigReport(cfg *config.GlobalConfig) globalConfigReport {
+	return globalConfigReport{SSHKeyPath: cfg.SSHKeyPath, GitHubToken: configValueForKey(cfg, "github-token"), CacheFrom: cfg.CacheFrom, ComposeParallelism: cfg.ComposeParallelism, InteractivePorts: cfg.InteractivePorts, DefaultOdooVersion: cfg.DefaultOdooVersion, DefaultAuthor: cfg.DefaultAuthor}
+}
+
 func configValueForKey(cfg *config.GlobalConfig, key string) string {
 	switch key {
 	case "ssh-key-path":
@@ -237,6 +417,19 @@ func configValueForKey(cfg *config.GlobalConfig, key string) string {
 			return ""
 		}
 		return config.MaskToken(cfg.GitHubToken)
+	case "cache-from":
+		return cfg.CacheFrom
+	case "compose-parallelism":
+		if cfg.ComposeParallelism == 0 {
+			return ""
+		}
+		return strconv.Itoa(cfg.ComposeParallelism)
+	case "interactive-ports":
+		return strconv.FormatBool(cfg.InteractivePorts)
+	case "default-odoo-version":
+		return cfg.DefaultOdooVersion
+	case "default-author":
+		return cfg.DefaultAuthor
 	default:
 		return ""
 	}
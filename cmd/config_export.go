@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagExportWithToken bool
+	flagImportJSON      bool
+)
+
+var configExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write the global configuration to a file",
+	Long: `Write the global configuration as JSON, to a file or to stdout when no file
+(or -) is given. Import it on another machine with 'odooctl config import'.
+
+The GitHub token is left out unless --with-token is passed. An SSH key path
+under your home directory is written as ~/..., so it resolves on the other
+machine.
+
+Examples:
+  odooctl config export odooctl-config.json
+  odooctl config export --with-token > odooctl-config.json`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runConfigExport,
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Load global configuration from a file",
+	Long: `Load settings written by 'odooctl config export' (or - for stdin). Each value
+is checked like 'odooctl config set' checks it, for instance the SSH key must
+exist, and nothing is saved unless all of them are valid.
+
+Settings in the file replace the current ones; settings it doesn't contain
+are kept.
+
+Examples:
+  odooctl config import odooctl-config.json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runConfigImport,
+}
+
+func init() {
+	configExportCmd.Flags().BoolVar(&flagExportWithToken, "with-token", false, "Include the GitHub token in the export")
+	configImportCmd.Flags().BoolVar(&flagImportJSON, "json", false, "Print JSON output")
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+}
+
+func runConfigExport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	exported := *cfg
+	if !flagExportWithToken {
+		exported.GitHubToken = ""
+	}
+	exported.SSHKeyPath = homeRelativePath(cfg.SSHKeyPath)
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if len(args) == 0 || args[0] == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	path := args[0]
+	// 0600 like config.json when the file holds the token. WriteFile only
+	// applies the mode to new files, so tighten an existing one before the
+	// token goes into it.
+	perm := os.FileMode(0644)
+	if exported.GitHubToken != "" {
+		perm = 0600
+		if err := os.Chmod(path, perm); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to restrict permissions of %s: %w", path, err)
+		}
+	}
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	fmt.Printf("%s Configuration exported to %s\n", color.GreenString("✓"), path)
+	if cfg.GitHubToken != "" && !flagExportWithToken {
+		fmt.Printf("%s github-token left out; pass --with-token to include it\n", color.CyanString("ℹ"))
+	}
+	return nil
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	imported, warnings, err := importConfig(cfg, data)
+	if err != nil {
+		return fmt.Errorf("invalid configuration in %s: %w", path, err)
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if flagImportJSON {
+		return output.PrintJSON(newGlobalConfigReport(cfg))
+	}
+	for _, warning := range warnings {
+		fmt.Printf("%s %s\n", color.YellowString("⚠"), warning)
+	}
+	fmt.Printf("%s Imported %d setting(s) from %s\n", color.GreenString("✓"), len(imported), path)
+	for _, key := range imported {
+		fmt.Printf("  %s: %s\n", key, color.CyanString(configValueForKey(cfg, key)))
+	}
+	return nil
+}
+
+// importConfig applies the settings of an exported config file to cfg,
+// validating each one like 'config set'. It returns the keys it set.
+func importConfig(cfg *config.GlobalConfig, data []byte) (imported, warnings []string, err error) {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, nil, fmt.Errorf("expected a JSON object: %w", err)
+	}
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		// Keys are the config.json field names, ssh_key_path for ssh-key-path
+		key := strings.ReplaceAll(field, "_", "-")
+		var value string
+		switch v := values[field].(type) {
+		case nil:
+			continue
+		case string:
+			value = v
+		case bool:
+			value = strconv.FormatBool(v)
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, nil, fmt.Errorf("%s: unsupported value %v", field, v)
+		}
+		warning, err := setConfigValue(cfg, key, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", field, err)
+		}
+		if warning != "" {
+			warnings = append(warnings, key+": "+warning)
+		}
+		imported = append(imported, key)
+	}
+	return imported, warnings, nil
+}
+
+// homeRelativePath writes a path under the home directory as ~/...
+func homeRelativePath(path string) string {
+	home, err := os.UserHomeDir()
+	if path == "" || err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(home, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return "~/" + filepath.ToSlash(rel)
+}
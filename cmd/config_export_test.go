@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
+)
+
+func TestImportConfig(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(keyPath, []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.GlobalConfig{DefaultAuthor: "Kept Author", CacheFrom: "old/image:1"}
+	data := `{
+  "ssh_key_path": "` + keyPath + `",
+  "github_token": "token-without-prefix",
+  "cache_from": "ghcr.io/acme/odoo-dev:17.0",
+  "compose_parallelism": 2,
+  "interactive_ports": true,
+  "default_odoo_version": "17.0"
+}`
+	imported, warnings, err := importConfig(cfg, []byte(data))
+	if err != nil {
+		t.Fatalf("importConfig() error = %v", err)
+	}
+	want := &config.GlobalConfig{
+		SSHKeyPath:         keyPath,
+		GitHubToken:        "token-without-prefix",
+		CacheFrom:          "ghcr.io/acme/odoo-dev:17.0",
+		ComposeParallelism: 2,
+		InteractivePorts:   true,
+		DefaultOdooVersion: "17.0",
+		DefaultAuthor:      "Kept Author",
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Fatalf("config = %+v, want %+v", cfg, want)
+	}
+	if len(imported) != 6 {
+		t.Errorf("imported = %v, want 6 keys", imported)
+	}
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "github-token:") {
+		t.Errorf("warnings = %v, want the token format warning", warnings)
+	}
+}
+
+func TestImportConfigRejectsInvalidValues(t *testing.T) {
+	for name, data := range map[string]string{
+		"missing ssh key": `{"ssh_key_path": "/nonexistent/id_ed25519"}`,
+		"unknown key":     `{"colour": "blue"}`,
+		"bad version":     `{"default_odoo_version": "9.0"}`,
+		"bad parallelism": `{"compose_parallelism": 1.5}`,
+		"not an object":   `["ssh_key_path"]`,
+	} {
+		cfg := &config.GlobalConfig{}
+		if _, _, err := importConfig(cfg, []byte(data)); err == nil {
+			t.Errorf("%s: importConfig() error = nil", name)
+		}
+	}
+}
+
+func TestConfigExportWithTokenRestrictsExistingFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := (&config.GlobalConfig{GitHubToken: "ghp_secret"}).Save(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	flagExportWithToken = true
+	t.Cleanup(func() { flagExportWithToken = false })
+	if err := runConfigExport(configExportCmd, []string{path}); err != nil {
+		t.Fatalf("runConfigExport() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("mode = %o, want 600", perm)
+	}
+}
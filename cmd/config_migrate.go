@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/mart337i/odooctl/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var flagMigrateJSON bool
+
+type configMigrateReport struct {
+	GlobalTokenSaved bool     `json:"global_token_saved"`
+	Migrated         []string `json:"migrated"`
+	Kept             []string `json:"kept"`
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move enterprise tokens from environments into the global config",
+	Long: `Move GitHub tokens for Odoo Enterprise stored in environment state files
+into the global config, so the secret is kept in one place. Each migrated
+environment references the global token instead and its files are re-rendered.
+
+When the global config has no token yet, it takes the first one found.
+Environments with a different token keep it as an explicit override.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runConfigMigrate,
+}
+
+func init() {
+	configMigrateCmd.Flags().BoolVar(&flagMigrateJSON, "json", false, "Print JSON output")
+	configCmd.AddCommand(configMigrateCmd)
+}
+
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	result, err := config.MigrateEnterpriseTokens()
+	if err != nil {
+		return fmt.Errorf("failed to migrate enterprise tokens: %w", err)
+	}
+
+	report := configMigrateReport{GlobalTokenSaved: result.GlobalTokenSaved, Migrated: []string{}, Kept: []string{}}
+	for _, state := range result.Migrated {
+		if err := templates.Render(state); err != nil {
+			return fmt.Errorf("failed to render templates for %s/%s: %w", state.ProjectName, state.Branch, err)
+		}
+		report.Migrated = append(report.Migrated, state.ProjectName+"/"+state.Branch)
+	}
+	for _, state := range result.Kept {
+		report.Kept = append(report.Kept, state.ProjectName+"/"+state.Branch)
+	}
+	if flagMigrateJSON {
+		return output.PrintJSON(report)
+	}
+
+	if result.GlobalTokenSaved {
+		fmt.Printf("%s Saved the GitHub token in the global config\n", color.GreenString("✓"))
+	}
+	for _, name := range report.Migrated {
+		fmt.Printf("  %s %s now uses the global token\n", color.GreenString("✓"), name)
+	}
+	for _, name := range report.Kept {
+		fmt.Printf("  %s %s keeps its own token (different from the global one)\n", color.YellowString("!"), name)
+	}
+	fmt.Printf("%s Migrated %d environment(s)\n", color.CyanString("ℹ"), len(report.Migrated))
+	return nil
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/deps"
+	"github.com/mart337i/odooctl/internal/odoo"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagPresetOdooVersion  string
+	flagPresetModules      string
+	flagPresetPip          string
+	flagPresetAddonsPaths  []string
+	flagPresetConfOptions  []string
+	flagPresetEnterprise   bool
+	flagPresetWithoutDemo  bool
+	flagPresetBrowser      bool
+	flagPresetAutoDiscover bool
+	flagPresetJSON         bool
+	flagPresetOutput       string
+)
+
+type presetMutationReport struct {
+	Name    string `json:"name"`
+	Path    string `json:"path,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+var presetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: "Manage presets for 'odooctl docker create'",
+	Long: `Presets bundle the settings of a standard stack (Odoo version, modules, pip
+packages, addons paths, odoo.conf options and create flags) under a name.
+They are stored in ~/.odooctl/presets/<name>.json, so a team can share them.
+
+Apply a preset with 'odooctl docker create --template <name>' (or
+--from-template); flags passed to create override the preset.
+
+Examples:
+  odooctl config preset save ecommerce -v 17.0 -m website_sale,stock --pip stripe
+  odooctl config preset save accounting -m account,l10n_be --without-demo
+  odooctl config preset list
+  odooctl config preset rm accounting
+  odooctl docker create --template ecommerce --name shop`,
+}
+
+var presetSaveCmd = &cobra.Command{
+	Use:          "save <name>",
+	Short:        "Save or replace a create preset",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runPresetSave,
+}
+
+var presetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved create presets",
+	Args:  cobra.NoArgs,
+	RunE:  runPresetList,
+}
+
+var presetDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a create preset",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runPresetDelete,
+}
+
+func init() {
+	presetSaveCmd.Flags().StringVarP(&flagPresetOdooVersion, "odoo-version", "v", "", "Odoo version ("+odoo.VersionsString()+")")
+	_ = presetSaveCmd.RegisterFlagCompletionFunc("odoo-version", cobra.FixedCompletions(odoo.OdooVersions, cobra.ShellCompDirectiveNoFileComp))
+	presetSaveCmd.Flags().StringVarP(&flagPresetModules, "modules", "m", "", "Modules to install (comma-separated)")
+	presetSaveCmd.Flags().StringVarP(&flagPresetPip, "pip", "p", "", "Extra pip packages (comma-separated or path to requirements.txt)")
+	presetSaveCmd.Flags().StringArrayVarP(&flagPresetAddonsPaths, "addons-path", "a", nil, "Additional addons directories (can specify multiple times)")
+	presetSaveCmd.Flags().StringArrayVar(&flagPresetConfOptions, "conf", nil, "Extra odoo.conf option as key=value (can specify multiple times)")
+	presetSaveCmd.Flags().BoolVarP(&flagPresetEnterprise, "enterprise", "e", false, "Include Odoo Enterprise")
+	presetSaveCmd.Flags().BoolVar(&flagPresetWithoutDemo, "without-demo", false, "Initialize without demo data")
+	presetSaveCmd.Flags().BoolVar(&flagPresetBrowser, "browser", false, "Include Playwright Chromium (Odoo 15.0+)")
+	presetSaveCmd.Flags().BoolVar(&flagPresetAutoDiscover, "auto-discover-deps", false, "Auto-discover Python dependencies from manifests during create")
+	presetSaveCmd.Flags().BoolVar(&flagPresetJSON, "json", false, "Print JSON output")
+	presetListCmd.Flags().BoolVar(&flagPresetJSON, "json", false, "Print JSON output")
+	presetListCmd.Flags().StringVarP(&flagPresetOutput, "output", "o", "table", output.FormatFlagUsage)
+	presetDeleteCmd.Flags().BoolVar(&flagPresetJSON, "json", false, "Print JSON output")
+	presetCmd.AddCommand(presetSaveCmd)
+	presetCmd.AddCommand(presetListCmd)
+	presetCmd.AddCommand(presetDeleteCmd)
+	configCmd.AddCommand(presetCmd)
+}
+
+func runPresetSave(cmd *cobra.Command, args []string) error {
+	preset := &config.Preset{
+		Name:             args[0],
+		OdooVersion:      flagPresetOdooVersion,
+		Modules:          splitList(flagPresetModules),
+		PipPackages:      deps.ParsePipPackages(flagPresetPip),
+		ConfOptions:      flagPresetConfOptions,
+		Enterprise:       flagPresetEnterprise,
+		WithoutDemo:      flagPresetWithoutDemo,
+		Browser:          flagPresetBrowser,
+		AutoDiscoverDeps: flagPresetAutoDiscover,
+	}
+	if preset.OdooVersion != "" && !odoo.IsValidVersion(preset.OdooVersion) {
+		return fmt.Errorf("unsupported Odoo version %q (supported: %s)", preset.OdooVersion, odoo.VersionsString())
+	}
+	confOptions, err := config.ParseConfOptions(preset.ConfOptions)
+	if err != nil {
+		return err
+	}
+	for key, value := range confOptions {
+		if value == "" {
+			return fmt.Errorf("--conf %s= has no value; pass key=value", key)
+		}
+	}
+	// Presets are shared across projects, so relative paths are stored absolute
+	for _, path := range flagPresetAddonsPaths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		preset.AddonsPaths = append(preset.AddonsPaths, absPath)
+	}
+
+	_, loadErr := config.LoadPreset(preset.Name)
+	if err := preset.Save(); err != nil {
+		return err
+	}
+
+	dir, _ := config.PresetsDir()
+	path := filepath.Join(dir, preset.Name+".json")
+	if flagPresetJSON {
+		return output.PrintJSON(presetMutationReport{Name: preset.Name, Path: path})
+	}
+	action := "saved"
+	if loadErr == nil {
+		action = "replaced"
+	}
+	fmt.Printf("%s Preset %s %s (%s)\n", color.GreenString("✓"), color.CyanString(preset.Name), action, path)
+	fmt.Printf("  Use it with: %s\n", color.CyanString("odooctl docker create --template "+preset.Name))
+	return nil
+}
+
+func runPresetList(cmd *cobra.Command, args []string) error {
+	format, err := output.ResolveFormat(flagPresetOutput, flagPresetJSON)
+	if err != nil {
+		return err
+	}
+	presets, err := config.ListPresets()
+	if err != nil {
+		return err
+	}
+	if format == output.FormatTable && len(presets) == 0 {
+		fmt.Println("No presets saved. Create one with 'odooctl config preset save <name>'")
+		return nil
+	}
+	if presets == nil {
+		presets = []*config.Preset{}
+	}
+
+	table := output.Table{Headers: []string{"name", "version", "modules", "pip", "options"}}
+	for _, preset := range presets {
+		table.Rows = append(table.Rows, []string{preset.Name, preset.OdooVersion, strings.Join(preset.Modules, ","), strings.Join(preset.PipPackages, ","), presetOptions(preset)})
+	}
+	return output.Print(format, table, presets)
+}
+
+func runPresetDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := config.DeletePreset(name); err != nil {
+		return err
+	}
+	if flagPresetJSON {
+		return output.PrintJSON(presetMutationReport{Name: name, Deleted: true})
+	}
+	fmt.Printf("%s Preset %s deleted\n", color.GreenString("✓"), name)
+	return nil
+}
+
+// presetOptions summarizes a preset's create flags for the list table
+func presetOptions(preset *config.Preset) string {
+	var options []string
+	if preset.Enterprise {
+		options = append(options, "enterprise")
+	}
+	if preset.WithoutDemo {
+		options = append(options, "without-demo")
+	}
+	if preset.Browser {
+		options = append(options, "browser")
+	}
+	if preset.AutoDiscoverDeps {
+		options = append(options, "auto-discover-deps")
+	}
+	if len(preset.AddonsPaths) > 0 {
+		options = append(options, fmt.Sprintf("addons-path x%d", len(preset.AddonsPaths)))
+	}
+	options = append(options, preset.ConfOptions...)
+	return strings.Join(options, " ")
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagBuildNoCache bool
+	flagBuildPull    bool
+)
+
+var buildCmd = &cobra.Command{
+	Use:          "build",
+	Short:        "Build the Docker image without starting containers",
+	SilenceUsage: true,
+	Long: `Build the environment's Odoo image with 'docker compose build', without
+bringing containers up. Running containers keep the old image until the next
+'odooctl docker run'.
+
+The cache-from image (see build-cache) seeds the build unless --no-cache is
+passed.
+
+Examples:
+  odooctl docker build              # Rebuild the image, e.g. in a CI step
+  odooctl docker build --pull       # Also pull a newer base image
+  odooctl docker build --no-cache   # Rebuild every layer`,
+	Args: cobra.NoArgs,
+	RunE: runBuild,
+}
+
+func init() {
+	buildCmd.Flags().BoolVar(&flagBuildNoCache, "no-cache", false, "Build without using cached layers")
+	buildCmd.Flags().BoolVar(&flagBuildPull, "pull", false, "Always pull a newer version of the base image")
+}
+
+func runBuild(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	if err := docker.CheckDaemon(); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+
+	refreshed, err := refreshStaleDockerfile(state)
+	if err != nil {
+		return fmt.Errorf("failed to refresh Docker configuration: %w", err)
+	}
+	if refreshed {
+		fmt.Printf("%s Refreshed Docker configuration to avoid system pip conflicts\n", green("✓"))
+	}
+	if !flagBuildNoCache {
+		if err := prepareBuildCache(state); err != nil {
+			return fmt.Errorf("failed to prepare build cache: %w", err)
+		}
+	}
+
+	buildArgs := []string{"build"}
+	if flagBuildNoCache {
+		buildArgs = append(buildArgs, "--no-cache")
+	}
+	if flagBuildPull {
+		buildArgs = append(buildArgs, "--pull")
+	}
+
+	fmt.Println("Building image...")
+	start := time.Now()
+	if err := docker.Compose(state, buildArgs...); err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+	duration := time.Since(start).Round(time.Second)
+
+	now := time.Now()
+	state.BuiltAt = &now
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	fmt.Printf("\n%s Image built in %s\n", green("✓"), duration)
+	fmt.Println("Start it with: odooctl docker run")
+	return nil
+}
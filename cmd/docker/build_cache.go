@@ -0,0 +1,106 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var buildCacheCmd = &cobra.Command{
+	Use:          "build-cache [image-ref]",
+	Short:        "Pull the image used to seed the build cache",
+	SilenceUsage: true,
+	Long: `Pull the cache-from image ahead of a build so its layers are available locally.
+
+The image defaults to the environment's cache-from setting (docker run --cache-from),
+falling back to the global default (odooctl config set cache-from <image-ref>).
+
+Examples:
+  odooctl docker build-cache                                # Warm the configured cache image
+  odooctl docker build-cache ghcr.io/acme/odoo-dev:17.0     # Warm a specific image`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBuildCache,
+}
+
+func runBuildCache(cmd *cobra.Command, args []string) error {
+	var ref string
+	if len(args) == 1 {
+		ref = strings.TrimSpace(args[0])
+	} else {
+		state, err := loadState()
+		if err != nil {
+			return err
+		}
+		ref = state.CacheFromRef()
+	}
+	if ref == "" {
+		return fmt.Errorf("no cache image configured. Use 'odooctl config set cache-from <image-ref>' or pass one as an argument")
+	}
+	if err := config.ValidateImageRef(ref); err != nil {
+		return err
+	}
+
+	fmt.Printf("Pulling cache image %s...\n", color.CyanString(ref))
+	if err := pullCacheImage(ref); err != nil {
+		return fmt.Errorf("failed to pull cache image %s: %w", ref, err)
+	}
+	fmt.Printf("%s Build cache warmed from %s\n", color.GreenString("✓"), ref)
+	return nil
+}
+
+// applyCacheFromFlag validates a --cache-from value and stores it on the state.
+// "none" disables the global default for this environment.
+func applyCacheFromFlag(state *config.State, value string) error {
+	ref := strings.TrimSpace(value)
+	if ref != config.CacheFromNone {
+		if err := config.ValidateImageRef(ref); err != nil {
+			return err
+		}
+	}
+	state.CacheFrom = ref
+	return nil
+}
+
+// prepareBuildCache renders the compose file with the cache-from image before
+// a build. If the image can't be pulled the build proceeds without a cache.
+func prepareBuildCache(state *config.State) error {
+	ref := state.CacheFromRef()
+	if ref == "" {
+		return templates.Render(state)
+	}
+
+	yellow := color.New(color.FgYellow).SprintFunc()
+	usable := true
+	if err := config.ValidateImageRef(ref); err != nil {
+		fmt.Printf("%s Ignoring cache-from: %v\n", yellow("⚠️"), err)
+		usable = false
+	} else if err := pullCacheImage(ref); err != nil {
+		fmt.Printf("%s Cache image %s could not be pulled, building without cache\n", yellow("⚠️"), ref)
+		usable = false
+	}
+	if usable {
+		fmt.Printf("%s Using build cache from %s\n", color.GreenString("✓"), ref)
+		return templates.Render(state)
+	}
+
+	// Render once without cache_from; the state keeps its setting so the
+	// next build tries the image again.
+	saved := state.CacheFrom
+	state.CacheFrom = config.CacheFromNone
+	err := templates.Render(state)
+	state.CacheFrom = saved
+	return err
+}
+
+func pullCacheImage(ref string) error {
+	cmd := exec.Command("docker", "pull", "--quiet", ref)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,203 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var flagCloneWithData bool
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <new-name>",
+	Short: "Copy the current environment under a new name",
+	Long: `Creates a new environment with the same settings as the current one: Odoo
+version, modules, addons paths, pip packages and odoo.conf options. Use it to
+experiment without touching a working environment.
+
+The new name replaces the project name, and the clone gets its own Docker
+Compose project, so its containers, database and filestore are separate. It gets
+free ports and becomes the project's active environment, as after 'odooctl
+docker create --name'.
+
+With --with-data, the database and filestore are copied too: the current
+environment (which must be running) is dumped and restored into the clone's
+containers. Without it, initialize the clone with 'odooctl docker run -i'.
+
+Examples:
+  odooctl docker clone shop-experiment
+  odooctl docker clone shop-migration --with-data`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runClone,
+}
+
+func init() {
+	cloneCmd.Flags().BoolVar(&flagCloneWithData, "with-data", false, "Copy the database and filestore into the clone")
+}
+
+func runClone(cmd *cobra.Command, args []string) error {
+	source, err := loadState()
+	if err != nil {
+		return err
+	}
+	clone, err := cloneState(source, args[0])
+	if err != nil {
+		return err
+	}
+	if flagCloneWithData {
+		if err := ensureDockerProjectAccess(source); err != nil {
+			return err
+		}
+		if !docker.IsRunning(source) {
+			return fmt.Errorf("--with-data needs the current environment running to dump it. Start it with: odooctl docker run")
+		}
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("%s Cloning %s into %s\n", cyan("📦"), environmentName(source), cyan(environmentName(clone)))
+	if err := templates.Render(clone); err != nil {
+		return fmt.Errorf("failed to render templates: %w", err)
+	}
+	if err := clone.Save(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	if err := config.SaveProjectLink(clone); err != nil {
+		return fmt.Errorf("failed to save project link: %w", err)
+	}
+	fmt.Printf("%s Environment files created\n", green("✓"))
+
+	if flagCloneWithData {
+		if err := cloneData(source, clone); err != nil {
+			return fmt.Errorf("environment created, but copying data failed: %w", err)
+		}
+	}
+
+	envDir, _ := config.EnvironmentDir(clone.ProjectName, clone.Branch)
+	fmt.Printf("\n%s Cloned into %s\n", green("✓"), cyan(environmentName(clone)))
+	fmt.Printf("  Files: %s\n", cyan(envDir))
+	fmt.Printf("  Odoo:  http://localhost:%d\n", clone.Ports.Odoo)
+	if !flagCloneWithData {
+		fmt.Printf("\nInitialize and start it with: %s\n", cyan("odooctl docker run -i"))
+	}
+	return nil
+}
+
+// cloneState copies source under a new project name with its own ports.
+// Build and install bookkeeping carries over only where it still holds.
+func cloneState(source *config.State, name string) (*config.State, error) {
+	if !config.IsValidName(name) || config.IsReservedProjectName(name) {
+		return nil, fmt.Errorf("invalid environment name %q (use letters, digits, '-', '_' or '.', e.g. %q)", name, config.SanitizeName(name))
+	}
+	if config.EnvironmentExists(name, source.Branch) {
+		return nil, fmt.Errorf("environment '%s/%s' already exists. Choose a different name or remove it with 'odooctl docker reset'", name, source.Branch)
+	}
+
+	if err := config.CheckComposeProjectFree(config.NewComposeProjectName(name, source.Branch), name, source.Branch); err != nil {
+		return nil, err
+	}
+
+	clone, err := source.Copy()
+	if err != nil {
+		return nil, err
+	}
+	clone.ProjectName = name
+	clone.ComposeProject = config.NewComposeProjectName(clone.ProjectName, clone.Branch)
+	clone.Ports = config.FindAvailablePorts(clone.OdooVersion, clone.ProjectName, clone.Branch)
+	clone.CreatedAt = time.Now()
+	// The image is shared (odoo-dev:<version>), the database is not
+	clone.InitializedAt = nil
+	clone.LastInstallAt = nil
+	clone.LastStartedAt = nil
+	// A bind-mounted filestore would be shared; the clone gets a volume
+	clone.FilestoreBindPath = ""
+	return clone, nil
+}
+
+// cloneData dumps source's database and filestore and restores them into
+// clone, starting the clone's containers
+func cloneData(source, clone *config.State) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	tmpDir, err := os.MkdirTemp("", "odooctl-clone-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	fmt.Printf("%s Dumping %s...\n", yellow("→"), source.DBName())
+	dumpFile := filepath.Join(tmpDir, newDumpManifest(dumpFormatCustom).DumpFile)
+	if err := dumpDatabaseToFile(source, source.DBName(), dumpFile); err != nil {
+		return fmt.Errorf("failed to dump database: %w", err)
+	}
+	filestoreDir := filepath.Join(tmpDir, "filestore")
+	if err := copyFilestore(source, source.DBName(), filestoreDir); err != nil {
+		return fmt.Errorf("failed to copy filestore: %w", err)
+	}
+
+	fmt.Printf("%s Starting the clone's containers...\n", yellow("→"))
+	if err := docker.Compose(clone, "up", "-d", "--wait"); err != nil {
+		return fmt.Errorf("failed to start containers: %w", err)
+	}
+
+	dbName := clone.DBName()
+	if entries, _ := os.ReadDir(filestoreDir); len(entries) > 0 {
+		fmt.Printf("%s Restoring filestore...\n", yellow("→"))
+		if err := replaceFilestore(clone, filestoreDir, dbName); err != nil {
+			return fmt.Errorf("failed to restore filestore: %w", err)
+		}
+	}
+
+	fmt.Printf("%s Restoring database...\n", yellow("→"))
+	if err := docker.Compose(clone, "stop", "odoo"); err != nil {
+		fmt.Printf("%s Warning: failed to stop odoo container: %v\n", yellow("!"), err)
+	}
+	restoreErr := recreateDatabase(clone, dbName)
+	if restoreErr == nil {
+		restoreErr = pgRestoreFile(clone, dumpFile, dbName, runtime.NumCPU())
+	}
+	if err := docker.Compose(clone, "up", "-d", "odoo"); err != nil {
+		fmt.Printf("%s Warning: failed to restart odoo container: %v\n", yellow("!"), err)
+	}
+	if restoreErr != nil {
+		return fmt.Errorf("failed to restore database: %w", restoreErr)
+	}
+
+	// The clone's database has the source's modules installed
+	if hashes, err := loadHashes(source); err == nil {
+		if err := saveHashes(clone, hashes); err != nil {
+			fmt.Printf("%s Warning: failed to copy module hashes: %v\n", yellow("!"), err)
+		}
+	}
+	now := time.Now()
+	clone.InitializedAt = &now
+	if err := clone.Save(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	fmt.Printf("%s Database and filestore copied\n", green("✓"))
+	return nil
+}
+
+// dumpDatabaseToFile writes a custom-format dump of dbName to path
+func dumpDatabaseToFile(state *config.State, dbName, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := dumpDatabase(state, dbName, file, dumpFormatCustom); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
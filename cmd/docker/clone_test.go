@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mart337i/odooctl/internal/config"
+)
+
+func TestCloneState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	now := time.Now()
+	source := &config.State{
+		ProjectName:      "shop",
+		OdooVersion:      "17.0",
+		Branch:           "main",
+		ProjectRoot:      "/src/shop",
+		Modules:          []string{"sale"},
+		ExtraConfOptions: map[string]string{"workers": "2"},
+		Ports:            config.CalculatePorts("17.0"),
+		InitializedAt:    &now,
+		BuiltAt:          &now,
+	}
+	source.FilestoreBindPath = "/src/shop-filestore"
+	if err := source.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := cloneState(source, "shop-test")
+	if err != nil {
+		t.Fatalf("cloneState() error = %v", err)
+	}
+	if clone.ProjectName != "shop-test" || clone.Branch != "main" || clone.ProjectRoot != source.ProjectRoot {
+		t.Errorf("clone = %s/%s in %s, want shop-test/main in %s", clone.ProjectName, clone.Branch, clone.ProjectRoot, source.ProjectRoot)
+	}
+	if clone.InitializedAt != nil || clone.BuiltAt == nil {
+		t.Errorf("clone InitializedAt = %v, BuiltAt = %v; want an uninitialized database and the shared image", clone.InitializedAt, clone.BuiltAt)
+	}
+	if clone.ComposeProjectName() != "shop-test-main" {
+		t.Errorf("clone ComposeProjectName() = %q, want shop-test-main", clone.ComposeProjectName())
+	}
+	if clone.FilestoreBindPath != "" {
+		t.Errorf("clone FilestoreBindPath = %q, want a filestore volume", clone.FilestoreBindPath)
+	}
+	clone.Modules[0] = "stock"
+	clone.ExtraConfOptions["workers"] = "4"
+	if source.Modules[0] != "sale" || source.ExtraConfOptions["workers"] != "2" {
+		t.Errorf("changing the clone changed the source: %v %v", source.Modules, source.ExtraConfOptions)
+	}
+
+	for _, name := range []string{"shop", "bad name", "", ".", "..", "..."} {
+		if _, err := cloneState(source, name); err == nil {
+			t.Errorf("cloneState(%q) error = nil", name)
+		}
+	}
+	if _, err := cloneState(source, "shop"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("cloneState(shop) error = %v, want already exists", err)
+	}
+}
@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mart337i/odooctl/internal/module"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	editCmd.ValidArgsFunction = completeEditFile
+	installCmd.ValidArgsFunction = completeLocalModules
+}
+
+// completeEditFile completes the files 'docker edit' can open
+func completeEditFile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	keys := make([]string, 0, len(filesMap))
+	for key := range filesMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLocalModules completes the modules in the project root, and "all",
+// skipping those already given
+func completeLocalModules(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	state, err := loadState()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	modules, _ := module.FindModules(state.ProjectRoot)
+	return moduleCompletions(modules, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func moduleCompletions(modules, args []string, toComplete string) []string {
+	given := make(map[string]bool, len(args))
+	for _, arg := range args {
+		given[arg] = true
+	}
+	var completions []string
+	if len(args) == 0 && strings.HasPrefix("all", toComplete) {
+		completions = append(completions, "all")
+	}
+	for _, name := range modules {
+		if !given[name] && strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+	return completions
+}
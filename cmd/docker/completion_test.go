@@ -0,0 +1,27 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompleteEditFile(t *testing.T) {
+	keys, _ := completeEditFile(editCmd, nil, "")
+	want := []string{"compose", "config", "dockerfile", "dockerignore", "env"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("completeEditFile() = %v, want %v", keys, want)
+	}
+	if keys, _ := completeEditFile(editCmd, []string{"config"}, ""); len(keys) != 0 {
+		t.Errorf("completeEditFile(config) = %v, want nothing after the file", keys)
+	}
+}
+
+func TestModuleCompletions(t *testing.T) {
+	modules := []string{"acme_sale", "acme_stock", "website_theme"}
+	if got := moduleCompletions(modules, nil, ""); !reflect.DeepEqual(got, []string{"all", "acme_sale", "acme_stock", "website_theme"}) {
+		t.Errorf("moduleCompletions() = %v", got)
+	}
+	if got := moduleCompletions(modules, []string{"acme_sale"}, "acme"); !reflect.DeepEqual(got, []string{"acme_stock"}) {
+		t.Errorf("moduleCompletions(acme_sale, acme) = %v, want [acme_stock]", got)
+	}
+}
@@ -0,0 +1,124 @@
+package docker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	pydeps "github.com/mart337i/odooctl/internal/deps"
+	"github.com/mart337i/odooctl/internal/docker"
+	modlib "github.com/mart337i/odooctl/internal/module"
+)
+
+// Coverage artifacts live in the filestore volume so they survive the
+// one-off "run --rm" containers used for tests.
+const (
+	coverageDataFile = "/var/lib/odoo/filestore/.odooctl-coverage"
+	coverageXMLFile  = "/var/lib/odoo/filestore/.odooctl-coverage.xml"
+)
+
+// ensureCoveragePackage makes sure the coverage package is importable in the
+// odoo image, installing it into the runtime dependency volume when missing.
+func ensureCoveragePackage(state *config.State) error {
+	if _, err := docker.ComposeOutput(state, "run", "--rm", "--no-deps", "odoo", "python3", "-c", "import coverage"); err == nil {
+		return nil
+	}
+	fmt.Printf("%s coverage is not installed in the image; adding it to the runtime dependencies\n", color.YellowString("!"))
+	if err := syncPythonDeps(state, []string{"coverage"}); err != nil {
+		return fmt.Errorf("coverage is required for --coverage: %w", err)
+	}
+	merged, _ := pydeps.MergePackages(state.PipPackages, []string{"coverage"})
+	state.PipPackages = merged
+	markPythonDepsSynced(state)
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return config.SaveProjectLink(state)
+}
+
+// coverageIncludePatterns maps tested local modules to their source
+// directories inside the container. With no modules, every mounted
+// local addons directory is measured.
+func coverageIncludePatterns(state *config.State, modules []string) []string {
+	roots := map[string]string{state.ProjectRoot: "/mnt/extra-addons"}
+	ordered := []string{state.ProjectRoot}
+	for i, path := range state.AddonsPaths {
+		roots[path] = fmt.Sprintf("/mnt/custom-addons-%d", i)
+		ordered = append(ordered, path)
+	}
+
+	if len(modules) == 0 {
+		patterns := make([]string, 0, len(ordered))
+		for _, root := range ordered {
+			patterns = append(patterns, roots[root]+"/*")
+		}
+		return patterns
+	}
+
+	var patterns []string
+	for _, mod := range modules {
+		for _, root := range ordered {
+			if modlib.IsModule(filepath.Join(root, mod)) {
+				patterns = append(patterns, fmt.Sprintf("%s/%s/*", roots[root], mod))
+				break
+			}
+		}
+	}
+	return patterns
+}
+
+// coverageRunCommand wraps odoo-bin arguments in "coverage run".
+func coverageRunCommand(includes []string, odooArgs []string) []string {
+	args := []string{"python3", "-m", "coverage", "run"}
+	if len(includes) > 0 {
+		args = append(args, "--include="+strings.Join(includes, ","))
+	}
+	args = append(args, "/usr/bin/odoo")
+	return append(args, odooArgs...)
+}
+
+// coverageOutputPath resolves a relative report path against the project
+// root, where CI tooling expects coverage.xml next to the sources.
+func coverageOutputPath(state *config.State, outputPath string) string {
+	if filepath.IsAbs(outputPath) {
+		return outputPath
+	}
+	return filepath.Join(state.ProjectRoot, outputPath)
+}
+
+// exportCoverageReport prints the coverage summary and copies coverage.xml
+// from the container to outputPath. It returns the overall line rate.
+func exportCoverageReport(state *config.State, outputPath string) (float64, error) {
+	coverageEnv := "COVERAGE_FILE=" + coverageDataFile
+	if err := docker.Compose(state, "run", "--rm", "--no-deps", "-e", coverageEnv, "odoo", "python3", "-m", "coverage", "report"); err != nil {
+		return 0, fmt.Errorf("coverage report failed: %w", err)
+	}
+	if err := docker.Compose(state, "run", "--rm", "--no-deps", "-e", coverageEnv, "odoo", "python3", "-m", "coverage", "xml", "-o", coverageXMLFile); err != nil {
+		return 0, fmt.Errorf("coverage xml failed: %w", err)
+	}
+
+	absOutput := coverageOutputPath(state, outputPath)
+	if text, err := docker.ComposeOutput(state, "cp", "odoo:"+coverageXMLFile, absOutput); err != nil {
+		return 0, fmt.Errorf("failed to copy coverage.xml from the odoo container (is it created? run 'odooctl docker run'): %s", strings.TrimSpace(text))
+	}
+
+	data, err := os.ReadFile(absOutput)
+	if err != nil {
+		return 0, err
+	}
+	return parseCoverageLineRate(data)
+}
+
+func parseCoverageLineRate(data []byte) (float64, error) {
+	var report struct {
+		LineRate float64 `xml:"line-rate,attr"`
+	}
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return 0, fmt.Errorf("failed to parse coverage.xml: %w", err)
+	}
+	return report.LineRate, nil
+}
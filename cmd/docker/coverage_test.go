@@ -0,0 +1,57 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
+)
+
+func TestCoverageIncludePatternsScopesToModuleSources(t *testing.T) {
+	projectRoot := t.TempDir()
+	extraAddons := t.TempDir()
+	for _, dir := range []string{filepath.Join(projectRoot, "sale_custom"), filepath.Join(extraAddons, "stock_custom")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "__manifest__.py"), []byte("{'name': 'x'}"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	state := &config.State{ProjectRoot: projectRoot, AddonsPaths: []string{extraAddons}}
+
+	got := coverageIncludePatterns(state, []string{"sale_custom", "stock_custom", "missing"})
+	want := []string{"/mnt/extra-addons/sale_custom/*", "/mnt/custom-addons-0/stock_custom/*"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("coverageIncludePatterns() = %v, want %v", got, want)
+	}
+
+	got = coverageIncludePatterns(state, nil)
+	want = []string{"/mnt/extra-addons/*", "/mnt/custom-addons-0/*"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("coverageIncludePatterns(nil) = %v, want %v", got, want)
+	}
+}
+
+func TestParseCoverageLineRate(t *testing.T) {
+	data := []byte(`<?xml version="1.0" ?><coverage version="7.4" line-rate="0.8412" branch-rate="0"></coverage>`)
+	rate, err := parseCoverageLineRate(data)
+	if err != nil {
+		t.Fatalf("parseCoverageLineRate() error = %v", err)
+	}
+	if rate != 0.8412 {
+		t.Fatalf("parseCoverageLineRate() = %v, want 0.8412", rate)
+	}
+}
+
+func TestCoverageOutputPathUsesProjectRoot(t *testing.T) {
+	state := &config.State{ProjectRoot: "/src/project"}
+	if got := coverageOutputPath(state, "coverage.xml"); got != "/src/project/coverage.xml" {
+		t.Errorf("coverageOutputPath(relative) = %q", got)
+	}
+	if got := coverageOutputPath(state, "/tmp/coverage.xml"); got != "/tmp/coverage.xml" {
+		t.Errorf("coverageOutputPath(absolute) = %q", got)
+	}
+}
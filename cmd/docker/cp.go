@@ -0,0 +1,134 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	dockerlib "github.com/mart337i/odooctl/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+// cpContainerDir is where relative container paths point: the odoo user's
+// data directory, which holds the filestore and sessions
+const cpContainerDir = "/var/lib/odoo"
+
+var flagCpService string
+
+var cpInCmd = &cobra.Command{
+	Use:   "cp-in <local> <container-path>",
+	Short: "Copy a file or directory into a container",
+	Long: `Copies a file or directory from the host into a service's container, the
+odoo service unless --service is given. Relative container paths are taken
+from /var/lib/odoo.
+
+Examples:
+  odooctl docker cp-in fixtures/partners.csv /tmp/partners.csv
+  odooctl docker cp-in ./import import          # -> /var/lib/odoo/import
+  odooctl docker cp-in dump.sql /tmp/ --service db`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runCpIn,
+}
+
+var cpOutCmd = &cobra.Command{
+	Use:   "cp-out <container-path> <local>",
+	Short: "Copy a file or directory out of a container",
+	Long: `Copies a file or directory from a service's container to the host, the odoo
+service unless --service is given. Relative container paths are taken from
+/var/lib/odoo.
+
+Examples:
+  odooctl docker cp-out /tmp/report.pdf .
+  odooctl docker cp-out filestore/mydb ./filestore-backup
+  odooctl docker cp-out /var/log/postgresql ./pg-logs --service db`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runCpOut,
+}
+
+func init() {
+	cpInCmd.Flags().StringVarP(&flagCpService, "service", "s", "odoo", "Service to copy into")
+	cpOutCmd.Flags().StringVarP(&flagCpService, "service", "s", "odoo", "Service to copy from")
+}
+
+func runCpIn(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	// docker compose runs in the environment directory, so local paths
+	// must not be relative to the current one
+	source, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(source); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s does not exist", args[0])
+		}
+		return err
+	}
+	target := containerPath(args[1])
+
+	if err := composeCp(state, source, flagCpService+":"+target); err != nil {
+		return fmt.Errorf("failed to copy %s into the %s container: %w", args[0], flagCpService, err)
+	}
+	fmt.Printf("%s Copied %s to %s:%s\n", color.GreenString("✓"), args[0], flagCpService, color.CyanString(target))
+	return nil
+}
+
+func runCpOut(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	source := containerPath(args[0])
+	dest, err := filepath.Abs(args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := composeCp(state, flagCpService+":"+source, dest); err != nil {
+		if isMissingCpSource(err.Error()) {
+			return fmt.Errorf("%s does not exist in the %s container", source, flagCpService)
+		}
+		return fmt.Errorf("failed to copy %s out of the %s container: %w", source, flagCpService, err)
+	}
+	fmt.Printf("%s Copied %s:%s to %s\n", color.GreenString("✓"), flagCpService, source, color.CyanString(args[1]))
+	return nil
+}
+
+// containerPath resolves a container path, taking relative paths from
+// cpContainerDir. A trailing slash is kept, as docker cp gives it meaning.
+func containerPath(p string) string {
+	if path.IsAbs(p) {
+		return p
+	}
+	resolved := path.Join(cpContainerDir, p)
+	if strings.HasSuffix(p, "/") {
+		resolved += "/"
+	}
+	return resolved
+}
+
+func composeCp(state *config.State, source, dest string) error {
+	text, err := dockerlib.ComposeOutput(state, "cp", source, dest)
+	if err != nil {
+		if text = strings.TrimSpace(text); text != "" {
+			return fmt.Errorf("%s", text)
+		}
+		return err
+	}
+	return nil
+}
+
+// isMissingCpSource reports whether docker cp failed because the source
+// path does not exist
+func isMissingCpSource(message string) bool {
+	return strings.Contains(message, "Could not find the file") || strings.Contains(message, "No such container:path")
+}
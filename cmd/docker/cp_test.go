@@ -0,0 +1,27 @@
+package docker
+
+import "testing"
+
+func TestContainerPath(t *testing.T) {
+	tests := map[string]string{
+		"/tmp/report.pdf": "/tmp/report.pdf",
+		"filestore/mydb":  "/var/lib/odoo/filestore/mydb",
+		"import/":         "/var/lib/odoo/import/",
+		"./sessions/../x": "/var/lib/odoo/x",
+		".":               "/var/lib/odoo",
+	}
+	for input, want := range tests {
+		if got := containerPath(input); got != want {
+			t.Errorf("containerPath(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestIsMissingCpSource(t *testing.T) {
+	if !isMissingCpSource("Error response from daemon: Could not find the file /tmp/x in container abc") {
+		t.Error("isMissingCpSource() = false for a missing file")
+	}
+	if isMissingCpSource("permission denied") {
+		t.Error("isMissingCpSource() = true for another error")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,6 +12,9 @@ import (
 	"github.com/mart337i/odooctl/internal/browser"
 	"github.com/mart337i/odooctl/internal/config"
 	"github.com/mart337i/odooctl/internal/deps"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/git"
+	"github.com/mart337i/odooctl/internal/module"
 	"github.com/mart337i/odooctl/internal/odoo"
 	"github.com/mart337i/odooctl/internal/output"
 	"github.com/mart337i/odooctl/internal/project"
@@ -25,58 +29,183 @@ var (
 	flagModules         string
 	flagEnterprise      bool
 	flagWithoutDemo     bool
+	flagWithoutDemoFor  []string
 	flagPip             string
 	flagAddonsPaths     []string
 	flagAutoDiscoverPip bool
 	flagCreateJSON      bool
 	flagCreateBrowser   bool
+	flagConfOptions     []string
+	flagCreateClone     string
+	flagCreateBranch    string
+	flagCreateCloneDir  string
+	flagCreateTemplate  string
+	flagCreateDBName    string
+	flagEnterpriseRepo  string
+	flagPostgresVersion string
+	flagCreateNetwork   string
+	flagFilestoreBind   string
+	flagCreateEnv       []string
+	flagReportURL       string
+	flagWebBaseURL      string
 )
 
 type createReport struct {
-	Project         string       `json:"project"`
-	Environment     string       `json:"environment"`
-	OdooVersion     string       `json:"odoo_version"`
-	Database        string       `json:"database"`
-	EnvDir          string       `json:"env_dir"`
-	Ports           config.Ports `json:"ports"`
-	Modules         []string     `json:"modules"`
-	AddonsPaths     []string     `json:"addons_paths"`
-	PipPackages     []string     `json:"pip_packages"`
-	Enterprise      bool         `json:"enterprise"`
-	AuthMethod      string       `json:"auth_method,omitempty"`
-	Browser         bool         `json:"browser"`
-	BrowserProvider string       `json:"browser_provider,omitempty"`
-	NextSteps       []string     `json:"next_steps"`
+	Project         string            `json:"project"`
+	Template        string            `json:"template,omitempty"`
+	Environment     string            `json:"environment"`
+	OdooVersion     string            `json:"odoo_version"`
+	Database        string            `json:"database"`
+	PostgresVersion string            `json:"postgres_version"`
+	ExternalNetwork string            `json:"external_network,omitempty"`
+	FilestoreBind   string            `json:"filestore_bind,omitempty"`
+	ReportURL       string            `json:"report_url"`
+	WebBaseURL      string            `json:"web_base_url,omitempty"`
+	EnvDir          string            `json:"env_dir"`
+	Ports           config.Ports      `json:"ports"`
+	Modules         []string          `json:"modules"`
+	AddonsPaths     []string          `json:"addons_paths"`
+	PipPackages     []string          `json:"pip_packages"`
+	ConfOptions     map[string]string `json:"extra_conf_options,omitempty"`
+	Env             map[string]string `json:"env,omitempty"` // secret-looking values masked
+	Enterprise      bool              `json:"enterprise"`
+	AuthMethod      string            `json:"auth_method,omitempty"`
+	EnterpriseRepo  string            `json:"enterprise_repo,omitempty"`
+	Browser         bool              `json:"browser"`
+	BrowserProvider string            `json:"browser_provider,omitempty"`
+	NextSteps       []string          `json:"next_steps"`
 }
 
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new Docker development environment",
-	Long:  `Generates Docker Compose, Dockerfile, and configuration files for Odoo development.`,
-	RunE:  runCreate,
+	Long: `Generates Docker Compose, Dockerfile, and configuration files for Odoo development.
+
+With --template (or --from-template), the settings of a preset saved with
+'odooctl config preset save' are used; flags passed on the command line
+override the preset.
+
+The database is initialized with report.url set to http://odoo:8069, where
+the PDF renderer reaches Odoo inside the container, and web.base.url frozen
+to --web-base-url when given.
+
+With --clone, the repository is cloned first and the environment is created for
+the clone, so the Odoo version can be picked up from the branch name. Private
+repositories use the SSH key or GitHub token saved with 'odooctl config'.
+
+Examples:
+  odooctl docker create
+  odooctl docker create --odoo-version 17.0 --modules sale,stock
+  odooctl docker create --template ecommerce --modules website_sale,stock,crm
+  odooctl docker create --db-name acme_prod
+  odooctl docker create --odoo-version 12.0 --postgres-version 12
+  odooctl docker create --network traefik_proxy
+  odooctl docker create --filestore-bind ./filestore
+  odooctl docker create --web-base-url https://erp.example.test
+  odooctl docker create -e --enterprise-repo git@gitlab.example.com:mirrors/enterprise.git
+  odooctl docker create --clone git@github.com:acme/odoo-addons.git --branch 17.0`,
+	RunE: runCreate,
 }
 
 func init() {
 	createCmd.Flags().StringVarP(&flagName, "name", "n", "", "Environment name (used as subdirectory, allows multiple environments per project)")
 	createCmd.Flags().StringVarP(&flagOdooVersion, "odoo-version", "v", "", "Odoo version ("+odoo.VersionsString()+")")
+	_ = createCmd.RegisterFlagCompletionFunc("odoo-version", cobra.FixedCompletions(odoo.OdooVersions, cobra.ShellCompDirectiveNoFileComp))
 	createCmd.Flags().StringVarP(&flagModules, "modules", "m", "", "Modules to install (comma-separated)")
 	createCmd.Flags().BoolVarP(&flagEnterprise, "enterprise", "e", false, "Include Odoo Enterprise")
+	createCmd.Flags().StringVar(&flagEnterpriseRepo, "enterprise-repo", "", "Clone Odoo Enterprise from this repository, e.g. a GitLab mirror (default: "+config.DefaultEnterpriseRepoURL+")")
 	createCmd.Flags().BoolVar(&flagWithoutDemo, "without-demo", false, "Initialize without demo data")
+	createCmd.Flags().StringSliceVar(&flagWithoutDemoFor, "without-demo-for", nil, "Initialize these modules without demo data, keeping it for the rest (comma-separated, before Odoo 19.0)")
 	createCmd.Flags().StringVarP(&flagPip, "pip", "p", "", "Extra pip packages (comma-separated or path to requirements.txt)")
 	createCmd.Flags().StringArrayVarP(&flagAddonsPaths, "addons-path", "a", nil, "Additional addons directories (can specify multiple times)")
 	createCmd.Flags().BoolVar(&flagAutoDiscoverPip, "auto-discover-deps", false, "Auto-discover Python dependencies from manifests during create")
 	createCmd.Flags().BoolVar(&flagCreateBrowser, "browser", false, "Include Playwright Chromium for AI inspection and Odoo browser tests (Odoo 15.0+)")
+	createCmd.Flags().StringArrayVar(&flagConfOptions, "conf", nil, "Extra odoo.conf option as key=value (can specify multiple times)")
+	createCmd.Flags().StringArrayVar(&flagCreateEnv, "env", nil, "Extra environment variable for the odoo container as KEY=VALUE (can specify multiple times)")
+	createCmd.Flags().StringVar(&flagCreateClone, "clone", "", "Clone this git repository and create the environment for it")
+	createCmd.Flags().StringVarP(&flagCreateBranch, "branch", "b", "", "Branch to clone (with --clone)")
+	createCmd.Flags().StringVar(&flagCreateCloneDir, "clone-dir", "", "Directory to clone into (with --clone, default: repository name)")
+	createCmd.Flags().StringVarP(&flagCreateTemplate, "template", "t", "", "Create from a preset saved with 'odooctl config preset save'")
+	createCmd.Flags().StringVar(&flagCreateTemplate, "from-template", "", "Alias for --template")
+	createCmd.Flags().StringVar(&flagPostgresVersion, "postgres-version", "", "Postgres image tag for the database, e.g. 16 or 16-alpine (default: "+config.DefaultPostgresVersion+", or the newest the Odoo version supports)")
+	createCmd.Flags().StringVar(&flagCreateNetwork, "network", "", "Also attach the odoo service to this existing Docker network (e.g. a shared reverse proxy's)")
+	createCmd.Flags().StringVar(&flagFilestoreBind, "filestore-bind", "", "Keep the filestore in this host directory instead of a Docker volume")
+	createCmd.Flags().StringVar(&flagReportURL, "report-url", "", "report.url set when the database is initialized (default: "+config.DefaultReportURL+")")
+	createCmd.Flags().StringVar(&flagWebBaseURL, "web-base-url", "", "web.base.url set and frozen when the database is initialized")
+	createCmd.Flags().StringVar(&flagCreateDBName, "db-name", "", "Database name (default: odoo-<version>, e.g. odoo-170)")
 	createCmd.Flags().BoolVar(&flagCreateJSON, "json", false, "Print JSON output")
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	if flagProject != "" || flagEnvironment != "" {
+		return fmt.Errorf("--project and --environment select an existing environment; create works from the project directory")
+	}
 	cwd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
+	if flagCreateDBName != "" {
+		if err := config.ValidateDBName(flagCreateDBName); err != nil {
+			return err
+		}
+	}
+	if flagPostgresVersion != "" {
+		if err := config.ValidatePostgresVersion(flagPostgresVersion); err != nil {
+			return err
+		}
+	}
+	for _, value := range []string{flagReportURL, flagWebBaseURL} {
+		if value == "" {
+			continue
+		}
+		if err := config.ValidateParamURL(value); err != nil {
+			return err
+		}
+	}
+	if flagCreateNetwork != "" {
+		if err := config.ValidateNetworkName(flagCreateNetwork); err != nil {
+			return err
+		}
+		if err := docker.CheckNetwork(flagCreateNetwork); err != nil && !flagCreateJSON {
+			fmt.Printf("%s %v\n", color.YellowString("⚠️"), err)
+		}
+	}
+	enterpriseRepo, err := config.ParseEnterpriseRepo(config.DefaultEnterpriseRepoURL)
+	if err != nil {
+		return err
+	}
+	if flagEnterpriseRepo != "" {
+		if enterpriseRepo, err = config.ParseEnterpriseRepo(flagEnterpriseRepo); err != nil {
+			return err
+		}
+	}
+
+	if flagCreateClone != "" {
+		cwd, err = cloneProject(flagCreateClone, flagCreateBranch, flagCreateCloneDir)
+		if err != nil {
+			return err
+		}
+	} else if flagCreateBranch != "" || flagCreateCloneDir != "" {
+		return fmt.Errorf("--branch and --clone-dir require --clone")
+	}
+
+	var preset *config.Preset
+	if flagCreateTemplate != "" {
+		preset, err = config.LoadPreset(flagCreateTemplate)
+		if err != nil {
+			return err
+		}
+		applyCreatePreset(cmd, preset)
+		if !flagCreateJSON {
+			fmt.Printf("%s Using preset %s\n", color.CyanString("ℹ"), preset.Name)
+		}
+	}
 
 	// Detect project context
 	ctx := project.Detect(cwd)
+	if ctx.Warning != "" && !flagCreateJSON {
+		fmt.Printf("%s %s\n", color.YellowString("⚠️"), ctx.Warning)
+	}
 
 	// Handle --name flag based on git repo context
 	// In git repo: --name overrides project name (existing behavior preserved for backwards compat)
@@ -95,12 +224,31 @@ func runCreate(cmd *cobra.Command, args []string) error {
 			ctx.Branch = ctx.Name
 		}
 	}
+	if !config.IsValidName(ctx.Name) || config.IsReservedProjectName(ctx.Name) {
+		return fmt.Errorf("invalid project name %q (use letters, digits, '-', '_' or '.', except %q and %q)", ctx.Name, config.ProjectLinksDirName, config.PresetsDirName)
+	}
+	if !config.IsValidName(ctx.Branch) {
+		return fmt.Errorf("invalid environment name %q (use letters, digits, '-', '_' or '.', e.g. %q)", ctx.Branch, config.SanitizeName(ctx.Branch))
+	}
 
 	if flagOdooVersion != "" {
-		ctx.OdooVersion = flagOdooVersion
+		version, _ := odoo.NormalizeVersion(flagOdooVersion)
+		// Majors newer than the supported ones reuse the newest templates
+		if _, err := templates.CheckVersion(version); err != nil {
+			return fmt.Errorf("invalid --odoo-version %q (valid: %s)", flagOdooVersion, odoo.VersionsString())
+		}
+		ctx.OdooVersion = version
 	}
 
-	// Prompt for version if not determined
+	// Fall back to the configured default, then prompt
+	if ctx.OdooVersion == "" {
+		if globalCfg, err := config.LoadGlobalConfig(); err == nil && globalCfg.DefaultOdooVersion != "" {
+			ctx.OdooVersion = globalCfg.DefaultOdooVersion
+			if !flagCreateJSON {
+				fmt.Printf("%s Using default Odoo version %s (odooctl config set default-odoo-version)\n", color.CyanString("ℹ"), ctx.OdooVersion)
+			}
+		}
+	}
 	if ctx.OdooVersion == "" {
 		version, err := prompt.SelectVersion()
 		if err != nil {
@@ -111,11 +259,22 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if flagCreateBrowser && !browser.SupportsVersion(ctx.OdooVersion) {
 		return fmt.Errorf("--browser is supported for Odoo 15.0+ environments; current version is %s", ctx.OdooVersion)
 	}
+	postgresVersion := flagPostgresVersion
+	if postgresVersion == "" {
+		postgresVersion = config.DefaultPostgresVersionFor(ctx.OdooVersion)
+	} else if !flagCreateJSON {
+		if warning := config.PostgresCompatibilityWarning(ctx.OdooVersion, postgresVersion); warning != "" {
+			fmt.Printf("%s %s\n", color.YellowString("⚠️"), warning)
+		}
+	}
 
 	// Check for existing environment
 	if config.EnvironmentExists(ctx.Name, ctx.Branch) {
 		return fmt.Errorf("environment '%s/%s' already exists. Use a different --name or remove the existing environment with 'odooctl docker reset'", ctx.Name, ctx.Branch)
 	}
+	if err := config.CheckComposeProjectFree(config.NewComposeProjectName(ctx.Name, ctx.Branch), ctx.Name, ctx.Branch); err != nil {
+		return fmt.Errorf("%w. Use a different --name", err)
+	}
 
 	// Parse modules
 	var modules []string
@@ -126,8 +285,37 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	confOptions, err := config.ParseConfOptions(flagConfOptions)
+	if err != nil {
+		return err
+	}
+	if err := dropClearedConfOptions(confOptions, preset); err != nil {
+		return err
+	}
+	if len(confOptions) == 0 {
+		confOptions = nil
+	}
+
+	extraEnv, err := config.ParseEnvVars(flagCreateEnv)
+	if err != nil {
+		return err
+	}
+	if len(extraEnv) == 0 {
+		extraEnv = nil
+	}
+
 	// Parse pip packages (supports comma-separated or requirements.txt)
 	pipPkgs := deps.ParsePipPackages(flagPip)
+	if preset != nil && !cmd.Flags().Changed("pip") {
+		pipPkgs = append([]string(nil), preset.PipPackages...)
+	}
+
+	filestoreBind := ""
+	if flagFilestoreBind != "" {
+		if filestoreBind, err = prepareFilestoreBind(flagFilestoreBind); err != nil {
+			return err
+		}
+	}
 
 	// Parse and validate addons paths
 	var addonsPaths []string
@@ -153,34 +341,60 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		pipPkgs = append(pipPkgs, discoveredPkgs...)
 	}
 
+	withoutDemoModules := withoutDemoModules(ctx.OdooVersion, append([]string{ctx.Root}, addonsPaths...))
+
 	// Handle enterprise authentication if needed
 	var enterpriseToken, enterpriseSSHKeyPath string
+	if flagEnterpriseRepo != "" && !flagEnterprise {
+		return fmt.Errorf("--enterprise-repo requires --enterprise")
+	}
 	if flagEnterprise {
 		var err error
-		enterpriseToken, enterpriseSSHKeyPath, err = promptEnterpriseAuth()
+		enterpriseToken, enterpriseSSHKeyPath, err = promptEnterpriseAuth(enterpriseRepo)
 		if err != nil {
 			return fmt.Errorf("enterprise authentication failed: %w", err)
 		}
 	}
 
+	// Tokens saved in the global config are referenced, not copied
+	useGlobalToken := false
+	if enterpriseToken != "" {
+		if globalCfg, err := config.LoadGlobalConfig(); err == nil && globalCfg.GitHubToken == enterpriseToken {
+			enterpriseToken = ""
+			useGlobalToken = true
+		}
+	}
+
 	// Build state
 	state := &config.State{
-		ProjectName:           ctx.Name,
-		OdooVersion:           ctx.OdooVersion,
-		Branch:                ctx.Branch,
-		IsGitRepo:             ctx.IsGitRepo,
-		ProjectRoot:           ctx.Root,
-		Modules:               modules,
-		Enterprise:            flagEnterprise,
-		EnterpriseGitHubToken: enterpriseToken,
-		EnterpriseSSHKeyPath:  enterpriseSSHKeyPath,
-		WithoutDemo:           flagWithoutDemo,
-		PipPackages:           pipPkgs,
-		BrowserEnabled:        flagCreateBrowser,
-		BrowserProvider:       browserProvider(flagCreateBrowser),
-		AddonsPaths:           addonsPaths,
-		Ports:                 config.CalculatePorts(ctx.OdooVersion),
-		CreatedAt:             time.Now(),
+		ProjectName:             ctx.Name,
+		ComposeProject:          config.NewComposeProjectName(ctx.Name, ctx.Branch),
+		OdooVersion:             ctx.OdooVersion,
+		Branch:                  ctx.Branch,
+		IsGitRepo:               ctx.IsGitRepo,
+		ProjectRoot:             ctx.Root,
+		Modules:                 modules,
+		Enterprise:              flagEnterprise,
+		EnterpriseGitHubToken:   enterpriseToken,
+		EnterpriseSSHKeyPath:    enterpriseSSHKeyPath,
+		EnterpriseRepoURL:       flagEnterpriseRepo,
+		UseGlobalEnterpriseAuth: useGlobalToken,
+		WithoutDemo:             flagWithoutDemo,
+		WithoutDemoModules:      withoutDemoModules,
+		PipPackages:             pipPkgs,
+		BrowserEnabled:          flagCreateBrowser,
+		BrowserProvider:         browserProvider(flagCreateBrowser),
+		AddonsPaths:             addonsPaths,
+		ExtraConfOptions:        confOptions,
+		ExtraEnv:                extraEnv,
+		DBNameOverride:          flagCreateDBName,
+		PostgresVersion:         postgresVersion,
+		ExternalNetwork:         flagCreateNetwork,
+		FilestoreBindPath:       filestoreBind,
+		ReportURL:               flagReportURL,
+		WebBaseURL:              flagWebBaseURL,
+		Ports:                   config.FindAvailablePorts(ctx.OdooVersion, ctx.Name, ctx.Branch),
+		CreatedAt:               time.Now(),
 	}
 
 	// Render templates
@@ -196,14 +410,182 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save project link: %w", err)
 	}
 
+	ignored, err := git.EnsureIgnored(state.ProjectRoot, state.IsGitRepo, gitignoreEntries(state))
+	if err != nil && !flagCreateJSON {
+		fmt.Printf("%s Failed to update .gitignore: %v\n", color.YellowString("⚠️"), err)
+	}
+
 	if flagCreateJSON {
-		return output.PrintJSON(buildCreateReport(state))
+		report := buildCreateReport(state)
+		report.Template = flagCreateTemplate
+		return output.PrintJSON(report)
 	}
 	printCreateSummary(state)
+	if len(ignored) > 0 {
+		fmt.Printf("\n%s Added to .gitignore: %s\n", color.CyanString("ℹ"), strings.Join(ignored, ", "))
+	}
+	if flagCreateClone != "" {
+		fmt.Printf("\n  Run these from the cloned project: %s\n", color.CyanString("cd "+state.ProjectRoot))
+	}
+
+	return nil
+}
+
+// withoutDemoModules cleans up --without-demo-for and warns about modules
+// that are not found in dirs or that the flag cannot apply to
+func withoutDemoModules(version string, dirs []string) []string {
+	if len(flagWithoutDemoFor) == 0 {
+		return nil
+	}
+	yellow := color.New(color.FgYellow).SprintFunc()
+	if flagWithoutDemo {
+		fmt.Printf("%s --without-demo already skips demo data for all modules; ignoring --without-demo-for\n", yellow("⚠️"))
+		return nil
+	}
+	if !templates.SupportsWithoutDemoModules(version) {
+		fmt.Printf("%s Odoo %s loads demo data for all modules or none; ignoring --without-demo-for\n", yellow("⚠️"), version)
+		return nil
+	}
+
+	local := make(map[string]bool)
+	for _, dir := range dirs {
+		found, _ := module.FindModules(dir)
+		for _, mod := range found {
+			local[mod] = true
+		}
+	}
+	var modules []string
+	for _, mod := range flagWithoutDemoFor {
+		mod = strings.TrimSpace(mod)
+		if mod == "" {
+			continue
+		}
+		if !local[mod] {
+			fmt.Printf("%s --without-demo-for: module %s was not found in the project or its addons paths\n", yellow("⚠️"), mod)
+		}
+		modules = append(modules, mod)
+	}
+	return modules
+}
+
+// gitignoreEntries lists the files odooctl may write into the project: the
+// marker of older versions, the default coverage report and a bind-mounted
+// filestore inside the project
+func gitignoreEntries(state *config.State) []string {
+	entries := []string{"/.odooctl", "/coverage.xml"}
+	if state.FilestoreBindPath != "" {
+		if rel, err := filepath.Rel(state.ProjectRoot, state.FilestoreBindPath); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			entries = append(entries, "/"+filepath.ToSlash(rel)+"/")
+		}
+	}
+	return entries
+}
 
+// prepareFilestoreBind resolves the --filestore-bind directory, creating it
+// when missing. A new directory is made writable for everyone, since the
+// container's odoo user rarely has the host user's uid.
+func prepareFilestoreBind(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid filestore path %q: %w", path, err)
+	}
+	info, err := os.Stat(absPath)
+	if err == nil {
+		if !info.IsDir() {
+			return "", fmt.Errorf("filestore path %s is not a directory", absPath)
+		}
+		return absPath, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := os.MkdirAll(absPath, 0755); err != nil {
+		return "", fmt.Errorf("failed to create filestore directory: %w", err)
+	}
+	if err := os.Chmod(absPath, 0777); err != nil {
+		return "", fmt.Errorf("failed to make the filestore directory writable: %w", err)
+	}
+	return absPath, nil
+}
+
+// dropClearedConfOptions removes the --conf key= entries that clear an option
+// set by preset. Any other empty value is an error, since a new environment
+// has no option to remove.
+func dropClearedConfOptions(options map[string]string, preset *config.Preset) error {
+	var presetOptions map[string]string
+	if preset != nil {
+		presetOptions, _ = config.ParseConfOptions(preset.ConfOptions)
+	}
+	for key, value := range options {
+		if value != "" {
+			continue
+		}
+		if presetOptions[key] == "" {
+			return fmt.Errorf("--conf %s= has no value; pass key=value, or use 'odooctl docker reconfigure --conf %s=' to remove an option", key, key)
+		}
+		delete(options, key)
+	}
 	return nil
 }
 
+// applyCreatePreset fills the create flags the user did not pass from preset.
+// --conf entries are merged, with the command line winning per key.
+// Pip packages are applied by the caller since the flag also accepts a file.
+func applyCreatePreset(cmd *cobra.Command, preset *config.Preset) {
+	flags := cmd.Flags()
+	if !flags.Changed("odoo-version") && preset.OdooVersion != "" {
+		flagOdooVersion = preset.OdooVersion
+	}
+	if !flags.Changed("modules") && len(preset.Modules) > 0 {
+		flagModules = strings.Join(preset.Modules, ",")
+	}
+	if !flags.Changed("addons-path") {
+		flagAddonsPaths = append([]string(nil), preset.AddonsPaths...)
+	}
+	flagConfOptions = append(append([]string(nil), preset.ConfOptions...), flagConfOptions...)
+	if !flags.Changed("enterprise") {
+		flagEnterprise = preset.Enterprise
+	}
+	if !flags.Changed("without-demo") {
+		flagWithoutDemo = preset.WithoutDemo
+	}
+	if !flags.Changed("browser") {
+		flagCreateBrowser = preset.Browser
+	}
+	if !flags.Changed("auto-discover-deps") {
+		flagAutoDiscoverPip = preset.AutoDiscoverDeps
+	}
+}
+
+// cloneProject clones url into dir (default: the repository name under the
+// current directory) using saved credentials, and returns the clone path.
+func cloneProject(url, branch, dir string) (string, error) {
+	if dir == "" {
+		dir = git.RepoNameFromURL(url)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(absDir); err == nil {
+		return "", fmt.Errorf("clone target already exists: %s", absDir)
+	}
+
+	globalCfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		return "", err
+	}
+	opts := git.CloneOptions{Branch: branch, SSHKeyPath: globalCfg.SSHKeyPath, Token: globalCfg.GitHubToken}
+
+	if !flagCreateJSON {
+		fmt.Printf("%s Cloning %s into %s\n", color.CyanString("📥"), url, absDir)
+	}
+	if err := git.Clone(url, absDir, opts); err != nil {
+		return "", err
+	}
+	return absDir, nil
+}
+
 func browserProvider(enabled bool) string {
 	if enabled {
 		return browser.ProviderPlaywrightChromium
@@ -214,7 +596,7 @@ func browserProvider(enabled bool) string {
 // promptEnterpriseAuth returns (token, sshKeyPath, error).
 // It loads global config first and offers to reuse saved credentials.
 // Any new credentials entered are persisted to global config for future use.
-func promptEnterpriseAuth() (string, string, error) {
+func promptEnterpriseAuth(repo config.EnterpriseRepo) (string, string, error) {
 	green := color.New(color.FgGreen).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
@@ -225,7 +607,7 @@ func promptEnterpriseAuth() (string, string, error) {
 	}
 
 	fmt.Println()
-	fmt.Printf("%s Enterprise access requires authentication\n\n", green("🔐"))
+	fmt.Printf("%s Enterprise access to %s requires authentication\n\n", green("🔐"), repo.Host)
 
 	// If we already have a saved token or SSH key, offer to reuse
 	if globalCfg.GitHubToken != "" {
@@ -282,12 +664,12 @@ func promptEnterpriseAuth() (string, string, error) {
 	}
 
 	if choice == "1" {
-		return promptSSHKey(globalCfg, detectedSSHKeys)
+		return promptSSHKey(globalCfg, detectedSSHKeys, repo)
 	}
-	return promptToken(globalCfg)
+	return promptToken(globalCfg, repo)
 }
 
-func promptSSHKey(globalCfg *config.GlobalConfig, detectedKeys []string) (string, string, error) {
+func promptSSHKey(globalCfg *config.GlobalConfig, detectedKeys []string, repo config.EnterpriseRepo) (string, string, error) {
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 
@@ -352,23 +734,33 @@ func promptSSHKey(globalCfg *config.GlobalConfig, detectedKeys []string) (string
 		}
 	}
 
-	fmt.Printf("%s Make sure this key is added to GitHub: https://github.com/settings/keys\n\n", yellow("ℹ"))
+	if repo.IsGitHub() {
+		fmt.Printf("%s Make sure this key is added to GitHub: https://github.com/settings/keys\n\n", yellow("ℹ"))
+	} else {
+		fmt.Printf("%s Make sure this key has read access to %s\n\n", yellow("ℹ"), repo.SSHURL())
+	}
 	return "", keyPath, nil
 }
 
-func promptToken(globalCfg *config.GlobalConfig) (string, string, error) {
+func promptToken(globalCfg *config.GlobalConfig, repo config.EnterpriseRepo) (string, string, error) {
 	green := color.New(color.FgGreen).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 
 	fmt.Println()
-	fmt.Printf("%s To create a Personal Access Token:\n", cyan("ℹ"))
-	fmt.Printf("  1. Visit: %s\n", cyan("https://github.com/settings/tokens/new"))
-	fmt.Printf("  2. Set description: %s\n", cyan("Odoo Enterprise Access"))
-	fmt.Printf("  3. Select scope: %s\n", cyan("repo (Full control of private repositories)"))
-	fmt.Printf("  4. Click %s and copy the token\n\n", cyan("'Generate token'"))
+	tokenPrompt := "Enter GitHub Personal Access Token:"
+	if repo.IsGitHub() {
+		fmt.Printf("%s To create a Personal Access Token:\n", cyan("ℹ"))
+		fmt.Printf("  1. Visit: %s\n", cyan("https://github.com/settings/tokens/new"))
+		fmt.Printf("  2. Set description: %s\n", cyan("Odoo Enterprise Access"))
+		fmt.Printf("  3. Select scope: %s\n", cyan("repo (Full control of private repositories)"))
+		fmt.Printf("  4. Click %s and copy the token\n\n", cyan("'Generate token'"))
+	} else {
+		fmt.Printf("%s Create an access token with read access to %s on %s\n\n", cyan("ℹ"), repo.Path, repo.Host)
+		tokenPrompt = fmt.Sprintf("Enter %s access token:", repo.Host)
+	}
 
-	token, err := prompt.InputPassword("Enter GitHub Personal Access Token:")
+	token, err := prompt.InputPassword(tokenPrompt)
 	if err != nil {
 		return "", "", err
 	}
@@ -378,7 +770,8 @@ func promptToken(globalCfg *config.GlobalConfig) (string, string, error) {
 		return "", "", fmt.Errorf("token cannot be empty")
 	}
 
-	if !strings.HasPrefix(token, "ghp_") && !strings.HasPrefix(token, "github_pat_") {
+	// Only GitHub tokens have a known format
+	if repo.IsGitHub() && !strings.HasPrefix(token, "ghp_") && !strings.HasPrefix(token, "github_pat_") {
 		fmt.Printf("\n%s Token doesn't match expected format (should start with 'ghp_' or 'github_pat_')\n", yellow("⚠"))
 		confirm, err := prompt.Confirm("Continue anyway?", false)
 		if err != nil {
@@ -417,6 +810,7 @@ func printCreateSummary(state *config.State) {
 	fmt.Printf("  Project:     %s\n", cyan(state.ProjectName))
 	fmt.Printf("  Environment: %s\n", cyan(state.Branch))
 	fmt.Printf("  Odoo:        %s\n", cyan(state.OdooVersion))
+	fmt.Printf("  Postgres:    %s\n", cyan(state.PostgresImageVersion()))
 	fmt.Printf("  Port:        %s\n", cyan(fmt.Sprintf("http://localhost:%d", state.Ports.Odoo)))
 	fmt.Printf("  Mailhog:     %s\n", cyan(fmt.Sprintf("http://localhost:%d", state.Ports.Mailhog)))
 
@@ -425,30 +819,89 @@ func printCreateSummary(state *config.State) {
 
 	if state.Enterprise {
 		authMethod := "SSH Agent"
-		if state.EnterpriseGitHubToken != "" {
+		if state.UseGlobalEnterpriseAuth {
+			authMethod = "saved GitHub Token"
+		} else if state.EnterpriseGitHubToken != "" {
 			authMethod = "GitHub Token"
 		} else if state.EnterpriseSSHKeyPath != "" {
 			authMethod = fmt.Sprintf("SSH Key (%s)", state.EnterpriseSSHKeyPath)
 		}
-		fmt.Printf("  Enterprise:  %s (%s)\n", green("✓"), authMethod)
+		repoHost := "github.com"
+		if repo, err := state.EnterpriseRepo(); err == nil {
+			repoHost = repo.Host
+		}
+		fmt.Printf("  Enterprise:  %s (%s from %s)\n", green("✓"), authMethod, repoHost)
+	}
+
+	if state.ExternalNetwork != "" {
+		fmt.Printf("  Network:     %s (external)\n", cyan(state.ExternalNetwork))
+	}
+
+	if state.FilestoreBindPath != "" {
+		fmt.Printf("  Filestore:   %s\n", cyan(state.FilestoreBindPath))
+	}
+
+	if state.ReportURL != "" {
+		fmt.Printf("  Report URL:  %s\n", cyan(state.ReportURL))
+	}
+
+	if state.WebBaseURL != "" {
+		fmt.Printf("  Base URL:    %s\n", cyan(state.WebBaseURL))
 	}
 
 	if len(state.AddonsPaths) > 0 {
 		fmt.Printf("  Addons:      %d custom path(s)\n", len(state.AddonsPaths))
 	}
 
+	if len(state.ExtraConfOptions) > 0 {
+		fmt.Printf("  Conf:        %d extra odoo.conf option(s)\n", len(state.ExtraConfOptions))
+	}
+
+	if len(state.ExtraEnv) > 0 {
+		fmt.Println("  Env:")
+		for _, key := range sortedKeys(state.ExtraEnv) {
+			fmt.Printf("    %s=%s\n", key, config.MaskEnvValue(key, state.ExtraEnv[key]))
+		}
+	}
+
 	fmt.Println()
 	fmt.Println("Next steps:")
 	fmt.Printf("  1. %s  # Build image and initialize database\n", cyan("odooctl docker run -i"))
 	fmt.Printf("  2. %s   # View container status\n", cyan("odooctl docker status"))
 }
 
+// maskedEnv returns env with secret-looking values masked
+func maskedEnv(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	masked := make(map[string]string, len(env))
+	for key, value := range env {
+		masked[key] = config.MaskEnvValue(key, value)
+	}
+	return masked
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func buildCreateReport(state *config.State) createReport {
 	dir, _ := config.EnvironmentDir(state.ProjectName, state.Branch)
-	authMethod := ""
+	authMethod, enterpriseRepo := "", ""
 	if state.Enterprise {
+		enterpriseRepo = state.EnterpriseRepoURL
+		if enterpriseRepo == "" {
+			enterpriseRepo = config.DefaultEnterpriseRepoURL
+		}
 		authMethod = "ssh-agent"
-		if state.EnterpriseGitHubToken != "" {
+		if state.UsesEnterpriseToken() {
 			authMethod = "github-token"
 		} else if state.EnterpriseSSHKeyPath != "" {
 			authMethod = "ssh-key"
@@ -459,13 +912,21 @@ func buildCreateReport(state *config.State) createReport {
 		Environment:     state.Branch,
 		OdooVersion:     state.OdooVersion,
 		Database:        state.DBName(),
+		PostgresVersion: state.PostgresImageVersion(),
+		ExternalNetwork: state.ExternalNetwork,
+		FilestoreBind:   state.FilestoreBindPath,
+		ReportURL:       state.ReportURLParam(),
+		WebBaseURL:      state.WebBaseURL,
 		EnvDir:          dir,
 		Ports:           state.Ports,
 		Modules:         append([]string{}, state.Modules...),
 		AddonsPaths:     append([]string{}, state.AddonsPaths...),
 		PipPackages:     append([]string{}, state.PipPackages...),
+		ConfOptions:     state.ExtraConfOptions,
+		Env:             maskedEnv(state.ExtraEnv),
 		Enterprise:      state.Enterprise,
 		AuthMethod:      authMethod,
+		EnterpriseRepo:  enterpriseRepo,
 		Browser:         state.BrowserEnabled,
 		BrowserProvider: state.BrowserProvider,
 		NextSteps: []string{
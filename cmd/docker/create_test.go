@@ -1,6 +1,12 @@
 package docker
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/spf13/cobra"
+)
 
 func TestCreateDoesNotAutoDiscoverDepsByDefault(t *testing.T) {
 	flag := createCmd.Flags().Lookup("auto-discover-deps")
@@ -11,3 +17,74 @@ func TestCreateDoesNotAutoDiscoverDepsByDefault(t *testing.T) {
 		t.Fatalf("auto-discover-deps default = %q, want false", flag.DefValue)
 	}
 }
+
+func TestApplyCreatePresetKeepsExplicitFlags(t *testing.T) {
+	t.Cleanup(func() {
+		flagOdooVersion, flagModules, flagConfOptions, flagAddonsPaths = "", "", nil, nil
+		flagEnterprise, flagWithoutDemo, flagCreateBrowser, flagAutoDiscoverPip = false, false, false, false
+	})
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&flagModules, "modules", "", "")
+	cmd.Flags().StringArrayVar(&flagConfOptions, "conf", nil, "")
+	cmd.Flags().BoolVar(&flagWithoutDemo, "without-demo", false, "")
+	for _, arg := range [][2]string{{"modules", "crm"}, {"conf", "workers=0"}, {"without-demo", "false"}} {
+		if err := cmd.Flags().Set(arg[0], arg[1]); err != nil {
+			t.Fatalf("Set(%s) error = %v", arg[0], err)
+		}
+	}
+
+	applyCreatePreset(cmd, &config.Preset{
+		Name:        "ecommerce",
+		OdooVersion: "17.0",
+		Modules:     []string{"website_sale", "stock"},
+		ConfOptions: []string{"workers=2", "limit_time_cpu=600"},
+		WithoutDemo: true,
+		Enterprise:  true,
+	})
+
+	if flagOdooVersion != "17.0" || !flagEnterprise {
+		t.Fatalf("preset values not applied: version=%q enterprise=%v", flagOdooVersion, flagEnterprise)
+	}
+	if flagModules != "crm" || flagWithoutDemo {
+		t.Fatalf("explicit flags overridden: modules=%q without-demo=%v", flagModules, flagWithoutDemo)
+	}
+	options, err := config.ParseConfOptions(flagConfOptions)
+	if err != nil {
+		t.Fatalf("ParseConfOptions() error = %v", err)
+	}
+	if options["workers"] != "0" || options["limit_time_cpu"] != "600" {
+		t.Fatalf("conf options = %v, want workers=0 from the flag and limit_time_cpu from the preset", options)
+	}
+}
+
+func TestGitignoreEntries(t *testing.T) {
+	state := &config.State{ProjectRoot: "/src/shop", FilestoreBindPath: "/src/shop/data/filestore"}
+	want := []string{"/.odooctl", "/coverage.xml", "/data/filestore/"}
+	if got := gitignoreEntries(state); !reflect.DeepEqual(got, want) {
+		t.Fatalf("gitignoreEntries() = %v, want %v", got, want)
+	}
+
+	state.FilestoreBindPath = "/srv/filestore"
+	if got := gitignoreEntries(state); len(got) != 2 {
+		t.Fatalf("gitignoreEntries(outside project) = %v, want no filestore entry", got)
+	}
+}
+
+func TestDropClearedConfOptions(t *testing.T) {
+	preset := &config.Preset{ConfOptions: []string{"workers=4"}}
+	options := map[string]string{"workers": "", "limit_time_real": "600"}
+	if err := dropClearedConfOptions(options, preset); err != nil {
+		t.Fatalf("dropClearedConfOptions() error = %v", err)
+	}
+	if want := map[string]string{"limit_time_real": "600"}; !reflect.DeepEqual(options, want) {
+		t.Errorf("options = %v, want %v", options, want)
+	}
+
+	if err := dropClearedConfOptions(map[string]string{"dbfilter": ""}, preset); err == nil {
+		t.Error("dropClearedConfOptions() accepted an empty value that clears nothing")
+	}
+	if err := dropClearedConfOptions(map[string]string{"dbfilter": ""}, nil); err == nil {
+		t.Error("dropClearedConfOptions() accepted an empty value without a preset")
+	}
+}
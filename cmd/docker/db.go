@@ -10,8 +10,10 @@ var flagDatabase string
 var dbCmd = &cobra.Command{
 	Use:   "db",
 	Short: "Open PostgreSQL shell",
-	Long:  `Opens an interactive PostgreSQL shell connected to the Odoo database.`,
-	RunE:  runDB,
+	Long: `Opens an interactive PostgreSQL shell connected to the Odoo database.
+
+Use 'odooctl docker db size' to see the database size and its largest tables.`,
+	RunE: runDB,
 }
 
 func init() {
@@ -0,0 +1,169 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	dockerlib "github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagDBSizeDatabase string
+	flagDBSizeTop      int
+	flagDBSizeJSON     bool
+)
+
+type dbSizeReport struct {
+	Database  string      `json:"database"`
+	SizeBytes int64       `json:"size_bytes"`
+	Tables    []tableSize `json:"tables"`
+}
+
+type tableSize struct {
+	Name string `json:"name"`
+	// TotalBytes includes indexes and TOAST data
+	TotalBytes int64 `json:"total_bytes"`
+	DataBytes  int64 `json:"data_bytes"`
+	IndexBytes int64 `json:"index_bytes"`
+	// Rows is PostgreSQL's estimate from the last ANALYZE
+	Rows int64 `json:"rows"`
+}
+
+var dbSizeCmd = &cobra.Command{
+	Use:          "size",
+	Short:        "Show the database size and its largest tables",
+	SilenceUsage: true,
+	Long: `Reports the size of the Odoo database and its largest tables, with the space
+taken by their indexes. Useful before a dump and to spot tables that keep
+growing, such as mail_message, mail_tracking_value or bus_bus.
+
+Attachments stored in the filestore are not part of the database size.
+
+Examples:
+  odooctl docker db size
+  odooctl docker db size --top 25
+  odooctl docker db size --json`,
+	Args: cobra.NoArgs,
+	RunE: runDBSize,
+}
+
+func init() {
+	dbSizeCmd.Flags().StringVarP(&flagDBSizeDatabase, "database", "d", "", "Database name (auto-detected if omitted)")
+	dbSizeCmd.Flags().IntVarP(&flagDBSizeTop, "top", "n", 10, "Number of tables to list")
+	dbSizeCmd.Flags().BoolVar(&flagDBSizeJSON, "json", false, "Print JSON output")
+	dbCmd.AddCommand(dbSizeCmd)
+}
+
+func runDBSize(cmd *cobra.Command, args []string) error {
+	if flagDBSizeTop < 1 {
+		return fmt.Errorf("--top must be at least 1")
+	}
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	database := flagDBSizeDatabase
+	if database == "" {
+		database = state.DBName()
+	}
+
+	text, err := psqlQuery(state, database, "SELECT pg_database_size(current_database())")
+	if err != nil {
+		return err
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil {
+		return fmt.Errorf("unexpected database size %q", strings.TrimSpace(text))
+	}
+
+	text, err = psqlQuery(state, database, largestTablesQuery(flagDBSizeTop))
+	if err != nil {
+		return err
+	}
+	tables, err := parseTableSizes(text)
+	if err != nil {
+		return err
+	}
+
+	report := dbSizeReport{Database: database, SizeBytes: size, Tables: tables}
+	if flagDBSizeJSON {
+		return output.PrintJSON(report)
+	}
+
+	fmt.Printf("\n%s Database %s: %s\n\n", color.CyanString("⚙"), color.CyanString(database), formatBytes(report.SizeBytes))
+	table := output.Table{Headers: []string{"table", "total", "data", "indexes", "rows", "share"}}
+	for _, t := range tables {
+		share := ""
+		if size > 0 {
+			share = fmt.Sprintf("%.1f%%", float64(t.TotalBytes)*100/float64(size))
+		}
+		table.Rows = append(table.Rows, []string{t.Name, formatBytes(t.TotalBytes), formatBytes(t.DataBytes), formatBytes(t.IndexBytes), strconv.FormatInt(t.Rows, 10), share})
+	}
+	if err := output.WriteTable(os.Stdout, table); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+// psqlQuery runs query in the db container and returns unaligned,
+// tab-separated rows without headers. Only stdout is parsed; stderr ends up
+// in the error.
+func psqlQuery(state *config.State, database, query string) (string, error) {
+	text, err := dockerlib.ComposeStdout(state, "exec", "-T", "db", "psql", "-U", "odoo", "-d", database, "-t", "-A", "-F", "\t", "-c", query)
+	if err != nil {
+		return "", fmt.Errorf("failed to query database %s (is the environment running?): %w", database, err)
+	}
+	return text, nil
+}
+
+// largestTablesQuery lists the top tables of the public schema by total size
+func largestTablesQuery(top int) string {
+	return fmt.Sprintf(`SELECT c.relname, pg_total_relation_size(c.oid), pg_relation_size(c.oid), pg_indexes_size(c.oid), GREATEST(c.reltuples, 0)::bigint
+FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'm') AND n.nspname = 'public'
+ORDER BY 2 DESC LIMIT %d`, top)
+}
+
+func parseTableSizes(text string) ([]tableSize, error) {
+	tables := []tableSize{}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected psql output: %q", line)
+		}
+		var numbers [4]int64
+		for i, field := range fields[1:] {
+			n, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected psql output: %q", line)
+			}
+			numbers[i] = n
+		}
+		tables = append(tables, tableSize{Name: fields[0], TotalBytes: numbers[0], DataBytes: numbers[1], IndexBytes: numbers[2], Rows: numbers[3]})
+	}
+	return tables, nil
+}
+
+// formatBytes renders a byte count with a binary unit (e.g. "1.5 GB")
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for value := n / unit; value >= unit; value /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,48 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTableSizes(t *testing.T) {
+	text := "mail_message\t52428800\t41943040\t10485760\t120000\nir_attachment\t8192\t8192\t0\t-1\n"
+	tables, err := parseTableSizes(text)
+	if err != nil {
+		t.Fatalf("parseTableSizes() error = %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("parseTableSizes() returned %d tables, want 2", len(tables))
+	}
+	if got := tables[0]; got.Name != "mail_message" || got.TotalBytes != 52428800 || got.IndexBytes != 10485760 || got.Rows != 120000 {
+		t.Fatalf("parseTableSizes()[0] = %+v", got)
+	}
+
+	if tables, err := parseTableSizes("\n"); err != nil || len(tables) != 0 {
+		t.Fatalf("parseTableSizes(empty) = %v, %v; want no tables", tables, err)
+	}
+	if _, err := parseTableSizes("ERROR:  relation does not exist"); err == nil {
+		t.Fatal("parseTableSizes() accepted malformed output")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{
+		0:                      "0 B",
+		1023:                   "1023 B",
+		1536:                   "1.5 KB",
+		52428800:               "50.0 MB",
+		3 * 1024 * 1024 * 1024: "3.0 GB",
+	}
+	for n, want := range cases {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestLargestTablesQueryLimit(t *testing.T) {
+	if query := largestTablesQuery(25); !strings.HasSuffix(query, "LIMIT 25") {
+		t.Fatalf("largestTablesQuery() = %q, want LIMIT 25", query)
+	}
+}
@@ -0,0 +1,204 @@
+package docker
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var flagDebugConfigIDE string
+
+var debugConfigCmd = &cobra.Command{
+	Use:          "debug-config",
+	Short:        "Print an IDE debugger configuration for this environment",
+	SilenceUsage: true,
+	Long: `Prints a ready-to-paste debugger configuration that maps the project and
+every --addons-path directory to their paths in the container, so breakpoints
+hit in your own files.
+
+  vscode   a complete .vscode/launch.json attaching to debugpy on the debug port
+           (start Odoo with 'odooctl docker run --debug')
+  pycharm  a Python Debug Server run configuration (.idea/runConfigurations).
+           PyCharm's debugger does not speak the debugpy protocol: the server
+           listens on the debug port + 1 and Odoo connects to it through
+           pydevd-pycharm, see the hints printed on stderr
+
+Only the configuration is written to stdout, so it can be redirected.
+
+Examples:
+  odooctl docker debug-config > .vscode/launch.json
+  odooctl docker debug-config --ide pycharm > .idea/runConfigurations/odooctl.xml`,
+	Args: cobra.NoArgs,
+	RunE: runDebugConfig,
+}
+
+func init() {
+	debugConfigCmd.Flags().StringVar(&flagDebugConfigIDE, "ide", "vscode", "IDE to print the configuration for (vscode, pycharm)")
+}
+
+func runDebugConfig(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+
+	yellow := color.New(color.FgYellow).SprintFunc()
+	switch flagDebugConfigIDE {
+	case "vscode":
+		text, err := vscodeLaunchConfig(state)
+		if err != nil {
+			return err
+		}
+		fmt.Println(text)
+		if !state.DebugpyEnabled {
+			fmt.Fprintf(os.Stderr, "%s debugpy is off; start Odoo with 'odooctl docker run --debug' before attaching\n", yellow("⚠️"))
+		}
+	case "pycharm":
+		text, err := pycharmDebugServerConfig(state)
+		if err != nil {
+			return err
+		}
+		fmt.Println(text)
+		fmt.Fprintf(os.Stderr, "%s Start the debug server in PyCharm, then let Odoo connect to it:\n", color.CyanString("ℹ"))
+		fmt.Fprintf(os.Stderr, "  odooctl docker reconfigure --add-pip pydevd-pycharm   (pin the version PyCharm suggests)\n")
+		fmt.Fprintf(os.Stderr, "  import pydevd_pycharm; pydevd_pycharm.settrace('host.docker.internal', port=%d, suspend=False)\n", pycharmDebugPort(state))
+	default:
+		return fmt.Errorf("unsupported --ide %q (use vscode or pycharm)", flagDebugConfigIDE)
+	}
+	return nil
+}
+
+// debugPathMapping maps a host directory to its mount in the odoo container
+type debugPathMapping struct {
+	LocalRoot  string `json:"localRoot"`
+	RemoteRoot string `json:"remoteRoot"`
+}
+
+// debugPathMappings lists the project and addons path mounts, most specific
+// host directory first, so an addons path inside the project is not resolved
+// through the project mount.
+func debugPathMappings(state *config.State) []debugPathMapping {
+	mappings := []debugPathMapping{{LocalRoot: state.ProjectRoot, RemoteRoot: "/mnt/extra-addons"}}
+	for i, path := range state.AddonsPaths {
+		mappings = append(mappings, debugPathMapping{LocalRoot: path, RemoteRoot: fmt.Sprintf("/mnt/custom-addons-%d", i)})
+	}
+	sort.SliceStable(mappings, func(i, j int) bool {
+		return len(filepath.Clean(mappings[i].LocalRoot)) > len(filepath.Clean(mappings[j].LocalRoot))
+	})
+	return mappings
+}
+
+type vscodeAttach struct {
+	Name         string             `json:"name"`
+	Type         string             `json:"type"`
+	Request      string             `json:"request"`
+	Connect      vscodeConnect      `json:"connect"`
+	PathMappings []debugPathMapping `json:"pathMappings"`
+	JustMyCode   bool               `json:"justMyCode"`
+}
+
+type vscodeConnect struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+func newVSCodeAttach(state *config.State) vscodeAttach {
+	return vscodeAttach{
+		Name:         fmt.Sprintf("Attach to Odoo %s (%s)", state.OdooVersion, state.ProjectName),
+		Type:         "debugpy",
+		Request:      "attach",
+		Connect:      vscodeConnect{Host: "localhost", Port: state.Ports.Debug},
+		PathMappings: debugPathMappings(state),
+		// Allow stepping into Odoo and library code
+		JustMyCode: false,
+	}
+}
+
+// vscodeLaunchConfig renders a complete launch.json
+func vscodeLaunchConfig(state *config.State) (string, error) {
+	launch := struct {
+		Version        string         `json:"version"`
+		Configurations []vscodeAttach `json:"configurations"`
+	}{Version: "0.2.0", Configurations: []vscodeAttach{newVSCodeAttach(state)}}
+	data, err := json.MarshalIndent(launch, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// pycharmDebugPort is where PyCharm's debug server listens on the host. The
+// debug port itself is published by the odoo container, and environments are
+// spaced by 10 ports, so +1 stays clear of both.
+func pycharmDebugPort(state *config.State) int {
+	return state.Ports.Debug + 1
+}
+
+type pycharmComponent struct {
+	XMLName       xml.Name             `xml:"component"`
+	Name          string               `xml:"name,attr"`
+	Configuration pycharmConfiguration `xml:"configuration"`
+}
+
+type pycharmConfiguration struct {
+	Name        string          `xml:"name,attr"`
+	Type        string          `xml:"type,attr"`
+	FactoryName string          `xml:"factoryName,attr"`
+	Options     []pycharmOption `xml:"option"`
+	Mappings    pycharmMappings `xml:"PathMappingSettings>option"`
+	Method      pycharmMethod   `xml:"method"`
+}
+
+type pycharmOption struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type pycharmMappings struct {
+	Name     string       `xml:"name,attr"`
+	Mappings []pycharmMap `xml:"list>mapping"`
+}
+
+type pycharmMap struct {
+	LocalRoot  string `xml:"local-root,attr"`
+	RemoteRoot string `xml:"remote-root,attr"`
+}
+
+type pycharmMethod struct {
+	V string `xml:"v,attr"`
+}
+
+// pycharmDebugServerConfig renders a Python Debug Server run configuration
+func pycharmDebugServerConfig(state *config.State) (string, error) {
+	component := pycharmComponent{
+		Name: "ProjectRunConfigurationManager",
+		Configuration: pycharmConfiguration{
+			Name:        fmt.Sprintf("Odoo %s (%s)", state.OdooVersion, state.ProjectName),
+			Type:        "PyRemoteDebugConfigurationType",
+			FactoryName: "Python Remote Debug",
+			Options: []pycharmOption{
+				{Name: "PORT", Value: fmt.Sprint(pycharmDebugPort(state))},
+				{Name: "HOST", Value: "host.docker.internal"},
+				{Name: "REDIRECT_OUTPUT", Value: "true"},
+				{Name: "SUSPEND_AFTER_CONNECT", Value: "false"},
+			},
+			Mappings: pycharmMappings{Name: "pathMappings"},
+			Method:   pycharmMethod{V: "2"},
+		},
+	}
+	for _, mapping := range debugPathMappings(state) {
+		component.Configuration.Mappings.Mappings = append(component.Configuration.Mappings.Mappings, pycharmMap{LocalRoot: mapping.LocalRoot, RemoteRoot: mapping.RemoteRoot})
+	}
+	data, err := xml.MarshalIndent(component, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
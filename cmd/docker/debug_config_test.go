@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
+)
+
+func debugConfigState() *config.State {
+	return &config.State{
+		ProjectName: "shop",
+		OdooVersion: "17.0",
+		ProjectRoot: "/work/shop",
+		AddonsPaths: []string{"/work/oca/web", "/work/shop/vendor"},
+		Ports:       config.Ports{Odoo: 9700, Mailhog: 9725, SMTP: 1725, Debug: 5778},
+	}
+}
+
+func TestDebugPathMappingsMostSpecificFirst(t *testing.T) {
+	got := debugPathMappings(debugConfigState())
+	want := []debugPathMapping{
+		{LocalRoot: "/work/shop/vendor", RemoteRoot: "/mnt/custom-addons-1"},
+		{LocalRoot: "/work/oca/web", RemoteRoot: "/mnt/custom-addons-0"},
+		{LocalRoot: "/work/shop", RemoteRoot: "/mnt/extra-addons"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("debugPathMappings() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("debugPathMappings()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestVSCodeLaunchConfig(t *testing.T) {
+	text, err := vscodeLaunchConfig(debugConfigState())
+	if err != nil {
+		t.Fatalf("vscodeLaunchConfig() error = %v", err)
+	}
+	var launch struct {
+		Version        string         `json:"version"`
+		Configurations []vscodeAttach `json:"configurations"`
+	}
+	if err := json.Unmarshal([]byte(text), &launch); err != nil {
+		t.Fatalf("launch.json is not valid JSON: %v\n%s", err, text)
+	}
+	if launch.Version != "0.2.0" || len(launch.Configurations) != 1 {
+		t.Fatalf("unexpected launch.json:\n%s", text)
+	}
+	attach := launch.Configurations[0]
+	if attach.Request != "attach" || attach.Connect.Port != 5778 || len(attach.PathMappings) != 3 {
+		t.Fatalf("unexpected attach configuration: %+v", attach)
+	}
+}
+
+func TestPycharmDebugServerConfig(t *testing.T) {
+	text, err := pycharmDebugServerConfig(debugConfigState())
+	if err != nil {
+		t.Fatalf("pycharmDebugServerConfig() error = %v", err)
+	}
+	var component pycharmComponent
+	if err := xml.Unmarshal([]byte(text), &component); err != nil {
+		t.Fatalf("run configuration is not valid XML: %v\n%s", err, text)
+	}
+	cfg := component.Configuration
+	if cfg.Type != "PyRemoteDebugConfigurationType" || cfg.Mappings.Name != "pathMappings" {
+		t.Fatalf("unexpected run configuration:\n%s", text)
+	}
+	if len(cfg.Mappings.Mappings) != 3 || cfg.Mappings.Mappings[2].RemoteRoot != "/mnt/extra-addons" {
+		t.Fatalf("mappings = %+v", cfg.Mappings.Mappings)
+	}
+	var port string
+	for _, option := range cfg.Options {
+		if option.Name == "PORT" {
+			port = option.Value
+		}
+	}
+	if port != "5779" {
+		t.Fatalf("PORT = %q, want 5779 (debug port + 1)", port)
+	}
+}
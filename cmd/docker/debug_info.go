@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
@@ -19,6 +20,7 @@ type debugInfoReport struct {
 	OdooURL       string `json:"odoo_url"`
 	MailHogURL    string `json:"mailhog_url"`
 	DebugEndpoint string `json:"debug_endpoint"`
+	DebugEnabled  bool   `json:"debug_enabled"`
 	EnvDir        string `json:"env_dir"`
 	OdooConfig    string `json:"odoo_config"`
 	VSCodeAttach  string `json:"vscode_attach"`
@@ -53,10 +55,14 @@ func runDebugInfo(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Database: %s\n", cyan(report.Database))
 	fmt.Printf("Odoo URL: %s\n", cyan(report.OdooURL))
 	fmt.Printf("MailHog:  %s\n", cyan(report.MailHogURL))
-	fmt.Printf("Debugpy:  %s\n", cyan(report.DebugEndpoint))
+	if report.DebugEnabled {
+		fmt.Printf("Debugpy:  %s\n", cyan(report.DebugEndpoint))
+	} else {
+		fmt.Printf("Debugpy:  %s (off, start with 'odooctl docker run --debug')\n", report.DebugEndpoint)
+	}
 	fmt.Printf("Env dir:  %s\n", report.EnvDir)
 	fmt.Printf("Config:   %s\n\n", report.OdooConfig)
-	fmt.Println("VS Code attach config:")
+	fmt.Println("VS Code attach config (full launch.json: 'odooctl docker debug-config'):")
 	fmt.Println(report.VSCodeAttach)
 	return nil
 }
@@ -74,20 +80,18 @@ func buildDebugInfoReport(state *config.State) (debugInfoReport, error) {
 		OdooURL:       fmt.Sprintf("http://localhost:%d", state.Ports.Odoo),
 		MailHogURL:    fmt.Sprintf("http://localhost:%d", state.Ports.Mailhog),
 		DebugEndpoint: debugEndpoint,
+		DebugEnabled:  state.DebugpyEnabled,
 		EnvDir:        dir,
 		OdooConfig:    filepath.Join(dir, "odoo.conf"),
-		VSCodeAttach:  vscodeAttachConfig("localhost", state.Ports.Debug),
+		VSCodeAttach:  vscodeAttachConfig(state),
 	}, nil
 }
 
-func vscodeAttachConfig(host string, port int) string {
-	return fmt.Sprintf(`{
-  "name": "Attach to odooctl Odoo",
-  "type": "python",
-  "request": "attach",
-  "connect": { "host": %q, "port": %d },
-  "pathMappings": [
-    { "localRoot": "${workspaceFolder}", "remoteRoot": "/mnt/extra-addons" }
-  ]
-}`, host, port)
+// vscodeAttachConfig renders the attach entry of launch.json's configurations
+func vscodeAttachConfig(state *config.State) string {
+	data, err := json.MarshalIndent(newVSCodeAttach(state), "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
 }
@@ -2,34 +2,67 @@ package docker
 
 import "github.com/spf13/cobra"
 
+// Select an environment other than the current directory's
+var (
+	flagProject     string
+	flagEnvironment string
+)
+
 var Cmd = &cobra.Command{
 	Use:   "docker",
 	Short: "Manage Docker development environments",
-	Long:  `Commands for creating and managing Odoo Docker development environments.`,
+	Long: `Commands for creating and managing Odoo Docker development environments.
+
+Commands act on the environment of the current directory. Use --project and
+--environment to pick another one from anywhere, e.g.:
+  odooctl docker logs --project shop --environment main`,
 }
 
 func init() {
+	Cmd.PersistentFlags().StringVar(&flagProject, "project", "", "Project whose environment to use instead of the current directory's")
+	Cmd.PersistentFlags().StringVar(&flagEnvironment, "environment", "", "Environment (branch) to use, with --project or within the current project")
+
 	Cmd.AddCommand(createCmd)
+	Cmd.AddCommand(cloneCmd)
+	Cmd.AddCommand(renameCmd)
 	Cmd.AddCommand(composeCmd)
 	Cmd.AddCommand(runCmd)
 	Cmd.AddCommand(execCmd)
+	Cmd.AddCommand(cpInCmd)
+	Cmd.AddCommand(cpOutCmd)
 	Cmd.AddCommand(restartCmd)
+	Cmd.AddCommand(restartOdooCmd)
 	Cmd.AddCommand(stopCmd)
 	Cmd.AddCommand(statusCmd)
 	Cmd.AddCommand(logsCmd)
+	Cmd.AddCommand(traceCmd)
 	Cmd.AddCommand(resetCmd)
 	Cmd.AddCommand(installCmd)
+	Cmd.AddCommand(updateListCmd)
 	Cmd.AddCommand(testCmd)
 	Cmd.AddCommand(editCmd)
 	Cmd.AddCommand(pathCmd)
 	Cmd.AddCommand(reconfigureCmd)
+	Cmd.AddCommand(listCmd)
 	Cmd.AddCommand(gotoCmd)
 	Cmd.AddCommand(dbCmd)
 	Cmd.AddCommand(sqlCmd)
 	Cmd.AddCommand(odooBinCmd)
+	Cmd.AddCommand(odooShellCmd)
+	Cmd.AddCommand(runCronCmd)
 	Cmd.AddCommand(shellCmd)
 	Cmd.AddCommand(openCmd)
 	Cmd.AddCommand(debugInfoCmd)
+	Cmd.AddCommand(debugConfigCmd)
+	Cmd.AddCommand(doctorCmd)
+	Cmd.AddCommand(envDiffCmd)
 	Cmd.AddCommand(dumpCmd)
+	Cmd.AddCommand(restoreCmd)
+	Cmd.AddCommand(filestoreCmd)
 	Cmd.AddCommand(depsCmd)
+	Cmd.AddCommand(exportRequirementsCmd)
+	Cmd.AddCommand(portCheckCmd)
+	Cmd.AddCommand(buildCmd)
+	Cmd.AddCommand(buildCacheCmd)
+	Cmd.AddCommand(lintCmd)
 }
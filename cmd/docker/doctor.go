@@ -0,0 +1,169 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/diagnostics"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/mart337i/odooctl/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagDoctorFix  bool
+	flagDoctorJSON bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment and repair broken project links",
+	Long: `Runs a checklist for the current environment and suggests fixes:
+  - the docker binary and the docker compose plugin are installed
+  - the Docker daemon is reachable and can see the project files
+  - the state file parses and the project directory still exists
+  - the generated files (docker-compose.yml, Dockerfile, odoo.conf) exist
+  - the environment's ports are free while it is stopped
+
+It then validates every project link in ~/.odooctl/projects, which tells
+odooctl which environment a project directory uses. A link is broken when its
+environment was deleted, its state can't be read or belongs to another
+directory, or when the project directory itself is gone. For each broken link
+you are offered to point it at another environment of the same project
+directory, or to remove it. --fix does this without asking.
+
+Examples:
+  odooctl docker doctor
+  odooctl docker doctor --fix
+  odooctl docker doctor --json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&flagDoctorFix, "fix", false, "Repair or remove broken links without asking")
+	doctorCmd.Flags().BoolVar(&flagDoctorJSON, "json", false, "Print JSON output (only changes links with --fix)")
+}
+
+type doctorReport struct {
+	diagnostics.Report
+	Links       []config.ProjectLinkStatus `json:"links"`
+	BrokenLinks int                        `json:"broken_links"`
+	Fixed       []string                   `json:"fixed,omitempty"`
+	Removed     []string                   `json:"removed,omitempty"`
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	statuses, err := config.CheckProjectLinks()
+	if err != nil {
+		return fmt.Errorf("failed to check project links: %w", err)
+	}
+	report := doctorReport{Report: diagnostics.Collect(cwd), Links: statuses}
+	for _, status := range statuses {
+		if status.Broken() {
+			report.BrokenLinks++
+		}
+	}
+
+	if flagDoctorJSON {
+		if flagDoctorFix {
+			for _, status := range statuses {
+				if !status.Broken() {
+					continue
+				}
+				if err := fixProjectLink(status, &report); err != nil {
+					return err
+				}
+			}
+		}
+		return output.PrintJSON(report)
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	diagnostics.Print("odooctl docker doctor", report.Report)
+	fmt.Println("\nProject links:")
+	if len(statuses) == 0 {
+		fmt.Println("  No project links found")
+		return nil
+	}
+	for _, status := range statuses {
+		if !status.Broken() {
+			fmt.Printf("%s %s → %s\n", green("✓"), status.ProjectRoot, status.EnvDir)
+			continue
+		}
+		fmt.Printf("%s %s: %s\n", yellow("⚠️"), displayLinkName(status), status.Problem)
+	}
+	fmt.Println()
+	if report.BrokenLinks == 0 {
+		fmt.Printf("%s All %d project link(s) are valid\n", green("✓"), len(statuses))
+		return nil
+	}
+
+	if !flagDoctorFix && prompt.NonInteractive() {
+		fmt.Printf("%s %d broken project link(s); run 'odooctl docker doctor --fix' to repair them\n", yellow("⚠️"), report.BrokenLinks)
+		return nil
+	}
+	for _, status := range statuses {
+		if !status.Broken() {
+			continue
+		}
+		if !flagDoctorFix {
+			question := fmt.Sprintf("Remove the link for %s?", displayLinkName(status))
+			if status.RepairEnvDir != "" {
+				question = fmt.Sprintf("Point %s at %s?", displayLinkName(status), status.RepairEnvDir)
+			}
+			confirmed, err := prompt.Confirm(question, true)
+			if err != nil || !confirmed {
+				continue
+			}
+		}
+		if err := fixProjectLink(status, &report); err != nil {
+			return err
+		}
+	}
+
+	for _, root := range report.Fixed {
+		fmt.Printf("%s Repaired the link for %s\n", green("✓"), cyan(root))
+	}
+	for _, root := range report.Removed {
+		fmt.Printf("%s Removed the link for %s\n", green("✓"), cyan(root))
+	}
+	if left := report.BrokenLinks - len(report.Fixed) - len(report.Removed); left > 0 {
+		fmt.Printf("%s %d broken project link(s) left\n", yellow("⚠️"), left)
+	}
+	return nil
+}
+
+// fixProjectLink repairs a broken link when another environment of its
+// project exists, and removes it otherwise
+func fixProjectLink(status config.ProjectLinkStatus, report *doctorReport) error {
+	if status.RepairEnvDir != "" {
+		if err := status.Repair(); err != nil {
+			return fmt.Errorf("failed to repair the link for %s: %w", displayLinkName(status), err)
+		}
+		report.Fixed = append(report.Fixed, displayLinkName(status))
+		return nil
+	}
+	if err := status.Remove(); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", status.Path, err)
+	}
+	report.Removed = append(report.Removed, displayLinkName(status))
+	return nil
+}
+
+func displayLinkName(status config.ProjectLinkStatus) string {
+	if status.ProjectRoot != "" {
+		return status.ProjectRoot
+	}
+	return status.Path
+}
@@ -1,9 +1,16 @@
 package docker
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,15 +24,120 @@ import (
 )
 
 var (
-	flagDumpOutput string
-	flagDumpJSON   bool
+	flagDumpOutput        string
+	flagDumpJSON          bool
+	flagDumpFormat        string
+	flagDumpDBOnly        bool
+	flagDumpFilestoreOnly bool
+	flagDumpCompression   string
+	flagDumpStoreFiles    bool
 )
 
 type dumpReport struct {
-	Project  string  `json:"project"`
-	Database string  `json:"database"`
-	File     string  `json:"file"`
-	SizeMB   float64 `json:"size_mb"`
+	Project    string   `json:"project"`
+	Database   string   `json:"database"`
+	File       string   `json:"file"`
+	Format     string   `json:"format,omitempty"`
+	Components []string `json:"components"`
+	SHA256     string   `json:"sha256,omitempty"`
+	SizeMB     float64  `json:"size_mb"`
+	// UncompressedMB is the size of the archived data
+	UncompressedMB   float64 `json:"uncompressed_mb"`
+	CompressionRatio float64 `json:"compression_ratio"`
+}
+
+// Database dump formats: plain SQL for psql, or pg_dump's custom format for
+// pg_restore, which restores large databases faster and in parallel
+const (
+	dumpFormatPlain  = "plain"
+	dumpFormatCustom = "custom"
+)
+
+// dumpManifestName is the archive entry describing how it was dumped
+const dumpManifestName = "manifest.json"
+
+// dumpCompressionLevels maps --compression to flate levels; none stores
+// entries without compressing them
+var dumpCompressionLevels = map[string]int{
+	"none":    flate.NoCompression,
+	"fast":    flate.BestSpeed,
+	"default": flate.DefaultCompression,
+	"best":    flate.BestCompression,
+}
+
+// dumpMetadataName is the archive entry describing the dumped environment
+const dumpMetadataName = "metadata.json"
+
+// dumpMetadata describes the environment a backup was made from, so restore
+// can warn about version mismatches and verify the dump
+type dumpMetadata struct {
+	OdooctlVersion string    `json:"odooctl_version"`
+	OdooVersion    string    `json:"odoo_version"`
+	Database       string    `json:"database"`
+	CreatedAt      time.Time `json:"created_at"`
+	PipPackages    []string  `json:"pip_packages"`
+	Modules        []string  `json:"modules"`
+	AddonsPaths    []string  `json:"addons_paths"`
+	// DumpSHA256 is the hex SHA256 of the database dump entry
+	DumpSHA256 string `json:"dump_sha256,omitempty"`
+}
+
+func newDumpMetadata(state *config.State, odooctlVersion string) dumpMetadata {
+	return dumpMetadata{
+		OdooctlVersion: odooctlVersion,
+		OdooVersion:    state.OdooVersion,
+		Database:       state.DBName(),
+		CreatedAt:      time.Now(),
+		PipPackages:    state.PipPackages,
+		Modules:        state.Modules,
+		AddonsPaths:    state.AddonsPaths,
+	}
+}
+
+// Parts of a backup, listed in the manifest
+const (
+	dumpComponentDatabase  = "database"
+	dumpComponentFilestore = "filestore"
+)
+
+type dumpManifest struct {
+	Format   string `json:"format,omitempty"`
+	DumpFile string `json:"dump_file,omitempty"`
+	// Components is empty in archives made before --db-only and
+	// --filestore-only, which hold both
+	Components []string `json:"components,omitempty"`
+}
+
+func newDumpManifest(format string) dumpManifest {
+	if format == dumpFormatCustom {
+		return dumpManifest{Format: dumpFormatCustom, DumpFile: "database.dump"}
+	}
+	return dumpManifest{Format: dumpFormatPlain, DumpFile: "database.sql"}
+}
+
+// Has reports whether the archive holds component
+func (m dumpManifest) Has(component string) bool {
+	if len(m.Components) == 0 {
+		return true
+	}
+	for _, c := range m.Components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// dumpFileName is the default archive name for what is dumped:
+// odoo-backup-<ts>.zip, odoo-db-<ts>.sql.zip or odoo-filestore-<ts>.zip
+func dumpFileName(manifest dumpManifest, timestamp string) string {
+	switch {
+	case !manifest.Has(dumpComponentFilestore):
+		return fmt.Sprintf("odoo-db-%s%s.zip", timestamp, filepath.Ext(manifest.DumpFile))
+	case !manifest.Has(dumpComponentDatabase):
+		return fmt.Sprintf("odoo-filestore-%s.zip", timestamp)
+	}
+	return fmt.Sprintf("odoo-backup-%s.zip", timestamp)
 }
 
 var dumpCmd = &cobra.Command{
@@ -34,19 +146,40 @@ var dumpCmd = &cobra.Command{
 	Long: `Creates a zip file containing the Odoo database dump and filestore.
 
 The backup includes:
-  - PostgreSQL database dump (database.sql)
+  - PostgreSQL database dump (database.sql, or database.dump with --format custom)
   - Filestore directory (filestore/)
+  - manifest.json recording the dump format and what the archive holds
+  - metadata.json with the Odoo and odooctl versions, the environment's
+    modules, pip packages and addons paths, and a SHA256 of the dump
+
+--db-only skips the filestore, which is often large and rarely changes, and
+--filestore-only skips the database. 'odooctl docker restore' restores
+whatever the archive holds.
+
+--compression trades dump time for archive size: none, fast, default or best.
+Filestore attachments are mostly images and PDFs that are compressed already;
+--store-filestore adds them as they are, which is much faster at little cost
+in size.
 
 Examples:
   odooctl docker dump                    # Create backup in current directory
   odooctl docker dump -o backup.zip      # Specify output filename
-  odooctl docker dump -o ~/backups/      # Save to specific directory`,
+  odooctl docker dump -o ~/backups/      # Save to specific directory
+  odooctl docker dump --format custom    # pg_dump -Fc, restored in parallel with pg_restore
+  odooctl docker dump --db-only          # Database only (odoo-db-<timestamp>.sql.zip)
+  odooctl docker dump --compression best # Smallest archive, slowest dump
+  odooctl docker dump --store-filestore  # Don't recompress attachments`,
 	RunE: runDump,
 }
 
 func init() {
 	dumpCmd.Flags().StringVarP(&flagDumpOutput, "output", "o", "", "Output file or directory (default: odoo-backup-YYYYMMDD-HHMMSS.zip)")
 	dumpCmd.Flags().BoolVar(&flagDumpJSON, "json", false, "Print JSON output")
+	dumpCmd.Flags().StringVar(&flagDumpFormat, "format", dumpFormatPlain, "Database dump format: plain (SQL) or custom (pg_dump -Fc)")
+	dumpCmd.Flags().BoolVar(&flagDumpDBOnly, "db-only", false, "Back up only the database")
+	dumpCmd.Flags().BoolVar(&flagDumpFilestoreOnly, "filestore-only", false, "Back up only the filestore")
+	dumpCmd.Flags().StringVar(&flagDumpCompression, "compression", "default", "Compression level: none, fast, default or best")
+	dumpCmd.Flags().BoolVar(&flagDumpStoreFiles, "store-filestore", false, "Store filestore files without compressing them")
 }
 
 func runDump(cmd *cobra.Command, args []string) error {
@@ -54,27 +187,53 @@ func runDump(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if flagDumpFormat != dumpFormatPlain && flagDumpFormat != dumpFormatCustom {
+		return fmt.Errorf("unsupported --format %q (use plain or custom)", flagDumpFormat)
+	}
+	if _, ok := dumpCompressionLevels[flagDumpCompression]; !ok {
+		return fmt.Errorf("unsupported --compression %q (use none, fast, default or best)", flagDumpCompression)
+	}
+	if flagDumpDBOnly && flagDumpFilestoreOnly {
+		return fmt.Errorf("--db-only and --filestore-only cannot be combined; omit both to back up the database and filestore")
+	}
+	if flagDumpFilestoreOnly && cmd.Flags().Changed("format") {
+		return fmt.Errorf("--format applies to the database dump and cannot be used with --filestore-only")
+	}
+	manifest := newDumpManifest(flagDumpFormat)
+	switch {
+	case flagDumpDBOnly:
+		manifest.Components = []string{dumpComponentDatabase}
+	case flagDumpFilestoreOnly:
+		manifest = dumpManifest{Components: []string{dumpComponentFilestore}}
+	default:
+		manifest.Components = []string{dumpComponentDatabase, dumpComponentFilestore}
+	}
 
 	green := color.New(color.FgGreen).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
 
 	// Check if containers are running
 	if !docker.IsRunning(state) {
 		return fmt.Errorf("containers are not running. Start them with: odooctl docker run")
 	}
+	// A just-started db may still refuse connections
+	if manifest.Has(dumpComponentDatabase) {
+		if err := docker.WaitForService(state, "db", dbReadyTimeout); err != nil {
+			return err
+		}
+	}
 
 	// Determine output file
 	outputFile := flagDumpOutput
 	if outputFile == "" {
 		timestamp := time.Now().Format("20060102-150405")
-		outputFile = fmt.Sprintf("odoo-backup-%s.zip", timestamp)
+		outputFile = dumpFileName(manifest, timestamp)
 	}
 
 	// If output is a directory, append default filename
 	if info, err := os.Stat(outputFile); err == nil && info.IsDir() {
 		timestamp := time.Now().Format("20060102-150405")
-		outputFile = filepath.Join(outputFile, fmt.Sprintf("odoo-backup-%s.zip", timestamp))
+		outputFile = filepath.Join(outputFile, dumpFileName(manifest, timestamp))
 	}
 
 	// Get database name
@@ -86,73 +245,188 @@ func runDump(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s Output: %s\n\n", cyan("💾"), outputFile)
 	}
 
-	// Create temporary directory for dump files
-	tmpDir, err := os.MkdirTemp("", "odooctl-dump-*")
-	if err != nil {
-		return fmt.Errorf("failed to create temp directory: %w", err)
+	metadata := newDumpMetadata(state, cmd.Root().Version)
+	if err := writeDumpArchive(state, manifest, &metadata, outputFile); err != nil {
+		return err
 	}
-	defer os.RemoveAll(tmpDir)
 
-	// Step 1: Dump database
-	if !flagDumpJSON {
-		fmt.Printf("%s Dumping database...\n", yellow("→"))
+	// Get file size
+	fileInfo, err := os.Stat(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", outputFile, err)
 	}
-	sqlFile := filepath.Join(tmpDir, "database.sql")
-	if err := dumpDatabase(state, dbName, sqlFile); err != nil {
-		return fmt.Errorf("failed to dump database: %w", err)
+	sizeInMB := float64(fileInfo.Size()) / (1024 * 1024)
+	uncompressed, err := archiveUncompressedSize(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", outputFile, err)
 	}
-	if !flagDumpJSON {
-		fmt.Printf("%s Database dumped successfully\n", green("✓"))
+	uncompressedMB := float64(uncompressed) / (1024 * 1024)
+	ratio := 1.0
+	if fileInfo.Size() > 0 {
+		ratio = float64(uncompressed) / float64(fileInfo.Size())
 	}
 
-	// Step 2: Copy filestore
-	if !flagDumpJSON {
-		fmt.Printf("%s Copying filestore...\n", yellow("→"))
+	if flagDumpJSON {
+		return output.PrintJSON(dumpReport{
+			Project:          state.ProjectName,
+			Database:         dbName,
+			File:             outputFile,
+			Format:           manifest.Format,
+			Components:       manifest.Components,
+			SHA256:           metadata.DumpSHA256,
+			SizeMB:           sizeInMB,
+			UncompressedMB:   uncompressedMB,
+			CompressionRatio: ratio,
+		})
 	}
-	filestoreDir := filepath.Join(tmpDir, "filestore")
-	if err := copyFilestore(state, dbName, filestoreDir); err != nil {
-		return fmt.Errorf("failed to copy filestore: %w", err)
+	fmt.Printf("\n%s Backup created successfully!\n", green("✓"))
+	fmt.Printf("  File: %s\n", cyan(outputFile))
+	fmt.Printf("  Contents: %s\n", cyan(strings.Join(manifest.Components, ", ")))
+	fmt.Printf("  Size: %s (%.1fx smaller than %.2f MB)\n", cyan(fmt.Sprintf("%.2f MB", sizeInMB)), ratio, uncompressedMB)
+
+	return nil
+}
+
+// writeDumpArchive writes the manifest, the database dump, the filestore and
+// the metadata straight into a zip next to outputFile, recording the dump's
+// checksum in metadata. Nothing is staged elsewhere on disk, so a dump doesn't
+// need twice its size in free space. The zip replaces outputFile only once it
+// is complete, so a failed dump leaves an existing backup there untouched.
+func writeDumpArchive(state *config.State, manifest dumpManifest, metadata *dumpMetadata, outputFile string) error {
+	file, err := os.CreateTemp(filepath.Dir(outputFile), "."+filepath.Base(outputFile)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputFile, err)
 	}
-	if !flagDumpJSON {
-		fmt.Printf("%s Filestore copied successfully\n", green("✓"))
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if err := writeDumpZip(state, manifest, metadata, file); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
+	}
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), outputFile); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
 	}
+	return nil
+}
 
-	// Step 3: Create zip archive
-	if !flagDumpJSON {
-		fmt.Printf("%s Creating zip archive...\n", yellow("→"))
+// writeDumpZip writes the archive's entries to w
+func writeDumpZip(state *config.State, manifest dumpManifest, metadata *dumpMetadata, w io.Writer) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	step := func(format string, a ...any) {
+		if !flagDumpJSON {
+			fmt.Printf(format+"\n", a...)
+		}
 	}
-	if err := createZipArchive(tmpDir, outputFile); err != nil {
-		return fmt.Errorf("failed to create zip archive: %w", err)
+
+	level := dumpCompressionLevels[flagDumpCompression]
+	zipWriter := newDumpZipWriter(w, level)
+	method := dumpZipMethod(level)
+	filestoreMethod := method
+	if flagDumpStoreFiles {
+		filestoreMethod = zip.Store
 	}
 
-	// Get file size
-	fileInfo, _ := os.Stat(outputFile)
-	sizeInMB := float64(fileInfo.Size()) / (1024 * 1024)
+	dbName := metadata.Database
 
-	if flagDumpJSON {
-		return output.PrintJSON(dumpReport{Project: state.ProjectName, Database: dbName, File: outputFile, SizeMB: sizeInMB})
+	if err := writeZipJSON(zipWriter, dumpManifestName, manifest); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dumpManifestName, err)
 	}
-	fmt.Printf("\n%s Backup created successfully!\n", green("✓"))
-	fmt.Printf("  File: %s\n", cyan(outputFile))
-	fmt.Printf("  Size: %s\n", cyan(fmt.Sprintf("%.2f MB", sizeInMB)))
 
+	// Step 1: Dump database
+	if manifest.Has(dumpComponentDatabase) {
+		step("%s Dumping database...", yellow("→"))
+		entry, err := zipWriter.CreateHeader(newZipHeader(manifest.DumpFile, time.Now(), method))
+		if err != nil {
+			return err
+		}
+		hash := sha256.New()
+		if err := dumpDatabase(state, dbName, io.MultiWriter(entry, hash), manifest.Format); err != nil {
+			return fmt.Errorf("failed to dump database: %w", err)
+		}
+		metadata.DumpSHA256 = hex.EncodeToString(hash.Sum(nil))
+		step("%s Database dumped successfully", green("✓"))
+	}
+
+	// Step 2: Copy filestore
+	if manifest.Has(dumpComponentFilestore) {
+		step("%s Copying filestore...", yellow("→"))
+		if _, err := zipWriter.Create("filestore/"); err != nil {
+			return err
+		}
+		if err := archiveFilestore(state, dbName, zipWriter, "filestore/", filestoreMethod); err != nil {
+			return fmt.Errorf("failed to copy filestore: %w", err)
+		}
+		step("%s Filestore copied successfully", green("✓"))
+	}
+
+	if err := writeZipJSON(zipWriter, dumpMetadataName, metadata); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dumpMetadataName, err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write zip archive: %w", err)
+	}
 	return nil
 }
 
-// dumpDatabase dumps the PostgreSQL database to a SQL file
-func dumpDatabase(state *config.State, dbName, outputFile string) error {
-	dir, err := config.EnvironmentDir(state.ProjectName, state.Branch)
+// writeZipJSON adds v to the archive as the JSON file name
+func writeZipJSON(zipWriter *zip.Writer, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return err
 	}
-
-	// Create the output file
-	file, err := os.Create(outputFile)
+	entry, err := zipWriter.CreateHeader(newZipHeader(name, time.Now(), zip.Deflate))
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	_, err = entry.Write(data)
+	return err
+}
+
+func newZipHeader(name string, modified time.Time, method uint16) *zip.FileHeader {
+	return &zip.FileHeader{Name: name, Method: method, Modified: modified}
+}
+
+// newDumpZipWriter returns a zip writer that deflates entries at level
+func newDumpZipWriter(w io.Writer, level int) *zip.Writer {
+	zipWriter := zip.NewWriter(w)
+	zipWriter.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, level)
+	})
+	return zipWriter
+}
 
+// dumpZipMethod stores entries rather than deflating them without compression
+func dumpZipMethod(level int) uint16 {
+	if level == flate.NoCompression {
+		return zip.Store
+	}
+	return zip.Deflate
+}
+
+// archiveUncompressedSize sums the sizes of the files in a zip archive
+func archiveUncompressedSize(path string) (uint64, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return 0, err
+	}
+	defer archive.Close()
+	var total uint64
+	for _, file := range archive.File {
+		total += file.UncompressedSize64
+	}
+	return total, nil
+}
+
+// dumpDatabase writes a pg_dump of the PostgreSQL database in the given
+// format to w
+func dumpDatabase(state *config.State, dbName string, w io.Writer, format string) error {
 	// Run pg_dump via docker compose exec
 	args := []string{
 		"exec",
@@ -164,15 +438,116 @@ func dumpDatabase(state *config.State, dbName, outputFile string) error {
 		"--no-owner",
 		"--no-acl",
 	}
+	if format == dumpFormatCustom {
+		args = append(args, "-Fc")
+	}
 
 	cmd := docker.ComposeCommand(state, args...)
-	cmd.Dir = dir
-	cmd.Stdout = file
+	cmd.Stdout = w
 	cmd.Stderr = os.Stderr
 
 	return cmd.Run()
 }
 
+// archiveFilestore adds the database's filestore to the archive under
+// prefix, reading it from the bind mount or as a tar stream from the odoo
+// container. A missing filestore adds nothing.
+func archiveFilestore(state *config.State, dbName string, zipWriter *zip.Writer, prefix string, method uint16) error {
+	if state.FilestoreBindPath != "" {
+		return archiveHostDir(filepath.Join(state.FilestoreBindPath, dbName), zipWriter, prefix, method)
+	}
+
+	// docker cp with - as destination writes a tar of the directory to stdout
+	cmd := docker.ComposeCommand(state, "cp", "odoo:/var/lib/odoo/filestore/"+dbName, "-")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if err := archiveTar(tar.NewReader(stdout), zipWriter, prefix, method); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		text := stderr.String()
+		if strings.Contains(strings.ToLower(text), "no such file") {
+			// Filestore doesn't exist, that's okay (new database)
+			return nil
+		}
+		return fmt.Errorf("docker cp failed: %s", strings.TrimSpace(text))
+	}
+	return nil
+}
+
+// archiveTar adds the regular files of a docker cp tar stream to the archive
+// under prefix, dropping the copied directory's own name from their paths
+func archiveTar(reader *tar.Reader, zipWriter *zip.Writer, prefix string, method uint16) error {
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		_, rel, ok := strings.Cut(strings.TrimPrefix(header.Name, "./"), "/")
+		if !ok || rel == "" {
+			continue
+		}
+		entry, err := zipWriter.CreateHeader(newZipHeader(prefix+rel, header.ModTime, method))
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(entry, reader); err != nil {
+			return err
+		}
+	}
+}
+
+// archiveHostDir adds the files under src to the archive under prefix. A
+// missing src adds nothing.
+func archiveHostDir(src string, zipWriter *zip.Writer, prefix string, method uint16) error {
+	err := filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := zipWriter.CreateHeader(newZipHeader(prefix+filepath.ToSlash(rel), info.ModTime(), method))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, in)
+		return err
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
 // copyFilestore copies the filestore from the Docker volume to a local directory
 func copyFilestore(state *config.State, dbName, outputDir string) error {
 	// Create output directory
@@ -180,6 +555,11 @@ func copyFilestore(state *config.State, dbName, outputDir string) error {
 		return err
 	}
 
+	// A bind-mounted filestore is read straight from the host
+	if state.FilestoreBindPath != "" {
+		return copyHostDir(filepath.Join(state.FilestoreBindPath, dbName), outputDir)
+	}
+
 	// The filestore is in the Docker volume at /var/lib/odoo/filestore/{dbName}
 	// We'll use docker compose cp to copy it
 	containerPath := fmt.Sprintf("odoo:/var/lib/odoo/filestore/%s", dbName)
@@ -219,71 +599,41 @@ func copyFilestore(state *config.State, dbName, outputDir string) error {
 	return nil
 }
 
-// createZipArchive creates a zip file from the given directory
-func createZipArchive(sourceDir, outputFile string) error {
-	// Create output file
-	zipFile, err := os.Create(outputFile)
-	if err != nil {
-		return err
-	}
-	defer zipFile.Close()
-
-	// Create zip writer
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
-
-	// Walk through source directory and add files to zip
-	return filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+// copyHostDir copies the files under src into dst. A missing src is an
+// empty filestore.
+func copyHostDir(src, dst string) error {
+	err := filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-
-		// Get relative path
-		relPath, err := filepath.Rel(sourceDir, path)
+		rel, err := filepath.Rel(src, path)
 		if err != nil {
 			return err
 		}
-
-		// Skip the root directory itself
-		if relPath == "." {
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !entry.Type().IsRegular() {
 			return nil
 		}
-
-		// Create zip header
-		header, err := zip.FileInfoHeader(info)
+		in, err := os.Open(path)
 		if err != nil {
 			return err
 		}
-
-		// Set the name to the relative path
-		header.Name = relPath
-
-		// Set compression method
-		if info.IsDir() {
-			header.Name += "/"
-		} else {
-			header.Method = zip.Deflate
-		}
-
-		// Create writer for this file
-		writer, err := zipWriter.CreateHeader(header)
+		defer in.Close()
+		out, err := os.Create(target)
 		if err != nil {
 			return err
 		}
-
-		// If it's a directory, we're done
-		if info.IsDir() {
-			return nil
-		}
-
-		// Open the file and copy contents
-		file, err := os.Open(path)
-		if err != nil {
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
 			return err
 		}
-		defer file.Close()
-
-		_, err = io.Copy(writer, file)
-		return err
+		return out.Close()
 	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
 }
@@ -0,0 +1,237 @@
+package docker
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mart337i/odooctl/internal/config"
+)
+
+func TestCopyFilestoreFromBindMount(t *testing.T) {
+	bind := t.TempDir()
+	attachment := filepath.Join(bind, "odoo-170", "3f", "3f786850e387550fdab836ed7e6dc881de23001b")
+	if err := os.MkdirAll(filepath.Dir(attachment), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(attachment, []byte("invoice"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	state := &config.State{FilestoreBindPath: bind}
+
+	outputDir := filepath.Join(t.TempDir(), "filestore")
+	if err := copyFilestore(state, "odoo-170", outputDir); err != nil {
+		t.Fatalf("copyFilestore() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outputDir, "3f", "3f786850e387550fdab836ed7e6dc881de23001b"))
+	if err != nil || string(data) != "invoice" {
+		t.Fatalf("copied attachment = %q, %v", data, err)
+	}
+
+	// A database without a filestore yet dumps an empty directory
+	emptyDir := filepath.Join(t.TempDir(), "filestore")
+	if err := copyFilestore(state, "missing", emptyDir); err != nil {
+		t.Fatalf("copyFilestore(missing) error = %v", err)
+	}
+	if entries, err := os.ReadDir(emptyDir); err != nil || len(entries) != 0 {
+		t.Fatalf("filestore of a missing database = %v, %v, want empty", entries, err)
+	}
+}
+
+func TestDumpFileName(t *testing.T) {
+	dbOnly := newDumpManifest(dumpFormatPlain)
+	dbOnly.Components = []string{dumpComponentDatabase}
+	customDBOnly := newDumpManifest(dumpFormatCustom)
+	customDBOnly.Components = []string{dumpComponentDatabase}
+
+	tests := []struct {
+		manifest dumpManifest
+		want     string
+	}{
+		{newDumpManifest(dumpFormatPlain), "odoo-backup-20240501-100000.zip"},
+		{dbOnly, "odoo-db-20240501-100000.sql.zip"},
+		{customDBOnly, "odoo-db-20240501-100000.dump.zip"},
+		{dumpManifest{Components: []string{dumpComponentFilestore}}, "odoo-filestore-20240501-100000.zip"},
+	}
+	for _, tt := range tests {
+		if got := dumpFileName(tt.manifest, "20240501-100000"); got != tt.want {
+			t.Errorf("dumpFileName(%v) = %q, want %q", tt.manifest.Components, got, tt.want)
+		}
+	}
+}
+
+func readZipEntries(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	entries := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[file.Name] = string(content)
+	}
+	return entries
+}
+
+func TestArchiveTar(t *testing.T) {
+	// docker cp odoo:/var/lib/odoo/filestore/odoo-170 - names entries after
+	// the copied directory
+	var tarData bytes.Buffer
+	tw := tar.NewWriter(&tarData)
+	for _, entry := range []struct {
+		name     string
+		typeflag byte
+		content  string
+	}{
+		{"odoo-170/", tar.TypeDir, ""},
+		{"odoo-170/3f/", tar.TypeDir, ""},
+		{"odoo-170/3f/3f786850", tar.TypeReg, "invoice"},
+		{"odoo-170/3f/link", tar.TypeSymlink, ""},
+	} {
+		header := &tar.Header{Name: entry.name, Typeflag: entry.typeflag, Size: int64(len(entry.content)), Mode: 0644}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var zipData bytes.Buffer
+	zipWriter := zip.NewWriter(&zipData)
+	if err := archiveTar(tar.NewReader(&tarData), zipWriter, "filestore/", zip.Deflate); err != nil {
+		t.Fatalf("archiveTar() error = %v", err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	entries := readZipEntries(t, zipData.Bytes())
+	if len(entries) != 1 || entries["filestore/3f/3f786850"] != "invoice" {
+		t.Fatalf("archive entries = %v, want only filestore/3f/3f786850", entries)
+	}
+}
+
+func TestArchiveHostDir(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "3f"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "3f", "3f786850"), []byte("invoice"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var zipData bytes.Buffer
+	zipWriter := zip.NewWriter(&zipData)
+	if err := archiveHostDir(src, zipWriter, "filestore/", zip.Deflate); err != nil {
+		t.Fatalf("archiveHostDir() error = %v", err)
+	}
+	if err := archiveHostDir(filepath.Join(src, "missing"), zipWriter, "filestore/", zip.Deflate); err != nil {
+		t.Fatalf("archiveHostDir(missing) error = %v", err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	entries := readZipEntries(t, zipData.Bytes())
+	if len(entries) != 1 || entries["filestore/3f/3f786850"] != "invoice" {
+		t.Fatalf("archive entries = %v, want only filestore/3f/3f786850", entries)
+	}
+}
+
+func TestDumpZipCompression(t *testing.T) {
+	data := strings.Repeat("INSERT INTO res_partner VALUES (1);\n", 1000)
+	sizes := make(map[string]int64)
+	for _, compression := range []string{"none", "best"} {
+		path := filepath.Join(t.TempDir(), "backup.zip")
+		file, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		level := dumpCompressionLevels[compression]
+		zipWriter := newDumpZipWriter(file, level)
+		entry, err := zipWriter.CreateHeader(newZipHeader("database.sql", time.Now(), dumpZipMethod(level)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(entry, data); err != nil {
+			t.Fatal(err)
+		}
+		if err := zipWriter.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := file.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		uncompressed, err := archiveUncompressedSize(path)
+		if err != nil || uncompressed != uint64(len(data)) {
+			t.Fatalf("archiveUncompressedSize(%s) = %d, %v, want %d", compression, uncompressed, err, len(data))
+		}
+		info, _ := os.Stat(path)
+		sizes[compression] = info.Size()
+	}
+	if sizes["none"] <= int64(len(data)) || sizes["best"] >= sizes["none"]/10 {
+		t.Fatalf("archive sizes = %v for %d bytes of SQL, want none larger and best much smaller", sizes, len(data))
+	}
+}
+
+func TestWriteDumpArchiveReplacesOutputOnlyOnSuccess(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("PATH", t.TempDir()) // no docker, so dumping the database fails
+	bind := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(bind, "odoo-170", "3f"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bind, "odoo-170", "3f", "attachment"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	state := &config.State{ProjectName: "shop", OdooVersion: "17.0", Branch: "main", FilestoreBindPath: bind}
+
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "backup.zip")
+	if err := os.WriteFile(outputFile, []byte("previous backup"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := newDumpManifest(dumpFormatPlain)
+	manifest.Components = []string{dumpComponentDatabase, dumpComponentFilestore}
+	if err := writeDumpArchive(state, manifest, &dumpMetadata{Database: "odoo-170"}, outputFile); err == nil {
+		t.Fatal("writeDumpArchive() error = nil without docker")
+	}
+	if data, err := os.ReadFile(outputFile); err != nil || string(data) != "previous backup" {
+		t.Fatalf("failed dump changed the existing backup: %q, %v", data, err)
+	}
+
+	manifest.Components = []string{dumpComponentFilestore}
+	if err := writeDumpArchive(state, manifest, &dumpMetadata{Database: "odoo-170"}, outputFile); err != nil {
+		t.Fatalf("writeDumpArchive() error = %v", err)
+	}
+	archive, err := zip.OpenReader(outputFile)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer archive.Close()
+	if contents, err := inspectDumpArchive(&archive.Reader); err != nil || contents.FilestoreFiles != 1 {
+		t.Fatalf("inspectDumpArchive() = %+v, %v, want one filestore file", contents, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("output directory has %d entries, want only the backup", len(entries))
+	}
+}
@@ -0,0 +1,243 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var flagEnvDiffJSON bool
+
+var envDiffCmd = &cobra.Command{
+	Use:   "env-diff <project/branch> [<project/branch>]",
+	Short: "Compare the configuration of two environments",
+	Long: `Compares two environments field by field: Odoo version, ports, modules, pip
+packages, addons paths, enterprise, demo data and extra odoo.conf options.
+Differences are highlighted. With a single argument the current environment is
+compared with it.
+
+Environments are named project/branch, as in ~/.odooctl/<project>/<branch>.
+
+Examples:
+  odooctl docker env-diff shop/main
+  odooctl docker env-diff shop/main shop/feature-x --json`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE:         runEnvDiff,
+}
+
+func init() {
+	envDiffCmd.Flags().BoolVar(&flagEnvDiffJSON, "json", false, "Print JSON output")
+}
+
+type envDiffReport struct {
+	Left      string         `json:"left"`
+	Right     string         `json:"right"`
+	Identical bool           `json:"identical"`
+	Fields    []envDiffField `json:"fields"`
+}
+
+// envDiffField is one compared setting. List settings also report the
+// entries found on one side only.
+type envDiffField struct {
+	Field     string   `json:"field"`
+	Left      string   `json:"left"`
+	Right     string   `json:"right"`
+	Equal     bool     `json:"equal"`
+	OnlyLeft  []string `json:"only_left,omitempty"`
+	OnlyRight []string `json:"only_right,omitempty"`
+}
+
+func runEnvDiff(cmd *cobra.Command, args []string) error {
+	var left, right *config.State
+	var err error
+	if len(args) == 1 {
+		if left, err = loadState(); err != nil {
+			return err
+		}
+		if right, err = loadEnvironment(args[0]); err != nil {
+			return err
+		}
+	} else {
+		if left, err = loadEnvironment(args[0]); err != nil {
+			return err
+		}
+		if right, err = loadEnvironment(args[1]); err != nil {
+			return err
+		}
+	}
+
+	fields := compareStates(left, right)
+	report := envDiffReport{Left: environmentName(left), Right: environmentName(right), Identical: true, Fields: fields}
+	for _, field := range fields {
+		if !field.Equal {
+			report.Identical = false
+		}
+	}
+	if flagEnvDiffJSON {
+		return output.PrintJSON(report)
+	}
+	return printEnvDiff(report)
+}
+
+// loadEnvironment loads the state of a project/branch environment
+func loadEnvironment(name string) (*config.State, error) {
+	project, branch, ok := strings.Cut(name, "/")
+	if !ok || project == "" || branch == "" {
+		return nil, fmt.Errorf("invalid environment %q (expected project/branch)", name)
+	}
+	state, err := config.Load(project, branch)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("environment %s not found. Run 'odooctl docker list' to see all environments", name)
+		}
+		return nil, fmt.Errorf("failed to load environment %s: %w", name, err)
+	}
+	return state, nil
+}
+
+func environmentName(state *config.State) string {
+	return state.ProjectName + "/" + state.Branch
+}
+
+// compareStates compares the settings that shape an environment
+func compareStates(left, right *config.State) []envDiffField {
+	fields := []envDiffField{
+		scalarField("Odoo version", left.OdooVersion, right.OdooVersion),
+		scalarField("Database", left.DBName(), right.DBName()),
+		scalarField("Odoo port", strconv.Itoa(left.Ports.Odoo), strconv.Itoa(right.Ports.Odoo)),
+		scalarField("Mailhog port", strconv.Itoa(left.Ports.Mailhog), strconv.Itoa(right.Ports.Mailhog)),
+		scalarField("SMTP port", strconv.Itoa(left.Ports.SMTP), strconv.Itoa(right.Ports.SMTP)),
+		scalarField("Debug port", strconv.Itoa(left.Ports.Debug), strconv.Itoa(right.Ports.Debug)),
+		listField("Modules", left.Modules, right.Modules, false),
+		listField("Pip packages", left.PipPackages, right.PipPackages, false),
+		// Addons paths are mounted by index, so their order matters
+		listField("Addons paths", left.AddonsPaths, right.AddonsPaths, true),
+		scalarField("Enterprise", yesNo(left.Enterprise), yesNo(right.Enterprise)),
+		scalarField("Demo data", yesNo(!left.WithoutDemo), yesNo(!right.WithoutDemo)),
+		listField("No demo data for", left.WithoutDemoModules, right.WithoutDemoModules, false),
+	}
+
+	keys := map[string]bool{}
+	for key := range left.ExtraConfOptions {
+		keys[key] = true
+	}
+	for key := range right.ExtraConfOptions {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		fields = append(fields, scalarField("odoo.conf "+key, confValue(left.ExtraConfOptions, key), confValue(right.ExtraConfOptions, key)))
+	}
+	return fields
+}
+
+func scalarField(name, left, right string) envDiffField {
+	return envDiffField{Field: name, Left: left, Right: right, Equal: left == right}
+}
+
+// listField compares two lists as sets, or element by element when ordered
+func listField(name string, left, right []string, ordered bool) envDiffField {
+	field := envDiffField{Field: name, Left: strings.Join(left, ", "), Right: strings.Join(right, ", ")}
+	field.OnlyLeft = missingFrom(left, right)
+	field.OnlyRight = missingFrom(right, left)
+	if ordered {
+		field.Equal = field.Left == field.Right
+	} else {
+		field.Equal = len(field.OnlyLeft) == 0 && len(field.OnlyRight) == 0
+	}
+	return field
+}
+
+// missingFrom returns the entries of list that other lacks, sorted
+func missingFrom(list, other []string) []string {
+	present := make(map[string]bool, len(other))
+	for _, entry := range other {
+		present[entry] = true
+	}
+	var missing []string
+	for _, entry := range list {
+		if !present[entry] {
+			missing = append(missing, entry)
+			present[entry] = true
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func confValue(options map[string]string, key string) string {
+	value, ok := options[key]
+	if !ok {
+		return "(unset)"
+	}
+	return value
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func printEnvDiff(report envDiffReport) error {
+	table := output.Table{Headers: []string{"field", report.Left, report.Right}}
+	for _, field := range report.Fields {
+		table.Rows = append(table.Rows, []string{field.Field, displayValue(field.Left), displayValue(field.Right)})
+	}
+	// Align first, then color: escape codes would throw off the tabwriter
+	var buf bytes.Buffer
+	if err := output.WriteTable(&buf, table); err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	fmt.Println(lines[0])
+	for i, line := range lines[1:] {
+		if report.Fields[i].Equal {
+			fmt.Println(line)
+		} else {
+			fmt.Println(color.YellowString(line))
+		}
+	}
+
+	fmt.Println()
+	if report.Identical {
+		fmt.Printf("%s %s and %s are configured identically\n", color.GreenString("✓"), report.Left, report.Right)
+		return nil
+	}
+	differences := 0
+	for _, field := range report.Fields {
+		if field.Equal {
+			continue
+		}
+		differences++
+		if len(field.OnlyLeft) > 0 {
+			fmt.Printf("  %s only in %s: %s\n", field.Field, report.Left, strings.Join(field.OnlyLeft, ", "))
+		}
+		if len(field.OnlyRight) > 0 {
+			fmt.Printf("  %s only in %s: %s\n", field.Field, report.Right, strings.Join(field.OnlyRight, ", "))
+		}
+	}
+	fmt.Printf("%s %d setting(s) differ\n", color.YellowString("⚠️"), differences)
+	return nil
+}
+
+func displayValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
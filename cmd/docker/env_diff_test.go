@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
+)
+
+func TestCompareStates(t *testing.T) {
+	left := &config.State{
+		OdooVersion:      "17.0",
+		Ports:            config.CalculatePorts("17.0"),
+		Modules:          []string{"sale", "stock"},
+		PipPackages:      []string{"requests"},
+		AddonsPaths:      []string{"/a", "/b"},
+		ExtraConfOptions: map[string]string{"workers": "2"},
+	}
+	right := &config.State{
+		OdooVersion:      "17.0",
+		Ports:            config.CalculatePorts("17.0"),
+		Modules:          []string{"stock", "sale", "mrp"},
+		PipPackages:      []string{"requests"},
+		AddonsPaths:      []string{"/b", "/a"},
+		WithoutDemo:      true,
+		ExtraConfOptions: map[string]string{"workers": "2", "log_level": "debug"},
+	}
+
+	fields := map[string]envDiffField{}
+	for _, field := range compareStates(left, right) {
+		fields[field.Field] = field
+	}
+
+	for _, name := range []string{"Odoo version", "Odoo port", "Pip packages", "odoo.conf workers"} {
+		if !fields[name].Equal {
+			t.Errorf("%s: Equal = false, want true", name)
+		}
+	}
+	modules := fields["Modules"]
+	if modules.Equal || len(modules.OnlyLeft) != 0 || !reflect.DeepEqual(modules.OnlyRight, []string{"mrp"}) {
+		t.Errorf("Modules = %+v, want only mrp on the right", modules)
+	}
+	if fields["Addons paths"].Equal {
+		t.Errorf("Addons paths: Equal = true, want false for a different order")
+	}
+	if demo := fields["Demo data"]; demo.Equal || demo.Left != "yes" || demo.Right != "no" {
+		t.Errorf("Demo data = %+v, want yes/no", demo)
+	}
+	if level := fields["odoo.conf log_level"]; level.Equal || level.Left != "(unset)" || level.Right != "debug" {
+		t.Errorf("odoo.conf log_level = %+v, want (unset)/debug", level)
+	}
+}
+
+func TestLoadEnvironmentRejectsInvalidName(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, name := range []string{"shop", "/main", "shop/"} {
+		if _, err := loadEnvironment(name); err == nil || !strings.Contains(err.Error(), "project/branch") {
+			t.Errorf("loadEnvironment(%q) error = %v, want an invalid name error", name, err)
+		}
+	}
+	if _, err := loadEnvironment("shop/main"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("loadEnvironment(shop/main) error = %v, want not found", err)
+	}
+}
@@ -8,29 +8,36 @@ import (
 )
 
 var (
-	flagExecRoot  bool
-	flagExecNoTTY bool
+	flagExecRoot    bool
+	flagExecNoTTY   bool
+	flagExecService string
 )
 
 var execCmd = &cobra.Command{
-	Use:          "exec [flags] <service> -- <command...>",
+	Use:          "exec [flags] [service] -- <command...>",
 	Short:        "Run a command inside a Docker service",
 	SilenceUsage: true,
 	Long: `Run arbitrary commands inside a Compose service without locating the
 generated Docker environment directory.
 
+The service is the argument before --, or --service, which defaults to odoo.
+Everything after -- is passed to the command unchanged.
+
 Examples:
+  odooctl docker exec -- python --version
+  odooctl docker exec --service db -- pg_isready
   odooctl docker exec odoo -- python --version
   odooctl docker exec odoo -- ls /mnt/extra-addons
   odooctl docker exec --root odoo -- apt update
   odooctl docker exec -T db -- psql -U odoo -d odoo-190 -c "select now();"`,
-	Args: cobra.MinimumNArgs(2),
+	Args: cobra.MinimumNArgs(1),
 	RunE: runExec,
 }
 
 func init() {
 	execCmd.Flags().BoolVar(&flagExecRoot, "root", false, "Run command as root")
 	execCmd.Flags().BoolVarP(&flagExecNoTTY, "no-tty", "T", false, "Disable pseudo-TTY allocation")
+	execCmd.Flags().StringVarP(&flagExecService, "service", "s", "odoo", "Service to run the command in")
 }
 
 func runExec(cmd *cobra.Command, args []string) error {
@@ -38,10 +45,9 @@ func runExec(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	service := args[0]
-	command := args[1:]
-	if service == "" || len(command) == 0 {
-		return fmt.Errorf("usage: odooctl docker exec <service> -- <command...>")
+	service, command, err := execTarget(args, cmd.ArgsLenAtDash(), flagExecService, cmd.Flags().Changed("service"))
+	if err != nil {
+		return err
 	}
 	composeArgs := []string{"exec"}
 	if flagExecNoTTY {
@@ -54,3 +60,23 @@ func runExec(cmd *cobra.Command, args []string) error {
 	composeArgs = append(composeArgs, command...)
 	return dockerlib.Compose(state, composeArgs...)
 }
+
+// execTarget splits the arguments into service and command. dash is the
+// number of arguments before --, or -1 without --.
+func execTarget(args []string, dash int, flagService string, serviceChanged bool) (string, []string, error) {
+	service := flagService
+	var command []string
+	switch {
+	case dash == 0:
+		command = args
+	case dash == 1 || (dash == -1 && len(args) >= 2):
+		if serviceChanged && args[0] != flagService {
+			return "", nil, fmt.Errorf("service given twice: %s and --service %s", args[0], flagService)
+		}
+		service, command = args[0], args[1:]
+	}
+	if service == "" || len(command) == 0 {
+		return "", nil, fmt.Errorf("usage: odooctl docker exec [service] -- <command...>")
+	}
+	return service, command, nil
+}
@@ -0,0 +1,44 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExecTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		dash        int
+		service     string
+		changed     bool
+		wantService string
+		wantCommand []string
+		wantErr     bool
+	}{
+		{name: "default service", args: []string{"python", "--version"}, dash: 0, service: "odoo", wantService: "odoo", wantCommand: []string{"python", "--version"}},
+		{name: "service flag", args: []string{"pg_isready"}, dash: 0, service: "db", changed: true, wantService: "db", wantCommand: []string{"pg_isready"}},
+		{name: "positional service", args: []string{"db", "psql"}, dash: 1, service: "odoo", wantService: "db", wantCommand: []string{"psql"}},
+		{name: "without dash", args: []string{"odoo", "ls", "/mnt"}, dash: -1, service: "odoo", wantService: "odoo", wantCommand: []string{"ls", "/mnt"}},
+		{name: "conflicting services", args: []string{"db", "psql"}, dash: 1, service: "odoo", changed: true, wantErr: true},
+		{name: "no command", args: []string{"db"}, dash: 1, service: "odoo", wantErr: true},
+		{name: "ambiguous", args: []string{"ls"}, dash: -1, service: "odoo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, command, err := execTarget(tt.args, tt.dash, tt.service, tt.changed)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("execTarget() = %s %v, want an error", service, command)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("execTarget() error = %v", err)
+			}
+			if service != tt.wantService || !reflect.DeepEqual(command, tt.wantCommand) {
+				t.Errorf("execTarget() = %s %v, want %s %v", service, command, tt.wantService, tt.wantCommand)
+			}
+		})
+	}
+}
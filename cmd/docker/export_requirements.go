@@ -0,0 +1,60 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	pydeps "github.com/mart337i/odooctl/internal/deps"
+	"github.com/spf13/cobra"
+)
+
+var flagExportRequirementsOutput string
+
+var exportRequirementsCmd = &cobra.Command{
+	Use:   "export-requirements",
+	Short: "Export the environment's pip packages as requirements.txt",
+	Long: `Writes the pip packages recorded for this environment (added with create
+--pip, reconfigure --add-pip, deps sync or install) in requirements.txt
+format, one per line with their version pins. Packages are deduplicated and
+sorted so the file diffs cleanly. Without --output they are printed.
+
+The file can be committed, or fed to another environment:
+  odooctl docker export-requirements -o requirements.txt
+  odooctl docker create --pip ./requirements.txt`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runExportRequirements,
+}
+
+func init() {
+	exportRequirementsCmd.Flags().StringVarP(&flagExportRequirementsOutput, "output", "o", "", "Write to this file instead of stdout")
+}
+
+func runExportRequirements(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+
+	requirements := pydeps.Requirements(state.PipPackages)
+	text := ""
+	if len(requirements) > 0 {
+		text = strings.Join(requirements, "\n") + "\n"
+	}
+	if flagExportRequirementsOutput == "" {
+		fmt.Print(text)
+		return nil
+	}
+
+	if err := os.WriteFile(flagExportRequirementsOutput, []byte(text), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", flagExportRequirementsOutput, err)
+	}
+	if len(requirements) == 0 {
+		fmt.Printf("%s No pip packages recorded for this environment; wrote an empty %s\n", color.YellowString("⚠️"), flagExportRequirementsOutput)
+		return nil
+	}
+	fmt.Printf("%s Wrote %d package(s) to %s\n", color.GreenString("✓"), len(requirements), color.CyanString(flagExportRequirementsOutput))
+	return nil
+}
@@ -0,0 +1,194 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	dockerlib "github.com/mart337i/odooctl/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagFilestoreDatabase string
+	flagFilestoreOutput   string
+)
+
+var filestoreCmd = &cobra.Command{
+	Use:   "filestore",
+	Short: "Browse the Odoo filestore",
+	Long: `Lists and copies files from the database's filestore in the odoo container
+(/var/lib/odoo/filestore/<db>). Odoo stores attachments under the SHA-1 of
+their content, sharded by its first two characters (ab/abcdef...), the value
+of ir_attachment.store_fname. Both commands accept a bare checksum too.
+
+Examples:
+  odooctl docker filestore ls
+  odooctl docker filestore ls 3f
+  odooctl docker filestore ls 3f786850e387550fdab836ed7e6dc881de23001b
+  odooctl docker filestore get 3f786850e387550fdab836ed7e6dc881de23001b
+  odooctl docker filestore get 3f/3f786850e387550fdab836ed7e6dc881de23001b -o invoice.pdf`,
+}
+
+var filestoreLsCmd = &cobra.Command{
+	Use:          "ls [path]",
+	Short:        "List a filestore directory or file",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runFilestoreLs,
+}
+
+var filestoreGetCmd = &cobra.Command{
+	Use:          "get <checksum>",
+	Short:        "Copy an attachment out of the filestore",
+	Long:         `Copies an attachment to the host. Without --output it is saved in the current directory under the attachment's name, or its checksum when no ir_attachment record uses it.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runFilestoreGet,
+}
+
+func init() {
+	filestoreCmd.PersistentFlags().StringVarP(&flagFilestoreDatabase, "database", "d", "", "Database name (auto-detected if omitted)")
+	filestoreGetCmd.Flags().StringVarP(&flagFilestoreOutput, "output", "o", "", "Output file or directory")
+	filestoreCmd.AddCommand(filestoreLsCmd)
+	filestoreCmd.AddCommand(filestoreGetCmd)
+}
+
+func filestoreDatabase(state *config.State) string {
+	if flagFilestoreDatabase != "" {
+		return flagFilestoreDatabase
+	}
+	return state.DBName()
+}
+
+func filestoreRoot(database string) string {
+	return "/var/lib/odoo/filestore/" + database
+}
+
+func runFilestoreLs(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	var arg string
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	rel, err := filestorePath(arg)
+	if err != nil {
+		return err
+	}
+	database := filestoreDatabase(state)
+
+	target := path.Join(filestoreRoot(database), rel)
+	if err := dockerlib.Compose(state, "exec", "-T", "odoo", "ls", "-lh", "--", target); err != nil {
+		return fmt.Errorf("failed to list %s (is the environment running?): %w", target, err)
+	}
+	if isStoreFname(rel) {
+		if attachment, ok := lookupAttachment(state, database, rel); ok {
+			fmt.Printf("%s Attachment #%s %s (%s), on %s\n", color.CyanString("ℹ"), attachment.ID, color.CyanString(attachment.Name), attachment.Mimetype, attachment.Owner())
+		} else {
+			fmt.Printf("%s No ir_attachment record uses this file\n", color.YellowString("⚠️"))
+		}
+	}
+	return nil
+}
+
+func runFilestoreGet(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	rel, err := filestorePath(args[0])
+	if err != nil {
+		return err
+	}
+	if !isStoreFname(rel) {
+		return fmt.Errorf("%q is not an attachment checksum (expected a 40 character SHA-1 or ab/<sha1>)", args[0])
+	}
+	database := filestoreDatabase(state)
+
+	name := path.Base(rel)
+	if attachment, ok := lookupAttachment(state, database, rel); ok && attachment.Name != "" {
+		name = filepath.Base(filepath.Clean("/" + attachment.Name))
+	}
+	dest := flagFilestoreOutput
+	if dest == "" {
+		dest = name
+	} else if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		dest = filepath.Join(dest, name)
+	}
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", dest)
+	}
+
+	source := path.Join(filestoreRoot(database), rel)
+	if text, err := dockerlib.ComposeOutput(state, "cp", "odoo:"+source, dest); err != nil {
+		return fmt.Errorf("failed to copy %s: %s", source, strings.TrimSpace(text))
+	}
+	fmt.Printf("%s Copied %s to %s\n", color.GreenString("✓"), rel, color.CyanString(dest))
+	return nil
+}
+
+var (
+	sha1Pattern       = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	storeFnamePattern = regexp.MustCompile(`^[0-9a-f]{2}/[0-9a-f]{40}$`)
+)
+
+// filestorePath turns a checksum, store_fname or relative path into a path
+// inside the database's filestore
+func filestorePath(arg string) (string, error) {
+	arg = strings.Trim(strings.TrimSpace(arg), "/")
+	if arg == "" {
+		return "", nil
+	}
+	if sha1Pattern.MatchString(arg) {
+		return arg[:2] + "/" + arg, nil
+	}
+	clean := path.Clean(arg)
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("%q is outside the filestore", arg)
+	}
+	return clean, nil
+}
+
+func isStoreFname(rel string) bool {
+	return storeFnamePattern.MatchString(rel) && rel[:2] == rel[3:5]
+}
+
+type filestoreAttachment struct {
+	ID       string
+	Name     string
+	Mimetype string
+	ResModel string
+	ResID    string
+}
+
+// Owner describes the record the attachment belongs to
+func (a filestoreAttachment) Owner() string {
+	if a.ResModel == "" {
+		return "no record"
+	}
+	return a.ResModel + "," + a.ResID
+}
+
+// lookupAttachment finds the first ir_attachment stored in rel. Several
+// attachments with identical content share one file.
+func lookupAttachment(state *config.State, database, rel string) (filestoreAttachment, bool) {
+	// rel is validated by isStoreFname, so it is safe to inline
+	query := fmt.Sprintf("SELECT id, name, coalesce(mimetype, ''), coalesce(res_model, ''), coalesce(res_id, 0) FROM ir_attachment WHERE store_fname = '%s' ORDER BY id LIMIT 1", rel)
+	text, err := psqlQuery(state, database, query)
+	if err != nil {
+		return filestoreAttachment{}, false
+	}
+	fields := strings.Split(strings.TrimRight(text, "\n"), "\t")
+	if len(fields) != 5 {
+		return filestoreAttachment{}, false
+	}
+	return filestoreAttachment{ID: fields[0], Name: fields[1], Mimetype: fields[2], ResModel: fields[3], ResID: fields[4]}, true
+}
@@ -0,0 +1,37 @@
+package docker
+
+import "testing"
+
+func TestFilestorePath(t *testing.T) {
+	const sha = "3f786850e387550fdab836ed7e6dc881de23001b"
+	cases := []struct {
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{sha, "3f/" + sha, false},
+		{"3f/" + sha, "3f/" + sha, false},
+		{"/3f/", "3f", false},
+		{"../other_db", "", true},
+		{"3f/../../other_db", "", true},
+	}
+	for _, tc := range cases {
+		got, err := filestorePath(tc.arg)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Fatalf("filestorePath(%q) = %q, %v; want %q, error %v", tc.arg, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestIsStoreFname(t *testing.T) {
+	const sha = "3f786850e387550fdab836ed7e6dc881de23001b"
+	if !isStoreFname("3f/" + sha) {
+		t.Fatal("expected a sharded checksum to be a store_fname")
+	}
+	for _, rel := range []string{"3f", sha, "aa/" + sha, "3f/" + sha + "'; DROP TABLE x; --"} {
+		if isStoreFname(rel) {
+			t.Fatalf("isStoreFname(%q) = true", rel)
+		}
+	}
+}
@@ -1,22 +1,22 @@
 package docker
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/git"
 	"github.com/mart337i/odooctl/internal/output"
 	"github.com/mart337i/odooctl/pkg/prompt"
 	"github.com/spf13/cobra"
 )
 
 var flagGotoJSON bool
+var flagGotoStash bool
 
 var gotoCmd = &cobra.Command{
 	Use:   "goto",
@@ -28,7 +28,11 @@ The command will:
 1. Show a tree view of all projects
 2. Let you select a project
 3. Change to that project's directory
-4. Optionally checkout the associated git branch`,
+4. Optionally checkout the associated git branch
+
+If the working tree has uncommitted changes, --stash (or confirming the prompt)
+stashes them before checking out. Stashes made by goto are popped again
+automatically when you return to the branch they were taken from.`,
 	RunE: runGoto,
 }
 
@@ -41,8 +45,22 @@ type projectInfo struct {
 	ProjectRoot string `json:"project_root"`
 }
 
+// newProjectInfo describes env, marking it when it is the current environment
+func newProjectInfo(env config.Environment, current *config.State) projectInfo {
+	state := env.State
+	return projectInfo{
+		Name:        state.ProjectName,
+		Path:        env.Dir,
+		Branch:      state.Branch,
+		Version:     state.OdooVersion,
+		IsCurrent:   current != nil && state.ProjectName == current.ProjectName && state.Branch == current.Branch,
+		ProjectRoot: state.ProjectRoot,
+	}
+}
+
 func init() {
 	gotoCmd.Flags().BoolVar(&flagGotoJSON, "json", false, "Print JSON output and skip interactive selection")
+	gotoCmd.Flags().BoolVar(&flagGotoStash, "stash", false, "Stash uncommitted changes before checking out the project branch")
 }
 
 func runGoto(cmd *cobra.Command, args []string) error {
@@ -50,72 +68,24 @@ func runGoto(cmd *cobra.Command, args []string) error {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	dim := color.New(color.Faint).SprintFunc()
 
-	configDir, err := config.ConfigDir()
-	if err != nil {
-		return err
-	}
-
 	// Get current directory to mark current project
 	cwd, _ := os.Getwd()
-	var currentProject string
-	if state, err := config.LoadFromDir(cwd); err == nil {
-		currentProject = state.ProjectName
-	}
+	current, _ := config.LoadFromDir(cwd)
 
-	// Scan for projects (new structure: ~/.odooctl/{project}/{branch}/)
-	projectEntries, err := os.ReadDir(configDir)
+	environments, err := config.AllEnvironments()
 	if err != nil {
 		return fmt.Errorf("no projects found")
 	}
 
 	var projects []projectInfo
-
-	for _, projectEntry := range projectEntries {
-		if !projectEntry.IsDir() {
-			continue
-		}
-
-		projectDir := filepath.Join(configDir, projectEntry.Name())
-		branchEntries, err := os.ReadDir(projectDir)
-		if err != nil {
-			continue
-		}
-
-		for _, branchEntry := range branchEntries {
-			if !branchEntry.IsDir() {
-				continue
-			}
-
-			statePath := filepath.Join(projectDir, branchEntry.Name(), config.StateFileName)
-			data, err := os.ReadFile(statePath)
-			if err != nil {
-				continue
-			}
-
-			var state config.State
-			if err := json.Unmarshal(data, &state); err != nil {
-				continue
-			}
-
-			projects = append(projects, projectInfo{
-				Name:        state.ProjectName,
-				Path:        filepath.Join(projectDir, branchEntry.Name()),
-				Branch:      state.Branch,
-				Version:     state.OdooVersion,
-				IsCurrent:   state.ProjectName == currentProject && state.Branch == branchEntry.Name(),
-				ProjectRoot: state.ProjectRoot,
-			})
-		}
+	for _, env := range environments {
+		projects = append(projects, newProjectInfo(env, current))
 	}
 
 	if len(projects) == 0 {
 		return fmt.Errorf("no valid projects found")
 	}
 
-	// Sort by name
-	sort.Slice(projects, func(i, j int) bool {
-		return projects[i].Name < projects[j].Name
-	})
 	if flagGotoJSON {
 		return output.PrintJSON(projects)
 	}
@@ -168,30 +138,8 @@ func runGoto(cmd *cobra.Command, args []string) error {
 	if selected.Branch != "" {
 		gitDir := filepath.Join(selected.ProjectRoot, ".git")
 		if _, err := os.Stat(gitDir); err == nil {
-			// Get current branch
-			cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-			cmd.Dir = selected.ProjectRoot
-			output, err := cmd.Output()
-			if err == nil {
-				currentBranch := strings.TrimSpace(string(output))
-				if currentBranch != selected.Branch {
-					// Check for uncommitted changes
-					cmd := exec.Command("git", "status", "--porcelain")
-					cmd.Dir = selected.ProjectRoot
-					output, _ := cmd.Output()
-					if len(output) > 0 {
-						fmt.Printf("%s Uncommitted changes detected.\n", yellow("⚠️"))
-						fmt.Printf("   Run: git stash && git checkout %s\n", selected.Branch)
-					} else {
-						// Checkout branch
-						fmt.Printf("Checking out branch %s...\n", cyan(selected.Branch))
-						cmd := exec.Command("git", "checkout", selected.Branch)
-						cmd.Dir = selected.ProjectRoot
-						cmd.Stdout = os.Stdout
-						cmd.Stderr = os.Stderr
-						_ = cmd.Run() // Best effort, errors will be visible in stderr
-					}
-				}
+			if err := checkoutProjectBranch(selected.ProjectRoot, selected.Branch); err != nil {
+				fmt.Printf("%s %v\n", yellow("⚠️"), err)
 			}
 		}
 	}
@@ -219,3 +167,59 @@ func runGoto(cmd *cobra.Command, args []string) error {
 
 	return shellCmd.Run()
 }
+
+// checkoutProjectBranch switches dir to branch, stashing uncommitted changes
+// when requested and restoring any stash goto left on the target branch.
+func checkoutProjectBranch(dir, branch string) error {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	currentBranch, err := git.CurrentBranch(dir)
+	if err != nil || currentBranch == branch {
+		return nil
+	}
+
+	dirty, err := git.HasUncommittedChanges(dir)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		fmt.Printf("%s Uncommitted changes detected on %s.\n", yellow("⚠️"), currentBranch)
+		stash := flagGotoStash
+		if !stash {
+			stash, err = prompt.Confirm(fmt.Sprintf("Stash changes and checkout %s?", branch), false)
+			if err != nil {
+				stash = false
+			}
+		}
+		if !stash {
+			fmt.Printf("   Run: git stash && git checkout %s\n", branch)
+			return nil
+		}
+		if err := git.Stash(dir, gotoStashMessage(currentBranch)); err != nil {
+			return err
+		}
+		fmt.Printf("%s Stashed changes from %s\n", color.GreenString("✓"), cyan(currentBranch))
+	}
+
+	fmt.Printf("Checking out branch %s...\n", cyan(branch))
+	if err := git.Checkout(dir, branch); err != nil {
+		return err
+	}
+
+	if ref, ok := git.FindStash(dir, gotoStashMessage(branch)); ok {
+		fmt.Printf("Restoring changes stashed on %s (%s)...\n", cyan(branch), ref)
+		if err := git.StashPop(dir, ref); err != nil {
+			return fmt.Errorf("%w (stash kept as %s)", err, ref)
+		}
+	}
+	if dirty {
+		fmt.Printf("   Your changes from %s are stashed; they are restored automatically when you goto it again\n", currentBranch)
+		fmt.Printf("   or run: git checkout %s && git stash pop\n", currentBranch)
+	}
+	return nil
+}
+
+func gotoStashMessage(branch string) string {
+	return "odooctl goto: " + branch
+}
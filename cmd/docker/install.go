@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/mart337i/odooctl/internal/config"
@@ -13,6 +16,7 @@ import (
 	"github.com/mart337i/odooctl/internal/docker"
 	"github.com/mart337i/odooctl/internal/module"
 	"github.com/mart337i/odooctl/internal/output"
+	"github.com/mart337i/odooctl/pkg/prompt"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +30,11 @@ var (
 	flagInstallDepsMode      string
 	flagInstallSkipDeps      bool
 	flagInstallJSON          bool
+	flagInstallVerify        bool
+	flagInstallParallel      int
+	flagInstallWithDeps      bool
+	flagInstallInteractive   bool
+	flagInstallOdooArgs      []string
 )
 
 type installListReport struct {
@@ -54,14 +63,39 @@ For EXTERNAL modules (Odoo core like sale, purchase):
   - Passes directly to odoo-bin without hash checking
 
 Examples:
-  odooctl docker install                  # Auto-detect changed local modules
+  odooctl docker install                  # Auto-detect changed local modules (fast)
   odooctl docker install sale purchase    # Install core modules
   odooctl docker install my_module        # Install local module  
   odooctl docker install sale_*           # Wildcard for local modules
-  odooctl docker install all              # All local modules
+  odooctl docker install all              # All local modules, re-hashing each one
   odooctl docker install --list-only      # Dry run
   odooctl docker install --update-all     # Force -u base (full upgrade)
-  odooctl docker install --compute-hashes # Store hashes without updating`,
+  odooctl docker install --compute-hashes # Store hashes without updating
+  odooctl docker install --interactive    # Pick local modules from a list
+  odooctl docker install my_module --odoo-arg=--log-handler=odoo.sql_db:DEBUG
+
+Without arguments, modules with no file modified since the last full install
+are skipped before hashing; the rest are still compared by hash. Use "all" to
+hash every module, e.g. after restoring files with their old timestamps.
+
+Local modules that depend on each other are installed in dependency order,
+one odoo-bin run per batch. With --with-deps, the local modules the targets
+depend on are included as well and everything is installed in a single
+odoo-bin run, sorted so dependencies come before their dependents. A
+dependency cycle between local modules is reported as an error.
+
+With --interactive, every local module is hashed and listed as new, changed
+or unchanged, with the new and changed ones preselected. The modules you pick
+are installed or updated; unchanged ones are updated anyway.
+
+With --verify, the local modules installed in the database are compared with
+the stored hashes afterwards, reporting modules whose hash is stored but which
+are not installed (e.g. after a database reset) and installed modules without
+a hash.
+
+--odoo-arg appends an option to every odoo-bin run, before --stop-after-init.
+Pass options with a value as --odoo-arg=--workers=0. Options odooctl sets
+itself (-c, -d, -i, -u, --stop-after-init) are rejected.`,
 	RunE: runInstall,
 }
 
@@ -75,9 +109,17 @@ func init() {
 	installCmd.Flags().StringVar(&flagInstallDepsMode, "deps-mode", "", "Missing dependency behavior: runtime or fail (default: runtime, fail when CI=true)")
 	installCmd.Flags().BoolVar(&flagInstallSkipDeps, "skip-deps", false, "Skip external Python dependency scanning")
 	installCmd.Flags().BoolVar(&flagInstallJSON, "json", false, "Print JSON output with --list-only")
+	installCmd.Flags().IntVar(&flagInstallParallel, "parallel", runtime.NumCPU(), "Number of modules hashed concurrently")
+	installCmd.Flags().BoolVar(&flagInstallWithDeps, "with-deps", false, "Include local dependencies of the targets and install in dependency order in one run")
+	installCmd.Flags().BoolVar(&flagInstallInteractive, "interactive", false, "Choose the local modules to install or update from a list")
+	installCmd.Flags().BoolVar(&flagInstallVerify, "verify", false, "Compare installed modules in the database with the stored hashes afterwards")
+	installCmd.Flags().StringArrayVar(&flagInstallOdooArgs, "odoo-arg", nil, "Extra odoo-bin argument (can specify multiple times)")
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
+	if err := validateOdooArgs(flagInstallOdooArgs, "-i", "--init", "-u", "--update"); err != nil {
+		return err
+	}
 	state, err := loadState()
 	if err != nil {
 		return err
@@ -87,6 +129,15 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
 
+	if flagInstallInteractive {
+		if flagInstallJSON || flagInstallUpdateAll || flagInstallComputeHashes {
+			return fmt.Errorf("--interactive cannot be combined with --json, --update-all or --compute-hashes")
+		}
+		if prompt.NonInteractive() {
+			return fmt.Errorf("--interactive needs a terminal; pass the modules as arguments instead")
+		}
+	}
+
 	// Handle --update-all flag (force -u base)
 	if flagInstallUpdateAll {
 		if flagInstallJSON {
@@ -160,6 +211,10 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if flagInstallWithDeps {
+		localTargets = withLocalDependencies(state, localTargets, localModuleSet)
+	}
+
 	// Apply ignore filter
 	if flagInstallIgnore != "" {
 		ignoreList := strings.Split(flagInstallIgnore, ",")
@@ -188,6 +243,9 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	// Handle hash-based detection for local modules
 	var localInstall, localUpdate []string
 	currentHashes := make(map[string]string)
+	scanStartedAt := time.Now()
+	// Only a check of every local module can vouch for LastInstallAt
+	fullScan := (len(args) == 0 || (len(args) == 1 && strings.ToLower(args[0]) == "all")) && flagInstallIgnore == "" && !flagInstallInteractive
 
 	if len(localTargets) > 0 {
 		storedHashes, err := loadHashes(state)
@@ -197,20 +255,66 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 		fmt.Printf("Checking %d local modules...\n", len(localTargets))
 
+		skipped := 0
+		var toHash []string
 		for _, mod := range localTargets {
 			modPath := filepath.Join(state.ProjectRoot, mod)
-			hash, err := module.Hash(modPath)
-			if err != nil {
-				fmt.Printf("%s Failed to hash %q: %v\n", yellow("!"), mod, err)
+			if len(args) == 0 && !flagInstallInteractive && unchangedSinceLastInstall(state, storedHashes, mod, modPath) {
+				skipped++
 				continue
 			}
-			currentHashes[mod] = hash
+			toHash = append(toHash, mod)
+		}
+
+		var localUnchanged []string
+		for _, result := range module.HashModules(state.ProjectRoot, toHash, flagInstallParallel) {
+			mod := result.Module
+			if result.Err != nil {
+				fmt.Printf("%s Failed to hash %q: %v\n", yellow("!"), mod, result.Err)
+				fullScan = false
+				continue
+			}
+			currentHashes[mod] = result.Hash
 
 			storedHash, exists := storedHashes[mod]
 			if !exists {
 				localInstall = append(localInstall, mod)
-			} else if storedHash != hash {
+			} else if storedHash != result.Hash {
 				localUpdate = append(localUpdate, mod)
+			} else {
+				localUnchanged = append(localUnchanged, mod)
+			}
+		}
+
+		if skipped > 0 && !flagInstallJSON {
+			fmt.Printf("%s %d module(s) untouched since the last install were not re-hashed\n", cyan("ℹ"), skipped)
+		}
+
+		if flagInstallInteractive {
+			if localInstall, localUpdate, err = selectLocalModules(storedHashes, localInstall, localUpdate, localUnchanged); err != nil {
+				return err
+			}
+			// Hashes are saved only for the modules picked
+			selected := make(map[string]string)
+			for _, mod := range append(append([]string{}, localInstall...), localUpdate...) {
+				selected[mod] = currentHashes[mod]
+			}
+			currentHashes = selected
+			if len(selected) == 0 && len(externalTargets) == 0 {
+				fmt.Println("No modules selected")
+				return nil
+			}
+		}
+
+		if flagInstallWithDeps {
+			if localInstall, localUpdate, err = orderLocalTargets(state, localInstall, localUpdate); err != nil {
+				return err
+			}
+		}
+
+		if !flagInstallJSON {
+			for _, warning := range missingLocalDependencies(state, append(append([]string{}, localInstall...), localUpdate...), localModuleSet, storedHashes) {
+				fmt.Printf("%s %s\n", yellow("!"), warning)
 			}
 		}
 
@@ -222,6 +326,9 @@ func runInstall(cmd *cobra.Command, args []string) error {
 			if err := saveHashes(state, storedHashes); err != nil {
 				return fmt.Errorf("failed to save hashes: %w", err)
 			}
+			if fullScan {
+				markInstallScan(state, scanStartedAt)
+			}
 			fmt.Printf("%s Computed and saved hashes for %d modules\n", green("✓"), len(currentHashes))
 			return nil
 		}
@@ -245,6 +352,8 @@ func runInstall(cmd *cobra.Command, args []string) error {
 			}
 			if len(localInstall) == 0 && len(localUpdate) == 0 {
 				fmt.Println("\nNo local modules need updating")
+			} else if flagInstallWithDeps {
+				fmt.Printf("\nInstall order: %s\n", strings.Join(append(append([]string{}, localInstall...), localUpdate...), " → "))
 			}
 			if len(externalTargets) > 0 {
 				fmt.Printf("\nExternal modules to install: %s\n", cyan(strings.Join(externalTargets, ", ")))
@@ -259,7 +368,13 @@ func runInstall(cmd *cobra.Command, args []string) error {
 			return output.PrintJSON(buildInstallListReport(localInstall, localUpdate, externalTargets))
 		}
 		if len(localTargets) > 0 {
+			if fullScan {
+				markInstallScan(state, scanStartedAt)
+			}
 			fmt.Printf("%s All local modules are up to date\n", green("✓"))
+			if flagInstallVerify {
+				verifyInstalledModules(state, localModules)
+			}
 		} else if len(args) == 0 {
 			fmt.Printf("%s No local modules found and no modules specified\n", yellow("!"))
 		} else {
@@ -300,7 +415,12 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	// Run odoo-bin via docker compose
 	fmt.Println("Running install/update...")
-	installErr := runOdooUpdate(state, allInstall, allUpdate)
+	var installErr error
+	if flagInstallWithDeps {
+		installErr = runOdooUpdate(state, allInstall, allUpdate)
+	} else {
+		installErr = runOdooUpdateBatches(state, externalTargets, localInstall, localUpdate)
+	}
 
 	// Always restart the odoo container, even if install failed
 	fmt.Println("Restarting Odoo container...")
@@ -339,13 +459,139 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		}
 		if err := saveHashes(state, storedHashes); err != nil {
 			fmt.Printf("%s Warning: failed to save hashes: %v\n", yellow("!"), err)
+		} else if fullScan {
+			markInstallScan(state, scanStartedAt)
 		}
 	}
 
 	fmt.Printf("\n%s Installation complete\n", green("✓"))
+	if flagInstallVerify {
+		verifyInstalledModules(state, localModules)
+	}
 	return nil
 }
 
+// verifyInstalledModules reports local modules whose stored hash disagrees
+// with their state in the database. It only warns, the install itself is done.
+func verifyInstalledModules(state *config.State, localModules []string) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Println("\nVerifying installed modules...")
+	text, err := psqlQuery(state, state.DBName(), "SELECT name FROM ir_module_module WHERE state = 'installed'")
+	if err != nil {
+		fmt.Printf("%s Could not verify: %v\n", yellow("!"), err)
+		return
+	}
+	installed := make(map[string]bool)
+	for _, line := range strings.Split(text, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			installed[name] = true
+		}
+	}
+	storedHashes, err := loadHashes(state)
+	if err != nil {
+		storedHashes = make(map[string]string)
+	}
+
+	notInstalled, untracked := installDiscrepancies(localModules, storedHashes, installed)
+	if len(notInstalled) == 0 && len(untracked) == 0 {
+		fmt.Printf("%s Database and stored hashes agree on all %d local modules\n", color.GreenString("✓"), len(localModules))
+		return
+	}
+	if len(notInstalled) > 0 {
+		path, _ := hashFilePath(state)
+		fmt.Printf("%s Hashed as up to date but not installed in %s: %s\n", yellow("!"), state.DBName(), strings.Join(notInstalled, ", "))
+		fmt.Printf("  Remove their entries from %s and run 'odooctl docker install' to install them\n", path)
+	}
+	if len(untracked) > 0 {
+		fmt.Printf("%s Installed in %s without a stored hash: %s\n", yellow("!"), state.DBName(), strings.Join(untracked, ", "))
+		fmt.Println("  Run 'odooctl docker install all' to store their hashes")
+	}
+}
+
+// installDiscrepancies compares local modules' stored hashes with the modules
+// installed in the database
+func installDiscrepancies(localModules []string, storedHashes map[string]string, installed map[string]bool) (notInstalled, untracked []string) {
+	for _, mod := range localModules {
+		_, hashed := storedHashes[mod]
+		switch {
+		case hashed && !installed[mod]:
+			notInstalled = append(notInstalled, mod)
+		case !hashed && installed[mod]:
+			untracked = append(untracked, mod)
+		}
+	}
+	sort.Strings(notInstalled)
+	sort.Strings(untracked)
+	return notInstalled, untracked
+}
+
+// installChoices labels local modules with their status for the
+// --interactive prompt, preselecting new and changed ones. It returns the
+// options, the preselected options and the module of each option.
+func installChoices(localInstall, localUpdate, localUnchanged []string) ([]string, []string, map[string]string) {
+	var options, defaults []string
+	modules := make(map[string]string)
+	add := func(mods []string, status string, preselect bool) {
+		sorted := append([]string{}, mods...)
+		sort.Strings(sorted)
+		for _, mod := range sorted {
+			option := fmt.Sprintf("%s (%s)", mod, status)
+			options = append(options, option)
+			modules[option] = mod
+			if preselect {
+				defaults = append(defaults, option)
+			}
+		}
+	}
+	add(localInstall, "new", true)
+	add(localUpdate, "changed", true)
+	add(localUnchanged, "unchanged", false)
+	return options, defaults, modules
+}
+
+// selectLocalModules asks which local modules to install or update. Picked
+// modules without a stored hash are installed, the others updated.
+func selectLocalModules(storedHashes map[string]string, localInstall, localUpdate, localUnchanged []string) ([]string, []string, error) {
+	options, defaults, modules := installChoices(localInstall, localUpdate, localUnchanged)
+	if len(options) == 0 {
+		return nil, nil, nil
+	}
+	selected, err := prompt.MultiSelect("Select modules to install or update:", options, defaults)
+	if err != nil {
+		return nil, nil, err
+	}
+	var install, update []string
+	for _, option := range selected {
+		mod := modules[option]
+		if _, exists := storedHashes[mod]; exists {
+			update = append(update, mod)
+		} else {
+			install = append(install, mod)
+		}
+	}
+	return install, update, nil
+}
+
+// unchangedSinceLastInstall reports whether mod can skip hashing: it has a
+// stored hash and none of its files changed since the last full install.
+func unchangedSinceLastInstall(state *config.State, storedHashes map[string]string, mod, modPath string) bool {
+	if state.LastInstallAt == nil || storedHashes[mod] == "" {
+		return false
+	}
+	modified, err := module.ModifiedSince(modPath, *state.LastInstallAt)
+	return err == nil && !modified
+}
+
+// markInstallScan records that every local module's stored hash matched its
+// contents as of scanStartedAt. Files edited during the run stay newer.
+func markInstallScan(state *config.State, scanStartedAt time.Time) {
+	state.LastInstallAt = &scanStartedAt
+	if err := state.Save(); err != nil {
+		fmt.Printf("%s Warning: failed to save state: %v\n", color.YellowString("!"), err)
+	}
+}
+
 func buildInstallListReport(localInstall, localUpdate, externalTargets []string) installListReport {
 	report := installListReport{
 		NewLocal:     append([]string{}, localInstall...),
@@ -389,6 +635,122 @@ func ensureInstallPythonDeps(state *config.State, targetModules []string) ([]str
 	}
 }
 
+// localDepends reads the manifest depends of local project modules.
+func localDepends(state *config.State, modules []string) map[string][]string {
+	depends := make(map[string][]string, len(modules))
+	for _, mod := range modules {
+		deps, err := module.ParseDepends(filepath.Join(state.ProjectRoot, mod, "__manifest__.py"))
+		if err != nil {
+			continue
+		}
+		depends[mod] = deps
+	}
+	return depends
+}
+
+// withLocalDependencies adds the local modules that targets depend on,
+// directly or indirectly, to targets
+func withLocalDependencies(state *config.State, targets []string, localModuleSet map[string]bool) []string {
+	result := append([]string{}, targets...)
+	seen := make(map[string]bool, len(targets))
+	for _, mod := range targets {
+		seen[mod] = true
+	}
+	for queue := targets; len(queue) > 0; {
+		depends := localDepends(state, queue)
+		var next []string
+		for _, mod := range queue {
+			for _, dep := range depends[mod] {
+				if localModuleSet[dep] && !seen[dep] {
+					seen[dep] = true
+					next = append(next, dep)
+				}
+			}
+		}
+		result = append(result, next...)
+		queue = next
+	}
+	return result
+}
+
+// orderLocalTargets sorts the local modules to install and update into
+// dependency order, failing on a dependency cycle between them
+func orderLocalTargets(state *config.State, localInstall, localUpdate []string) ([]string, []string, error) {
+	targets := append(append([]string{}, localInstall...), localUpdate...)
+	order, err := module.InstallOrder(targets, localDepends(state, targets))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot order modules for --with-deps: %w", err)
+	}
+	installSet := make(map[string]bool, len(localInstall))
+	for _, mod := range localInstall {
+		installSet[mod] = true
+	}
+	var install, update []string
+	for _, mod := range order {
+		if installSet[mod] {
+			install = append(install, mod)
+		} else {
+			update = append(update, mod)
+		}
+	}
+	return install, update, nil
+}
+
+// missingLocalDependencies reports local modules that targets depend on but
+// which are neither part of this run nor installed by a previous one.
+func missingLocalDependencies(state *config.State, targets []string, localModuleSet map[string]bool, storedHashes map[string]string) []string {
+	targetSet := make(map[string]bool, len(targets))
+	for _, mod := range targets {
+		targetSet[mod] = true
+	}
+	var warnings []string
+	depends := localDepends(state, targets)
+	for _, mod := range targets {
+		for _, dep := range depends[mod] {
+			if !localModuleSet[dep] || targetSet[dep] {
+				continue
+			}
+			if _, installed := storedHashes[dep]; !installed {
+				warnings = append(warnings, fmt.Sprintf("%s depends on local module %s, which is not installed and not part of this run", mod, dep))
+			}
+		}
+	}
+	return warnings
+}
+
+// runOdooUpdateBatches installs and updates local modules in dependency
+// order, one odoo-bin run per batch. External modules go with the first batch.
+func runOdooUpdateBatches(state *config.State, external, localInstall, localUpdate []string) error {
+	installSet := make(map[string]bool, len(localInstall))
+	for _, mod := range localInstall {
+		installSet[mod] = true
+	}
+	targets := append(append([]string{}, localInstall...), localUpdate...)
+	batches := module.InstallBatches(targets, localDepends(state, targets))
+	if len(batches) <= 1 {
+		return runOdooUpdate(state, append(append([]string{}, external...), localInstall...), localUpdate)
+	}
+
+	for i, batch := range batches {
+		var install, update []string
+		if i == 0 {
+			install = append(install, external...)
+		}
+		for _, mod := range batch {
+			if installSet[mod] {
+				install = append(install, mod)
+			} else {
+				update = append(update, mod)
+			}
+		}
+		fmt.Printf("%s Batch %d/%d: %s\n", color.CyanString("📦"), i+1, len(batches), strings.Join(batch, ", "))
+		if err := runOdooUpdate(state, install, update); err != nil {
+			return fmt.Errorf("batch %d/%d failed: %w", i+1, len(batches), err)
+		}
+	}
+	return nil
+}
+
 func runOdooUpdate(state *config.State, install, update []string) error {
 	// Build odoo-bin command
 	args := []string{
@@ -403,6 +765,7 @@ func runOdooUpdate(state *config.State, install, update []string) error {
 	if len(update) > 0 {
 		args = append(args, "-u", strings.Join(update, ","))
 	}
+	args = append(args, flagInstallOdooArgs...)
 	args = append(args, "--stop-after-init")
 
 	return docker.Compose(state, args...)
@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/pkg/prompt"
+)
+
+func TestInstallDiscrepancies(t *testing.T) {
+	local := []string{"shop", "shop_theme", "shop_report", "helpdesk_ext"}
+	hashes := map[string]string{"shop": "a", "shop_theme": "b", "helpdesk_ext": "c"}
+	installed := map[string]bool{"base": true, "shop": true, "shop_report": true}
+
+	notInstalled, untracked := installDiscrepancies(local, hashes, installed)
+	if want := []string{"helpdesk_ext", "shop_theme"}; !reflect.DeepEqual(notInstalled, want) {
+		t.Errorf("notInstalled = %v, want %v", notInstalled, want)
+	}
+	if want := []string{"shop_report"}; !reflect.DeepEqual(untracked, want) {
+		t.Errorf("untracked = %v, want %v", untracked, want)
+	}
+}
+
+func TestWithLocalDependencies(t *testing.T) {
+	root := t.TempDir()
+	manifests := map[string]string{
+		"shop_report": `{'depends': ['shop', 'account']}`,
+		"shop":        `{'depends': ['sale', 'shop_base']}`,
+		"shop_base":   `{'depends': ['base']}`,
+		"helpdesk":    `{'depends': ['shop']}`,
+	}
+	localModuleSet := map[string]bool{}
+	for mod, manifest := range manifests {
+		localModuleSet[mod] = true
+		if err := os.MkdirAll(filepath.Join(root, mod), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, mod, "__manifest__.py"), []byte(manifest), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	state := &config.State{ProjectRoot: root}
+
+	got := withLocalDependencies(state, []string{"shop_report"}, localModuleSet)
+	if want := []string{"shop_report", "shop", "shop_base"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("withLocalDependencies() = %v, want %v", got, want)
+	}
+
+	install, update, err := orderLocalTargets(state, []string{"shop_report", "shop_base"}, []string{"shop"})
+	if err != nil {
+		t.Fatalf("orderLocalTargets() error = %v", err)
+	}
+	if want := []string{"shop_base", "shop_report"}; !reflect.DeepEqual(install, want) {
+		t.Errorf("install = %v, want %v", install, want)
+	}
+	if want := []string{"shop"}; !reflect.DeepEqual(update, want) {
+		t.Errorf("update = %v, want %v", update, want)
+	}
+}
+
+func TestInstallChoices(t *testing.T) {
+	options, defaults, modules := installChoices([]string{"sale_b", "sale_a"}, []string{"stock_x"}, []string{"crm_y"})
+	wantOptions := []string{"sale_a (new)", "sale_b (new)", "stock_x (changed)", "crm_y (unchanged)"}
+	if !reflect.DeepEqual(options, wantOptions) {
+		t.Fatalf("options = %v, want %v", options, wantOptions)
+	}
+	if !reflect.DeepEqual(defaults, wantOptions[:3]) {
+		t.Fatalf("defaults = %v, want the new and changed modules", defaults)
+	}
+	if modules["crm_y (unchanged)"] != "crm_y" {
+		t.Fatalf("modules = %v", modules)
+	}
+
+	prompt.SetNonInteractive(true)
+	defer prompt.SetNonInteractive(false)
+	stored := map[string]string{"stock_x": "old", "crm_y": "same"}
+	install, update, err := selectLocalModules(stored, []string{"sale_a"}, []string{"stock_x"}, []string{"crm_y"})
+	if err != nil || !reflect.DeepEqual(install, []string{"sale_a"}) || !reflect.DeepEqual(update, []string{"stock_x"}) {
+		t.Fatalf("selectLocalModules() = %v, %v, %v", install, update, err)
+	}
+}
@@ -0,0 +1,104 @@
+package docker
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/lint"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var flagLintJSON bool
+
+type lintReport struct {
+	OK       bool           `json:"ok"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	Findings []lint.Finding `json:"findings"`
+}
+
+var lintCmd = &cobra.Command{
+	Use:          "lint",
+	Short:        "Check odoo.conf and docker-compose.yml for misconfigurations",
+	SilenceUsage: true,
+	Long: `Checks the environment's generated odoo.conf and docker-compose.yml for
+mistakes that only show up once the containers run, typically after manual
+'odooctl docker edit' changes:
+
+  - addons_path entries that are not mounted into the container, or whose
+    host directory no longer exists
+  - db_host that is not a compose service, db_port other than 5432
+  - workers > 0 without a published longpolling/gevent port
+  - duplicate options, addons paths and module entries
+
+Exits with status 1 when errors are found.
+
+Examples:
+  odooctl docker lint
+  odooctl docker lint --json`,
+	Args: cobra.NoArgs,
+	RunE: runLint,
+}
+
+func init() {
+	lintCmd.Flags().BoolVar(&flagLintJSON, "json", false, "Print JSON output")
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	findings, err := lint.Environment(state)
+	if err != nil {
+		return err
+	}
+
+	report := lintReport{Findings: findings}
+	if report.Findings == nil {
+		report.Findings = []lint.Finding{}
+	}
+	for _, finding := range findings {
+		if finding.Severity == lint.SeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+	report.OK = report.Errors == 0
+
+	if flagLintJSON {
+		if err := output.PrintJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printLintReport(report)
+	}
+	if !report.OK {
+		return &output.ExitCodeError{Code: 1, Message: fmt.Sprintf("%d configuration error(s) found", report.Errors)}
+	}
+	return nil
+}
+
+func printLintReport(report lintReport) {
+	if len(report.Findings) == 0 {
+		fmt.Printf("%s odoo.conf and docker-compose.yml look good\n", color.GreenString("✓"))
+		return
+	}
+
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	for _, finding := range report.Findings {
+		location := finding.File
+		if finding.Line > 0 {
+			location = fmt.Sprintf("%s:%d", finding.File, finding.Line)
+		}
+		label := yellow("warning")
+		if finding.Severity == lint.SeverityError {
+			label = red("error  ")
+		}
+		fmt.Printf("  %s %-24s %s\n", label, location, finding.Message)
+	}
+	fmt.Printf("\n%d error(s), %d warning(s). Fix them with 'odooctl docker edit' or regenerate the files with 'odooctl docker reconfigure'\n", report.Errors, report.Warnings)
+}
@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var flagListJSON bool
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List every environment across all projects",
+	Long: `Lists the environments in ~/.odooctl with their Odoo version, port, whether
+their containers are running and the project directory. The current
+environment is marked with an arrow.
+
+Examples:
+  odooctl docker list
+  odooctl docker ls --json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runList,
+}
+
+type environmentListEntry struct {
+	projectInfo
+	Port    int  `json:"port"`
+	Running bool `json:"running"`
+}
+
+func init() {
+	listCmd.Flags().BoolVar(&flagListJSON, "json", false, "Print JSON output")
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	environments, err := config.AllEnvironments()
+	if err != nil {
+		return fmt.Errorf("failed to read environments: %w", err)
+	}
+	cwd, _ := os.Getwd()
+	current, _ := config.LoadFromDir(cwd)
+
+	entries := []environmentListEntry{}
+	for _, env := range environments {
+		entries = append(entries, environmentListEntry{
+			projectInfo: newProjectInfo(env, current),
+			Port:        env.State.Ports.Odoo,
+			Running:     docker.IsRunning(env.State),
+		})
+	}
+	if flagListJSON {
+		return output.PrintJSON(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No environments found. Run 'odooctl docker create' in a project to add one.")
+		return nil
+	}
+	return output.WriteTable(os.Stdout, environmentListTable(entries))
+}
+
+func environmentListTable(entries []environmentListEntry) output.Table {
+	home, _ := os.UserHomeDir()
+	table := output.Table{Headers: []string{"  environment", "version", "port", "state", "project root"}}
+	for _, entry := range entries {
+		marker := "  "
+		if entry.IsCurrent {
+			marker = "→ "
+		}
+		state := "stopped"
+		if entry.Running {
+			state = "running"
+		}
+		root := entry.ProjectRoot
+		if home != "" {
+			root = strings.Replace(root, home, "~", 1)
+		}
+		table.Rows = append(table.Rows, []string{marker + entry.Name + "/" + entry.Branch, entry.Version, strconv.Itoa(entry.Port), state, root})
+	}
+	return table
+}
@@ -1,45 +1,68 @@
 package docker
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
 	"strings"
 
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
 	"github.com/mart337i/odooctl/internal/docker"
 	"github.com/mart337i/odooctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var (
-	flagFollow    bool
-	flagLogTail   int
-	flagLogJSON   bool
-	flagLogGrep   string
-	flagLogErrors bool
-	flagLogSince  string
+	flagFollow        bool
+	flagLogTail       int
+	flagLogJSON       bool
+	flagLogGrep       string
+	flagLogErrors     bool
+	flagLogSince      string
+	flagLogFull       bool
+	flagLogErrorsOnly bool
+	flagLogTimestamps bool
+	flagLogAll        bool
 )
 
 type logsReport struct {
-	Service string `json:"service"`
-	Tail    int    `json:"tail"`
-	Since   string `json:"since,omitempty"`
-	Grep    string `json:"grep,omitempty"`
-	Errors  bool   `json:"errors"`
-	Text    string `json:"text"`
+	Service    string   `json:"service,omitempty"`
+	Services   []string `json:"services,omitempty"`
+	Tail       int      `json:"tail"`
+	Full       bool     `json:"full,omitempty"`
+	Since      string   `json:"since,omitempty"`
+	Grep       string   `json:"grep,omitempty"`
+	Errors     bool     `json:"errors"`
+	ErrorsOnly bool     `json:"errors_only,omitempty"`
+	Text       string   `json:"text"`
 }
 
 var logsCmd = &cobra.Command{
-	Use:          "logs [service]",
+	Use:          "logs [service...]",
 	Short:        "View container logs",
 	SilenceUsage: true,
-	Long: `Shows logs from Docker containers. Defaults to the odoo service.
+	Long: `Shows logs from Docker containers. Defaults to the odoo service; name
+several services to interleave their logs, or pass --all for every service.
 
 Examples:
   odooctl docker logs             # Last 100 lines of odoo logs
   odooctl docker logs -f          # Follow odoo logs
   odooctl docker logs --tail 50   # Last 50 lines
   odooctl docker logs --errors    # Tracebacks and common Odoo errors
+  odooctl docker logs -f --errors-only  # Only WARNING/ERROR/CRITICAL records, with tracebacks
   odooctl docker logs --grep Traceback --since 10m
-  odooctl docker logs db          # View database logs`,
+  odooctl docker logs --full      # Whole history, including rotated log files
+  odooctl docker logs db          # View database logs
+  odooctl docker logs odoo db -f -t  # Follow odoo and db logs with timestamps
+  odooctl docker logs --all --since 5m
+
+Container logs are rotated (json-file driver, 5 files of 10m by default) so they
+can't fill the disk. Change the limits with:
+  odooctl docker reconfigure --log-max-size 50m --log-max-file 10`,
 	RunE: runLogs,
 }
 
@@ -50,6 +73,10 @@ func init() {
 	logsCmd.Flags().StringVar(&flagLogGrep, "grep", "", "Filter log lines containing text (case-insensitive)")
 	logsCmd.Flags().BoolVar(&flagLogErrors, "errors", false, "Filter common Odoo error and traceback lines")
 	logsCmd.Flags().StringVar(&flagLogSince, "since", "", "Show logs since a duration or timestamp, passed to docker compose logs")
+	logsCmd.Flags().BoolVar(&flagLogFull, "full", false, "Show the complete history across rotated log files (ignores --tail)")
+	logsCmd.Flags().BoolVar(&flagLogErrorsOnly, "errors-only", false, "Show only WARNING, ERROR and CRITICAL Odoo log records, keeping their tracebacks")
+	logsCmd.Flags().BoolVarP(&flagLogTimestamps, "timestamps", "t", false, "Show timestamps")
+	logsCmd.Flags().BoolVar(&flagLogAll, "all", false, "Show logs of every service")
 }
 
 func runLogs(cmd *cobra.Command, args []string) error {
@@ -58,34 +85,52 @@ func runLogs(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	service := "odoo"
-	if len(args) > 0 {
-		service = args[0]
+	services, err := logServices(args, flagLogAll)
+	if err != nil {
+		return err
+	}
+	if flagLogErrorsOnly && (flagLogGrep != "" || flagLogErrors) {
+		return fmt.Errorf("--errors-only cannot be combined with --grep or --errors")
 	}
-	filtering := flagLogJSON || flagLogGrep != "" || flagLogErrors
+	// --errors-only filters while streaming, so it can follow
+	filtering := flagLogJSON || flagLogGrep != "" || flagLogErrors || (flagLogErrorsOnly && !flagFollow)
 	if flagFollow && filtering {
 		return fmt.Errorf("--follow cannot be used with --json, --grep, or --errors")
 	}
 
-	logArgs := []string{"logs"}
-	if flagFollow {
-		logArgs = append(logArgs, "-f")
-	}
-	if flagLogTail > 0 {
-		logArgs = append(logArgs, "--tail", fmt.Sprintf("%d", flagLogTail))
-	}
-	if flagLogSince != "" {
-		logArgs = append(logArgs, "--since", flagLogSince)
+	tail := flagLogTail
+	if flagLogFull {
+		fullServices := services
+		if len(fullServices) == 0 {
+			if fullServices, err = docker.ServiceNames(state); err != nil {
+				return err
+			}
+		}
+		for _, service := range fullServices {
+			if err := checkFullLogHistory(state, service, !flagLogJSON); err != nil {
+				return err
+			}
+		}
+		tail = 0
 	}
-	logArgs = append(logArgs, service)
+
+	logArgs := composeLogsArgs(services, flagFollow, flagLogTimestamps, tail, flagLogSince)
 	if filtering {
 		text, err := docker.ComposeOutput(state, logArgs...)
 		if err != nil {
 			return err
 		}
-		text = filterLogText(text, flagLogGrep, flagLogErrors)
+		if flagLogErrorsOnly {
+			text = filterOdooLogLevels(text)
+		} else {
+			text = filterLogText(text, flagLogGrep, flagLogErrors)
+		}
 		if flagLogJSON {
-			return output.PrintJSON(logsReport{Service: service, Tail: flagLogTail, Since: flagLogSince, Grep: flagLogGrep, Errors: flagLogErrors, Text: text})
+			report := logsReport{Services: services, Tail: tail, Full: flagLogFull, Since: flagLogSince, Grep: flagLogGrep, Errors: flagLogErrors, ErrorsOnly: flagLogErrorsOnly, Text: text}
+			if len(services) == 1 {
+				report.Service = services[0]
+			}
+			return output.PrintJSON(report)
 		}
 		fmt.Print(text)
 		if !strings.HasSuffix(text, "\n") && text != "" {
@@ -93,10 +138,132 @@ func runLogs(cmd *cobra.Command, args []string) error {
 		}
 		return nil
 	}
+	if flagLogErrorsOnly {
+		return followOdooProblems(state, logArgs)
+	}
 
 	return docker.Compose(state, logArgs...)
 }
 
+// logServices returns the services to show logs for: the named ones, odoo
+// when none is named, or none (meaning all) with --all
+func logServices(args []string, all bool) ([]string, error) {
+	if all {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("--all cannot be combined with service names")
+		}
+		return nil, nil
+	}
+	if len(args) == 0 {
+		return []string{"odoo"}, nil
+	}
+	return args, nil
+}
+
+// composeLogsArgs builds the docker compose logs arguments. A tail of 0
+// shows the whole history.
+func composeLogsArgs(services []string, follow, timestamps bool, tail int, since string) []string {
+	logArgs := []string{"logs"}
+	if follow {
+		logArgs = append(logArgs, "-f")
+	}
+	if timestamps {
+		logArgs = append(logArgs, "-t")
+	}
+	if tail > 0 {
+		logArgs = append(logArgs, "--tail", strconv.Itoa(tail))
+	}
+	if since != "" {
+		logArgs = append(logArgs, "--since", since)
+	}
+	return append(logArgs, services...)
+}
+
+// followOdooProblems streams docker compose logs, printing only the records
+// odooLogFilter keeps.
+func followOdooProblems(state *config.State, logArgs []string) error {
+	logCmd := docker.ComposeCommand(state, logArgs...)
+	logCmd.Stderr = os.Stderr
+	stdout, err := logCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := logCmd.Start(); err != nil {
+		return fmt.Errorf("failed to read logs: %w", err)
+	}
+
+	var filter odooLogFilter
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); filter.Keep(line) {
+			fmt.Println(line)
+		}
+	}
+	return logCmd.Wait()
+}
+
+// filterOdooLogLevels keeps the lines of text that odooLogFilter keeps
+func filterOdooLogLevels(text string) string {
+	var filter odooLogFilter
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if filter.Keep(line) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// containerLogConfig mirrors HostConfig.LogConfig from docker inspect
+type containerLogConfig struct {
+	Type   string            `json:"Type"`
+	Config map[string]string `json:"Config"`
+}
+
+// checkFullLogHistory makes sure the service's log driver can be read back
+// in full and tells the user how much history rotation keeps.
+func checkFullLogHistory(state *config.State, service string, verbose bool) error {
+	ids, err := docker.ComposeOutput(state, "ps", "-a", "-q", service)
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(ids)
+	if len(fields) == 0 {
+		return fmt.Errorf("no container found for service %q", service)
+	}
+
+	out, err := exec.Command("docker", "inspect", "--format", "{{json .HostConfig.LogConfig}}", fields[0]).Output()
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s container: %w", service, err)
+	}
+	var logConfig containerLogConfig
+	if err := json.Unmarshal(out, &logConfig); err != nil {
+		return fmt.Errorf("failed to parse log configuration: %w", err)
+	}
+
+	switch logConfig.Type {
+	case "json-file", "local", "journald":
+	default:
+		return fmt.Errorf("the %s container logs to the %q driver, which docker can't read back", service, logConfig.Type)
+	}
+	if !verbose {
+		return nil
+	}
+
+	yellow := color.New(color.FgYellow).SprintFunc()
+	maxSize, maxFile := logConfig.Config["max-size"], logConfig.Config["max-file"]
+	if maxSize == "" {
+		fmt.Printf("%s The %s container has no log rotation; regenerate it with 'odooctl docker reconfigure --log-max-size 10m' to cap log size\n", yellow("⚠️"), service)
+		return nil
+	}
+	if maxFile == "" {
+		maxFile = "1"
+	}
+	fmt.Printf("%s Showing all retained logs (rotation keeps up to %s files of %s)\n", color.CyanString("ℹ"), maxFile, maxSize)
+	return nil
+}
+
 func filterLogText(text, grep string, errorsOnly bool) string {
 	if grep == "" && !errorsOnly {
 		return text
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -25,3 +26,54 @@ func TestFilterLogTextErrors(t *testing.T) {
 		t.Fatalf("filtered output included info line: %q", filtered)
 	}
 }
+
+func TestFilterOdooLogLevelsKeepsTracebacks(t *testing.T) {
+	text := strings.Join([]string{
+		"odoo-1  | 2024-05-01 10:00:00,123 7 INFO db odoo.modules.loading: loading 42 modules...",
+		"odoo-1  | 2024-05-01 10:00:01,456 7 ERROR db odoo.http: Exception during request handling.",
+		"odoo-1  | Traceback (most recent call last):",
+		"odoo-1  |   File \"/usr/lib/python3/dist-packages/odoo/http.py\", line 1, in _serve_db",
+		"odoo-1  | ValueError: boom",
+		"odoo-1  | 2024-05-01 10:00:02,000 7 INFO db werkzeug: GET / HTTP/1.1 500",
+		"odoo-1  | continuation of an info record",
+		"odoo-1  | 2024-05-01 10:00:03,000 7 WARNING db odoo.fields: Field x has no string",
+	}, "\n")
+
+	filtered := filterOdooLogLevels(text)
+	for _, want := range []string{" ERROR db odoo.http", "Traceback (most recent call last):", "in _serve_db", "ValueError: boom", " WARNING db odoo.fields"} {
+		if !strings.Contains(filtered, want) {
+			t.Fatalf("filtered output missing %q:\n%s", want, filtered)
+		}
+	}
+	for _, unwanted := range []string{"loading 42 modules", "werkzeug", "continuation of an info record"} {
+		if strings.Contains(filtered, unwanted) {
+			t.Fatalf("filtered output included %q:\n%s", unwanted, filtered)
+		}
+	}
+}
+
+func TestLogServices(t *testing.T) {
+	if services, err := logServices(nil, false); err != nil || !reflect.DeepEqual(services, []string{"odoo"}) {
+		t.Errorf("logServices(nil) = %v, %v, want [odoo]", services, err)
+	}
+	if services, err := logServices([]string{"odoo", "db"}, false); err != nil || !reflect.DeepEqual(services, []string{"odoo", "db"}) {
+		t.Errorf("logServices(odoo db) = %v, %v, want [odoo db]", services, err)
+	}
+	if services, err := logServices(nil, true); err != nil || services != nil {
+		t.Errorf("logServices(--all) = %v, %v, want every service", services, err)
+	}
+	if _, err := logServices([]string{"db"}, true); err == nil {
+		t.Error("logServices(db, --all) succeeded, want an error")
+	}
+}
+
+func TestComposeLogsArgs(t *testing.T) {
+	got := composeLogsArgs([]string{"odoo", "db"}, true, true, 50, "10m")
+	want := []string{"logs", "-f", "-t", "--tail", "50", "--since", "10m", "odoo", "db"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("composeLogsArgs() = %v, want %v", got, want)
+	}
+	if got := composeLogsArgs(nil, false, false, 0, ""); !reflect.DeepEqual(got, []string{"logs"}) {
+		t.Errorf("composeLogsArgs(all, full history) = %v, want [logs]", got)
+	}
+}
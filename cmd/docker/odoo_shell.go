@@ -0,0 +1,91 @@
+package docker
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagOdooShellFile     string
+	flagOdooShellLogLevel string
+)
+
+var odooShellCmd = &cobra.Command{
+	Use:          "odoo-shell",
+	Short:        "Run a Python script in the Odoo shell",
+	SilenceUsage: true,
+	Long: `Runs a Python script inside 'odoo shell' for the environment's database.
+
+The script executes with the usual shell variables (env, self, odoo) available
+and its output is printed. Without --file, a script piped on stdin is used; with
+an interactive terminal this opens the regular Odoo shell.
+
+The shell does not commit automatically: call env.cr.commit() to persist changes.
+
+Examples:
+  odooctl docker odoo-shell --file fix_partners.py
+  echo "print(env['res.users'].search_count([]))" | odooctl docker odoo-shell
+  odooctl docker odoo-shell --file - < fix_partners.py`,
+	Args: cobra.NoArgs,
+	RunE: runOdooShell,
+}
+
+func init() {
+	odooShellCmd.Flags().StringVarP(&flagOdooShellFile, "file", "f", "", "Python script to execute ('-' reads stdin)")
+	odooShellCmd.Flags().StringVar(&flagOdooShellLogLevel, "log-level", "warn", "Odoo log level while the script runs")
+}
+
+func runOdooShell(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	if err := ensureDockerProjectAccess(state); err != nil {
+		return err
+	}
+
+	var script io.Reader
+	switch {
+	case flagOdooShellFile == "-":
+		script = os.Stdin
+	case flagOdooShellFile != "":
+		file, err := os.Open(flagOdooShellFile)
+		if err != nil {
+			return fmt.Errorf("failed to open script: %w", err)
+		}
+		defer file.Close()
+		script = file
+	case !stdinIsTerminal():
+		script = os.Stdin
+	default:
+		return docker.Compose(state, "exec", "odoo", "odoo", "shell", "-d", state.DBName())
+	}
+
+	return runOdooShellScript(state, script, flagOdooShellLogLevel)
+}
+
+// runOdooShellScript pipes a Python script into 'odoo shell' for the
+// environment's database, streaming its output.
+func runOdooShellScript(state *config.State, script io.Reader, logLevel string) error {
+	shellCmd := docker.ComposeCommand(state, "exec", "-T", "odoo", "odoo", "shell", "-d", state.DBName(), "--log-level="+logLevel)
+	shellCmd.Stdin = script
+	shellCmd.Stdout = os.Stdout
+	shellCmd.Stderr = os.Stderr
+	if err := shellCmd.Run(); err != nil {
+		return fmt.Errorf("odoo shell script failed: %w", err)
+	}
+	return nil
+}
+
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return true
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -1,6 +1,10 @@
 package docker
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/mart337i/odooctl/internal/docker"
 	"github.com/spf13/cobra"
 )
@@ -30,3 +34,32 @@ func runOdooBin(cmd *cobra.Command, args []string) error {
 
 	return docker.Compose(state, execArgs...)
 }
+
+// reservedOdooArgs are the odoo-bin options every odooctl-built command sets
+var reservedOdooArgs = []string{"-c", "--config", "-d", "--database", "--stop-after-init"}
+
+// validateOdooArgs rejects --odoo-arg values that repeat an option odooctl
+// already sets, which would otherwise silently win or clash. reserved lists
+// the options the calling command sets on top of reservedOdooArgs.
+func validateOdooArgs(args []string, reserved ...string) error {
+	for _, arg := range args {
+		name := odooArgName(arg)
+		if slices.Contains(reservedOdooArgs, name) || slices.Contains(reserved, name) {
+			return fmt.Errorf("--odoo-arg %s: odooctl already passes %s to odoo-bin", arg, name)
+		}
+	}
+	return nil
+}
+
+// odooArgName is the option an odoo-bin argument sets: --workers for
+// --workers=0 and -d for -dmydb
+func odooArgName(arg string) string {
+	if strings.HasPrefix(arg, "--") {
+		name, _, _ := strings.Cut(arg, "=")
+		return name
+	}
+	if strings.HasPrefix(arg, "-") && len(arg) > 2 {
+		return arg[:2]
+	}
+	return arg
+}
@@ -0,0 +1,16 @@
+package docker
+
+import "testing"
+
+func TestValidateOdooArgs(t *testing.T) {
+	allowed := []string{"--workers=0", "--log-handler=odoo.sql_db:DEBUG", "--limit-time-cpu", "600", "--dev=all"}
+	if err := validateOdooArgs(allowed, "-i", "--init"); err != nil {
+		t.Fatalf("validateOdooArgs(%v) error = %v", allowed, err)
+	}
+
+	for _, arg := range []string{"-d", "-dother", "--database=other", "-c", "--config=/tmp/odoo.conf", "--stop-after-init", "-isale", "--init=sale"} {
+		if err := validateOdooArgs([]string{arg}, "-i", "--init"); err == nil {
+			t.Errorf("validateOdooArgs(%s) error = nil, want an error", arg)
+		}
+	}
+}
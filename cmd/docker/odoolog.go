@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// composeLogPrefix matches what docker compose logs puts in front of a
+	// line: the service prefix ("odoo-1  | ") and, with -t, a timestamp
+	composeLogPrefix = regexp.MustCompile(`^(\S+)\s+\| (?:\d{4}-\d{2}-\d{2}T\S+ )?`)
+	// odooLogHeader matches the start of an Odoo log record:
+	// "2024-05-01 10:00:00,123 7 ERROR db odoo.addons.x: ..."
+	odooLogHeader = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} (\d{2}:\d{2}:\d{2}),\d{3} (\d+) (DEBUG|INFO|WARNING|ERROR|CRITICAL) `)
+)
+
+// odooLogLine is one line of Odoo log output. Lines that don't start a
+// record (tracebacks, multi-line messages) belong to the record before them
+// from the same service and have no Level.
+type odooLogLine struct {
+	Service   string // compose service prefix, "" with --no-log-prefix
+	Time      string // HH:MM:SS
+	PID       string
+	Level     string
+	Traceback bool // the line starts a Python traceback
+}
+
+func parseOdooLogLine(line string) odooLogLine {
+	var parsed odooLogLine
+	if prefix := composeLogPrefix.FindStringSubmatch(line); prefix != nil {
+		parsed.Service = prefix[1]
+		line = line[len(prefix[0]):]
+	}
+	if header := odooLogHeader.FindStringSubmatch(line); header != nil {
+		parsed.Time, parsed.PID, parsed.Level = header[1], header[2], header[3]
+	}
+	parsed.Traceback = strings.HasPrefix(line, "Traceback (most recent call last):")
+	return parsed
+}
+
+// IsRecord reports whether the line starts a log record
+func (l odooLogLine) IsRecord() bool {
+	return l.Level != ""
+}
+
+// IsProblem reports whether the line starts a WARNING, ERROR or CRITICAL
+// record
+func (l odooLogLine) IsProblem() bool {
+	return l.Level == "WARNING" || l.Level == "ERROR" || l.Level == "CRITICAL"
+}
+
+// odooLogFilter keeps WARNING, ERROR and CRITICAL records and tracebacks.
+// Continuation lines share the fate of the record before them from the same
+// service, so other services' output in between is not mistaken for them.
+type odooLogFilter struct {
+	keep map[string]bool
+}
+
+// Keep reports whether line should be shown.
+func (f *odooLogFilter) Keep(line string) bool {
+	if f.keep == nil {
+		f.keep = make(map[string]bool)
+	}
+	parsed := parseOdooLogLine(line)
+	switch {
+	case parsed.IsRecord():
+		f.keep[parsed.Service] = parsed.IsProblem()
+	case parsed.Traceback:
+		f.keep[parsed.Service] = true
+	}
+	return f.keep[parsed.Service]
+}
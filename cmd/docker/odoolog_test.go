@@ -0,0 +1,58 @@
+package docker
+
+import "testing"
+
+func TestParseOdooLogLine(t *testing.T) {
+	tests := []struct {
+		line                      string
+		service, time, pid, level string
+	}{
+		{"2024-05-01 10:00:00,123 7 ERROR db odoo.http: boom", "", "10:00:00", "7", "ERROR"},
+		{"odoo-1  | 2024-05-01 10:00:00,123 7 INFO db odoo.modules.loading: loading", "odoo-1", "10:00:00", "7", "INFO"},
+		{"odoo-1  | 2024-05-01T10:00:00.123456789Z 2024-05-01 10:00:00,123 7 WARNING db odoo.fields: x", "odoo-1", "10:00:00", "7", "WARNING"},
+		{"db-1  | 2024-05-01 10:00:00.123 UTC [1] LOG:  checkpoint starting", "db-1", "", "", ""},
+		{`  File "/usr/lib/python3/dist-packages/odoo/http.py", line 1`, "", "", "", ""},
+	}
+	for _, tt := range tests {
+		got := parseOdooLogLine(tt.line)
+		if got.Service != tt.service || got.Time != tt.time || got.PID != tt.pid || got.Level != tt.level {
+			t.Errorf("parseOdooLogLine(%q) = %+v", tt.line, got)
+		}
+	}
+	if !parseOdooLogLine("odoo-1  | Traceback (most recent call last):").Traceback {
+		t.Error("parseOdooLogLine() did not detect a traceback")
+	}
+}
+
+func TestOdooLogFilter(t *testing.T) {
+	lines := []struct {
+		line string
+		keep bool
+	}{
+		{"Attaching to odoo-1", false},
+		{"odoo-1  | 2024-05-01 10:00:00,123 7 WARNING db odoo.fields: Field x has no string", true},
+		{"db-1  | 2024-05-01 10:00:00.200 UTC [1] LOG:  checkpoint starting", false},
+		{"mailhog-1  | [APIv1] KEEPALIVE /api/v1/events", false},
+		{"odoo-1  | second line of the warning", true},
+		{"odoo-1  | 2024-05-01 10:00:01,000 7 INFO db odoo.modules.loading: loading 42 modules", false},
+		{"odoo-1  | Traceback (most recent call last):", true},
+		{`odoo-1  |   File "/mnt/extra-addons/x/models.py", line 3, in <module>`, true},
+		{"odoo-1  | NameError: name 'fields' is not defined", true},
+		{"odoo-1  | 2024-05-01 10:00:02,000 7 CRITICAL ? odoo.service.server: Failed to initialize database", true},
+	}
+	var filter odooLogFilter
+	for _, tc := range lines {
+		if got := filter.Keep(tc.line); got != tc.keep {
+			t.Errorf("Keep(%q) = %v, want %v", tc.line, got, tc.keep)
+		}
+	}
+
+	// restart-odoo and trace read logs without the service prefix
+	var unprefixed odooLogFilter
+	for _, tc := range lines[5:] {
+		line := tc.line[len("odoo-1  | "):]
+		if got := unprefixed.Keep(line); got != tc.keep {
+			t.Errorf("Keep(%q) = %v, want %v", line, got, tc.keep)
+		}
+	}
+}
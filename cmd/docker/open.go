@@ -7,22 +7,34 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
 	"github.com/mart337i/odooctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
-var flagOpenJSON bool
+var (
+	flagOpenJSON    bool
+	flagOpenMailhog bool
+)
 
 type openReport struct {
-	Target string `json:"target"`
-	URL    string `json:"url"`
-	Opened bool   `json:"opened"`
-	Error  string `json:"error,omitempty"`
+	Target  string `json:"target"`
+	URL     string `json:"url"`
+	Running bool   `json:"running"`
+	Opened  bool   `json:"opened"`
+	Error   string `json:"error,omitempty"`
 }
 
 var openCmd = &cobra.Command{
-	Use:          "open [odoo|mailhog|debug]",
-	Short:        "Open or print useful development URLs",
+	Use:   "open [odoo|mailhog|debug]",
+	Short: "Open or print useful development URLs",
+	Long: `Open the Odoo web UI, or another service, in the default browser.
+The URL is printed as well, so it can be copied when no browser opener is found.
+
+Examples:
+  odooctl docker open
+  odooctl docker open --mailhog
+  odooctl docker open debug`,
 	SilenceUsage: true,
 	Args:         cobra.MaximumNArgs(1),
 	RunE:         runOpen,
@@ -30,6 +42,7 @@ var openCmd = &cobra.Command{
 
 func init() {
 	openCmd.Flags().BoolVar(&flagOpenJSON, "json", false, "Print JSON output")
+	openCmd.Flags().BoolVar(&flagOpenMailhog, "mailhog", false, "Open the Mailhog UI instead of Odoo")
 }
 
 func runOpen(cmd *cobra.Command, args []string) error {
@@ -41,12 +54,22 @@ func runOpen(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		target = args[0]
 	}
+	if flagOpenMailhog {
+		if len(args) > 0 && args[0] != "mailhog" && args[0] != "mail" {
+			return fmt.Errorf("--mailhog cannot be combined with target %q", args[0])
+		}
+		target = "mailhog"
+	}
 	url, err := targetURL(state, target)
 	if err != nil {
 		return err
 	}
+	running := docker.IsRunning(state)
 	if flagOpenJSON {
-		return output.PrintJSON(openReport{Target: target, URL: url})
+		return output.PrintJSON(openReport{Target: target, URL: url, Running: running})
+	}
+	if !running {
+		fmt.Printf("%s Containers are not running. Start them with 'odooctl docker run'.\n", color.YellowString("⚠️"))
 	}
 	openErr := openURL(url)
 	if openErr != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/mart337i/odooctl/internal/config"
@@ -24,6 +25,8 @@ type pathReport struct {
 	FilesPresent []string     `json:"files_present"`
 	FilesMissing []string     `json:"files_missing"`
 	AddonsPaths  []string     `json:"addons_paths"`
+	// LastStartedAt is when 'docker run' last brought the containers up
+	LastStartedAt *time.Time `json:"last_started_at,omitempty"`
 }
 
 var pathCmd = &cobra.Command{
@@ -64,6 +67,11 @@ func runPath(cmd *cobra.Command, args []string) error {
 	if state.Enterprise {
 		fmt.Printf("%s Edition:  Enterprise\n", cyan("🏢"))
 	}
+	if state.LastStartedAt != nil {
+		fmt.Printf("%s Started:  %s\n", cyan("🕒"), state.LastStartedAt.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("%s Started:  never\n", cyan("🕒"))
+	}
 
 	if report.FilesReady {
 		entries, _ := os.ReadDir(dir)
@@ -92,6 +100,8 @@ func dockerPathReport(state *config.State, dir string) pathReport {
 		Ports:       state.Ports,
 		Enterprise:  state.Enterprise,
 		AddonsPaths: append([]string{}, state.AddonsPaths...),
+
+		LastStartedAt: state.LastStartedAt,
 	}
 	for _, file := range []string{"docker-compose.yml", "Dockerfile", "odoo.conf"} {
 		if _, err := os.Stat(filepath.Join(dir, file)); os.IsNotExist(err) {
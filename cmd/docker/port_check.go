@@ -0,0 +1,138 @@
+package docker
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var flagPortCheckJSON bool
+
+type portCheckEntry struct {
+	Service     string             `json:"service"`
+	Port        int                `json:"port"`
+	Available   bool               `json:"available"`
+	Holder      *docker.PortHolder `json:"holder,omitempty"`
+	Environment string             `json:"environment,omitempty"`
+}
+
+type portCheckReport struct {
+	Ports      []portCheckEntry `json:"ports"`
+	Conflicts  int              `json:"conflicts"`
+	Suggestion string           `json:"suggestion,omitempty"`
+}
+
+var portCheckCmd = &cobra.Command{
+	Use:   "port-check",
+	Short: "Check whether the environment's ports are free",
+	Long: `Reports each host port used by the current environment and, for occupied
+ports, which process or container is holding it.
+
+Ports held by this environment's own running containers are not conflicts.
+Ports held by another odooctl environment name that environment.`,
+	Args: cobra.NoArgs,
+	RunE: runPortCheck,
+}
+
+func init() {
+	portCheckCmd.Flags().BoolVar(&flagPortCheckJSON, "json", false, "Print JSON output")
+}
+
+func runPortCheck(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+
+	running := docker.IsRunning(state)
+	services := []struct {
+		name string
+		port int
+	}{
+		{"odoo", state.Ports.Odoo},
+		{"mailhog", state.Ports.Mailhog},
+		{"smtp", state.Ports.SMTP},
+		{"debug", state.Ports.Debug},
+	}
+
+	var report portCheckReport
+	for _, svc := range services {
+		entry := portCheckEntry{Service: svc.name, Port: svc.port, Available: config.IsPortAvailable(svc.port)}
+		if !entry.Available {
+			if holder, ok := docker.FindPortHolder(svc.port); ok {
+				entry.Holder = &holder
+			}
+			entry.Environment = portOwnerEnvironment(svc.port, state, running)
+			if entry.Environment != "this environment" {
+				report.Conflicts++
+			}
+		}
+		report.Ports = append(report.Ports, entry)
+	}
+	if report.Conflicts > 0 {
+		report.Suggestion = "odooctl docker reconfigure --regenerate-ports"
+	}
+
+	if flagPortCheckJSON {
+		return output.PrintJSON(report)
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	fmt.Printf("\nPorts for %s (%s)\n\n", state.ProjectName, state.Branch)
+	for _, entry := range report.Ports {
+		label := fmt.Sprintf("  %-8s %-6d", entry.Service, entry.Port)
+		switch {
+		case entry.Available:
+			fmt.Printf("%s %s\n", label, green("free"))
+		case entry.Environment == "this environment":
+			fmt.Printf("%s %s\n", label, dim("in use by this environment"))
+		default:
+			holder := "unknown process"
+			if entry.Holder != nil {
+				holder = entry.Holder.String()
+			}
+			if entry.Environment != "" {
+				holder += ", odooctl environment " + entry.Environment
+			}
+			fmt.Printf("%s %s %s\n", label, red("in use"), holder)
+		}
+	}
+
+	if report.Conflicts > 0 {
+		fmt.Printf("\n%s %d port conflict(s). Pick free ports with: %s\n", yellow("⚠️"), report.Conflicts, report.Suggestion)
+		return nil
+	}
+	fmt.Printf("\n%s No port conflicts\n", green("✓"))
+	return nil
+}
+
+// portOwnerEnvironment returns "project/branch" of the odooctl environment
+// configured to use port, or "this environment" for the current one.
+func portOwnerEnvironment(port int, current *config.State, currentRunning bool) string {
+	environments, err := config.AllEnvironments()
+	if err != nil {
+		return ""
+	}
+	for _, env := range environments {
+		state := env.State
+		if state.Ports.Odoo != port && state.Ports.Mailhog != port && state.Ports.SMTP != port && state.Ports.Debug != port {
+			continue
+		}
+		if state.ProjectName == current.ProjectName && state.Branch == current.Branch {
+			if currentRunning {
+				return "this environment"
+			}
+			continue
+		}
+		return state.ProjectName + "/" + state.Branch
+	}
+	return ""
+}
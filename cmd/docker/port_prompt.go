@@ -0,0 +1,138 @@
+package docker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/pkg/prompt"
+)
+
+// maxPortAttempts bounds how often custom ports are asked for again
+const maxPortAttempts = 3
+
+// interactivePorts reports whether a port conflict in 'docker run' should be
+// resolved by asking the user: with --interactive-ports, or on a terminal when
+// the interactive-ports config key is set.
+func interactivePorts() bool {
+	if flagRunNoPrompt || prompt.NonInteractive() {
+		return false
+	}
+	if flagRunInteractivePorts {
+		return true
+	}
+	cfg, err := config.LoadGlobalConfig()
+	return err == nil && cfg.InteractivePorts && stdinIsTerminal()
+}
+
+// choosePorts shows the conflicting ports and lets the user take the next free
+// set, enter ports by hand, or abort.
+func choosePorts(state *config.State, conflicting []int) (config.Ports, error) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	busy := make(map[int]bool)
+	for _, port := range conflicting {
+		busy[port] = true
+	}
+	fmt.Println()
+	for _, svc := range portServices(state.Ports) {
+		if !busy[svc.port] {
+			fmt.Printf("  %-8s %-6d free\n", svc.name, svc.port)
+			continue
+		}
+		holder := "unknown process"
+		if h, ok := docker.FindPortHolder(svc.port); ok {
+			holder = h.String()
+		}
+		fmt.Printf("  %-8s %-6d %s %s\n", svc.name, svc.port, yellow("in use"), holder)
+	}
+
+	proposed := config.FindAvailablePorts(state.OdooVersion, state.ProjectName, state.Branch)
+	fmt.Printf("\nNext free ports: %s\n", cyan(formatPorts(proposed)))
+	accept, err := prompt.Confirm("Use these ports?", true)
+	if err != nil {
+		return config.Ports{}, err
+	}
+	if accept {
+		return proposed, nil
+	}
+
+	custom, err := prompt.Confirm("Enter ports yourself?", true)
+	if err != nil {
+		return config.Ports{}, err
+	}
+	if !custom {
+		return config.Ports{}, fmt.Errorf("port conflict on %v not resolved; free the ports or run 'odooctl docker port-check'", conflicting)
+	}
+
+	for attempt := 1; ; attempt++ {
+		ports, err := inputPorts(proposed)
+		if err != nil {
+			return config.Ports{}, err
+		}
+		err = validateChosenPorts(ports, config.IsPortAvailable)
+		if err == nil {
+			return ports, nil
+		}
+		if attempt == maxPortAttempts {
+			return config.Ports{}, err
+		}
+		fmt.Printf("%s %v\n", yellow("⚠️"), err)
+	}
+}
+
+type portService struct {
+	name string
+	port int
+}
+
+func portServices(p config.Ports) []portService {
+	return []portService{{"odoo", p.Odoo}, {"mailhog", p.Mailhog}, {"smtp", p.SMTP}, {"debug", p.Debug}}
+}
+
+func formatPorts(p config.Ports) string {
+	var parts []string
+	for _, svc := range portServices(p) {
+		parts = append(parts, fmt.Sprintf("%s %d", svc.name, svc.port))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// inputPorts asks for each port, offering defaults as the answers
+func inputPorts(defaults config.Ports) (config.Ports, error) {
+	var values [4]int
+	for i, svc := range portServices(defaults) {
+		answer, err := prompt.InputString(fmt.Sprintf("%s port:", svc.name), strconv.Itoa(svc.port))
+		if err != nil {
+			return config.Ports{}, err
+		}
+		port, err := strconv.Atoi(strings.TrimSpace(answer))
+		if err != nil {
+			return config.Ports{}, fmt.Errorf("invalid %s port %q", svc.name, answer)
+		}
+		values[i] = port
+	}
+	return config.Ports{Odoo: values[0], Mailhog: values[1], SMTP: values[2], Debug: values[3]}, nil
+}
+
+// validateChosenPorts checks that ports are in range, distinct and free
+func validateChosenPorts(p config.Ports, isFree func(int) bool) error {
+	seen := make(map[int]string)
+	for _, svc := range portServices(p) {
+		if svc.port < 1 || svc.port > 65535 {
+			return fmt.Errorf("%s port %d is out of range (1-65535)", svc.name, svc.port)
+		}
+		if other, ok := seen[svc.port]; ok {
+			return fmt.Errorf("%s and %s can't share port %d", other, svc.name, svc.port)
+		}
+		seen[svc.port] = svc.name
+		if !isFree(svc.port) {
+			return fmt.Errorf("%s port %d is already in use", svc.name, svc.port)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/pkg/prompt"
+)
+
+func TestValidateChosenPorts(t *testing.T) {
+	allFree := func(int) bool { return true }
+	cases := []struct {
+		name    string
+		ports   config.Ports
+		isFree  func(int) bool
+		wantErr string
+	}{
+		{"valid", config.Ports{Odoo: 9700, Mailhog: 9725, SMTP: 1725, Debug: 5778}, allFree, ""},
+		{"out of range", config.Ports{Odoo: 70000, Mailhog: 9725, SMTP: 1725, Debug: 5778}, allFree, "out of range"},
+		{"shared", config.Ports{Odoo: 9700, Mailhog: 9700, SMTP: 1725, Debug: 5778}, allFree, "can't share port 9700"},
+		{"busy", config.Ports{Odoo: 9700, Mailhog: 9725, SMTP: 1725, Debug: 5778}, func(port int) bool { return port != 5778 }, "debug port 5778 is already in use"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateChosenPorts(tc.ports, tc.isFree)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateChosenPorts() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("validateChosenPorts() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestInteractivePortsNeverPromptsWhenNonInteractive(t *testing.T) {
+	prompt.SetNonInteractive(true)
+	flagRunInteractivePorts = true
+	t.Cleanup(func() {
+		prompt.SetNonInteractive(false)
+		flagRunInteractivePorts = false
+	})
+
+	if interactivePorts() {
+		t.Fatal("interactivePorts() = true in non-interactive mode")
+	}
+}
+
+func TestFormatPorts(t *testing.T) {
+	got := formatPorts(config.Ports{Odoo: 9710, Mailhog: 9735, SMTP: 1735, Debug: 5788})
+	if want := "odoo 9710, mailhog 9735, smtp 1735, debug 5788"; got != want {
+		t.Fatalf("formatPorts() = %q, want %q", got, want)
+	}
+}
@@ -24,6 +24,15 @@ var (
 	flagReconfigNoCache      bool
 	flagReconfigBrowser      bool
 	flagReconfigNoBrowser    bool
+	flagReconfigConf         []string
+	flagReconfigRegenPorts   bool
+	flagReconfigCacheFrom    string
+	flagReconfigLogMaxSize   string
+	flagReconfigLogMaxFile   int
+	flagReconfigPostgres     string
+	flagReconfigEnv          []string
+	flagReconfigReportURL    string
+	flagReconfigWebBaseURL   string
 )
 
 var reconfigureCmd = &cobra.Command{
@@ -45,9 +54,30 @@ Examples:
   # Auto-discover dependencies
   odooctl docker reconfigure --auto-discover-deps
 
+  # Set extra odoo.conf options (use key= to remove one)
+  odooctl docker reconfigure --conf proxy_mode=True --conf limit_memory_hard=0
+
+  # Set environment variables for the odoo container (use KEY= to remove one)
+  odooctl docker reconfigure --env PGAPPNAME=odoo-dev --env OLD_VAR=
+
   # Enable Playwright Chromium browser tooling
   odooctl docker reconfigure --browser --rebuild
 
+  # Move to free host ports after a port conflict
+  odooctl docker reconfigure --regenerate-ports
+
+  # Seed rebuilds from a previously pushed image
+  odooctl docker reconfigure --cache-from ghcr.io/acme/odoo-dev:17.0
+
+  # Change container log rotation limits
+  odooctl docker reconfigure --log-max-size 50m --log-max-file 10
+
+  # Move the database to another Postgres version
+  odooctl docker reconfigure --postgres-version 16
+
+  # Change the URLs set when the database is next initialized (run -i)
+  odooctl docker reconfigure --report-url http://odoo-app:8069 --web-base-url https://erp.example.test
+
   # Combine options
   odooctl docker reconfigure --add-pip requests --add-addons-path ~/addons --rebuild`,
 	RunE: runReconfigure,
@@ -62,6 +92,15 @@ func init() {
 	reconfigureCmd.Flags().BoolVar(&flagReconfigNoCache, "no-cache", false, "Rebuild without Docker layer cache")
 	reconfigureCmd.Flags().BoolVar(&flagReconfigBrowser, "browser", false, "Enable Playwright Chromium browser tooling (Odoo 15.0+)")
 	reconfigureCmd.Flags().BoolVar(&flagReconfigNoBrowser, "no-browser", false, "Disable browser tooling in generated config")
+	reconfigureCmd.Flags().StringArrayVar(&flagReconfigConf, "conf", nil, "Set an extra odoo.conf option as key=value, or key= to remove it (can specify multiple times)")
+	reconfigureCmd.Flags().StringArrayVar(&flagReconfigEnv, "env", nil, "Set an environment variable for the odoo container as KEY=VALUE, or KEY= to remove it (can specify multiple times)")
+	reconfigureCmd.Flags().BoolVar(&flagReconfigRegenPorts, "regenerate-ports", false, "Pick new free host ports for this environment")
+	reconfigureCmd.Flags().StringVar(&flagReconfigCacheFrom, "cache-from", "", "Image to seed the build cache from ('none' to disable)")
+	reconfigureCmd.Flags().StringVar(&flagReconfigLogMaxSize, "log-max-size", "", "Size of each rotated container log file (e.g. 10m)")
+	reconfigureCmd.Flags().IntVar(&flagReconfigLogMaxFile, "log-max-file", 0, "Number of rotated container log files to keep")
+	reconfigureCmd.Flags().StringVar(&flagReconfigPostgres, "postgres-version", "", "Postgres image tag for the database, e.g. 16 or 16-alpine")
+	reconfigureCmd.Flags().StringVar(&flagReconfigReportURL, "report-url", "", "report.url set when the database is initialized ('' for "+config.DefaultReportURL+")")
+	reconfigureCmd.Flags().StringVar(&flagReconfigWebBaseURL, "web-base-url", "", "web.base.url set and frozen when the database is initialized ('' to leave it to Odoo)")
 }
 
 func runReconfigure(cmd *cobra.Command, args []string) error {
@@ -126,6 +165,66 @@ func runReconfigure(cmd *cobra.Command, args []string) error {
 		addedPipPackages = append(addedPipPackages, added...)
 	}
 
+	// Merge extra odoo.conf options
+	confUpdates, err := config.ParseConfOptions(flagReconfigConf)
+	if err != nil {
+		return err
+	}
+	newConfOptions := make(map[string]string, len(state.ExtraConfOptions))
+	for key, value := range state.ExtraConfOptions {
+		newConfOptions[key] = value
+	}
+	confChanged := false
+	for key, value := range confUpdates {
+		current, exists := newConfOptions[key]
+		if value == "" {
+			if exists {
+				delete(newConfOptions, key)
+				confChanged = true
+				fmt.Printf("%s Removing odoo.conf option: %s\n", cyan("⚙"), key)
+			}
+			continue
+		}
+		if !exists || current != value {
+			newConfOptions[key] = value
+			confChanged = true
+			fmt.Printf("%s Setting odoo.conf option: %s = %s\n", cyan("⚙"), key, value)
+		}
+	}
+	if len(newConfOptions) == 0 {
+		newConfOptions = nil
+	}
+
+	// Merge extra environment variables
+	envUpdates, err := config.ParseEnvVars(flagReconfigEnv)
+	if err != nil {
+		return err
+	}
+	newEnv := make(map[string]string, len(state.ExtraEnv))
+	for key, value := range state.ExtraEnv {
+		newEnv[key] = value
+	}
+	envChanged := false
+	for key, value := range envUpdates {
+		current, exists := newEnv[key]
+		if value == "" {
+			if exists {
+				delete(newEnv, key)
+				envChanged = true
+				fmt.Printf("%s Removing environment variable: %s\n", cyan("⚙"), key)
+			}
+			continue
+		}
+		if !exists || current != value {
+			newEnv[key] = value
+			envChanged = true
+			fmt.Printf("%s Setting environment variable: %s=%s\n", cyan("⚙"), key, config.MaskEnvValue(key, value))
+		}
+	}
+	if len(newEnv) == 0 {
+		newEnv = nil
+	}
+
 	// Check if anything changed
 	newBrowserEnabled := state.BrowserEnabled
 	newBrowserProvider := state.BrowserProvider
@@ -138,7 +237,95 @@ func runReconfigure(cmd *cobra.Command, args []string) error {
 		newBrowserProvider = ""
 	}
 
-	if len(newPipPackages) == len(state.PipPackages) && len(newAddonsPaths) == len(state.AddonsPaths) && newBrowserEnabled == state.BrowserEnabled && newBrowserProvider == state.BrowserProvider {
+	cacheChanged := false
+	if cmd.Flags().Changed("cache-from") {
+		previous := state.CacheFrom
+		if err := applyCacheFromFlag(state, flagReconfigCacheFrom); err != nil {
+			return err
+		}
+		if state.CacheFrom != previous {
+			cacheChanged = true
+			fmt.Printf("%s Setting cache-from: %s\n", cyan("⚙"), state.CacheFrom)
+		}
+	}
+
+	loggingChanged := false
+	if cmd.Flags().Changed("log-max-size") {
+		if err := config.ValidateLogMaxSize(flagReconfigLogMaxSize); err != nil {
+			return err
+		}
+		if flagReconfigLogMaxSize != state.LogMaxSize {
+			state.LogMaxSize = flagReconfigLogMaxSize
+			loggingChanged = true
+		}
+	}
+	if cmd.Flags().Changed("log-max-file") {
+		if flagReconfigLogMaxFile < 1 {
+			return fmt.Errorf("--log-max-file must be at least 1")
+		}
+		if flagReconfigLogMaxFile != state.LogMaxFile {
+			state.LogMaxFile = flagReconfigLogMaxFile
+			loggingChanged = true
+		}
+	}
+	if loggingChanged {
+		fmt.Printf("%s Container logs: %d files of %s\n", cyan("⚙"), state.LoggingMaxFile(), state.LoggingMaxSize())
+	}
+
+	postgresChanged := false
+	if cmd.Flags().Changed("postgres-version") {
+		if err := config.ValidatePostgresVersion(flagReconfigPostgres); err != nil {
+			return err
+		}
+		if flagReconfigPostgres != state.PostgresImageVersion() {
+			if warning := config.PostgresCompatibilityWarning(state.OdooVersion, flagReconfigPostgres); warning != "" {
+				fmt.Printf("%s %s\n", yellow("⚠️"), warning)
+			}
+			if config.PostgresMajor(flagReconfigPostgres) != config.PostgresMajor(state.PostgresImageVersion()) {
+				fmt.Printf("%s Postgres %s can't open a database created by Postgres %s.\n", yellow("⚠️"), flagReconfigPostgres, state.PostgresImageVersion())
+				fmt.Println("  Back it up with 'odooctl docker dump' first; after the switch, run")
+				fmt.Println("  'odooctl docker reset -v' and 'odooctl docker restore <dump.zip>'.")
+				confirmed, err := prompt.Confirm("Switch the Postgres version anyway?", true)
+				if err != nil || !confirmed {
+					fmt.Println("Reconfigure cancelled.")
+					return nil
+				}
+			}
+			fmt.Printf("%s Setting Postgres version: %s\n", cyan("⚙"), flagReconfigPostgres)
+			state.PostgresVersion = flagReconfigPostgres
+			postgresChanged = true
+		}
+	}
+
+	urlsChanged := false
+	for _, setting := range []struct {
+		flag  string
+		value string
+		field *string
+	}{
+		{"report-url", flagReconfigReportURL, &state.ReportURL},
+		{"web-base-url", flagReconfigWebBaseURL, &state.WebBaseURL},
+	} {
+		if !cmd.Flags().Changed(setting.flag) || setting.value == *setting.field {
+			continue
+		}
+		if setting.value != "" {
+			if err := config.ValidateParamURL(setting.value); err != nil {
+				return err
+			}
+		}
+		*setting.field = setting.value
+		urlsChanged = true
+	}
+	if urlsChanged {
+		fmt.Printf("%s Database initialization sets report.url to %s\n", cyan("⚙"), state.ReportURLParam())
+		if state.WebBaseURL != "" {
+			fmt.Printf("%s Database initialization sets web.base.url to %s\n", cyan("⚙"), state.WebBaseURL)
+		}
+		fmt.Printf("%s The existing database keeps its values until it is initialized again\n", cyan("ℹ"))
+	}
+
+	if len(newPipPackages) == len(state.PipPackages) && len(newAddonsPaths) == len(state.AddonsPaths) && newBrowserEnabled == state.BrowserEnabled && newBrowserProvider == state.BrowserProvider && !confChanged && !envChanged && !flagReconfigRegenPorts && !cacheChanged && !loggingChanged && !postgresChanged && !urlsChanged {
 		fmt.Printf("%s No changes to apply\n", yellow("⚠️"))
 		return nil
 	}
@@ -151,11 +338,19 @@ func runReconfigure(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Ports are picked after stopping so this environment's own ports count as free
+	if flagReconfigRegenPorts {
+		state.Ports = config.FindAvailablePorts(state.OdooVersion, state.ProjectName, state.Branch)
+		fmt.Printf("%s Ports: odoo %d, mailhog %d, smtp %d, debug %d\n", cyan("⚙"), state.Ports.Odoo, state.Ports.Mailhog, state.Ports.SMTP, state.Ports.Debug)
+	}
+
 	// Update state
 	state.PipPackages = newPipPackages
 	state.AddonsPaths = newAddonsPaths
 	state.BrowserEnabled = newBrowserEnabled
 	state.BrowserProvider = newBrowserProvider
+	state.ExtraConfOptions = newConfOptions
+	state.ExtraEnv = newEnv
 
 	// Regenerate files
 	if err := templates.Render(state); err != nil {
@@ -182,6 +377,11 @@ func runReconfigure(cmd *cobra.Command, args []string) error {
 	// Rebuild if requested
 	if flagReconfigRebuild {
 		fmt.Println("\nRebuilding container...")
+		if !flagReconfigNoCache {
+			if err := prepareBuildCache(state); err != nil {
+				return fmt.Errorf("failed to prepare build cache: %w", err)
+			}
+		}
 		buildArgs := []string{"build"}
 		if flagReconfigNoCache {
 			buildArgs = append(buildArgs, "--no-cache")
@@ -199,7 +399,7 @@ func runReconfigure(cmd *cobra.Command, args []string) error {
 			fmt.Printf("\n%s Odoo: http://localhost:%d\n", cyan("🌐"), state.Ports.Odoo)
 
 			if len(newAddonsPaths) > 0 {
-				fmt.Printf("\n%s Next steps: Apps → Update Apps List → Search module\n", yellow("📋"))
+				fmt.Printf("\n%s Next steps: odooctl docker update-list, then Apps → Search module\n", yellow("📋"))
 			}
 		}
 	} else {
@@ -0,0 +1,138 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/mart337i/odooctl/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var flagRenameJSON bool
+
+type renameReport struct {
+	Project        string   `json:"project"`
+	OldName        string   `json:"old_name"`
+	NewName        string   `json:"new_name"`
+	EnvDir         string   `json:"env_dir"`
+	ComposeProject string   `json:"compose_project"`
+	CopiedVolumes  []string `json:"copied_volumes,omitempty"`
+	OldVolumes     []string `json:"old_volumes,omitempty"`
+	Restarted      bool     `json:"restarted"`
+}
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <new-name>",
+	Short: "Rename the current environment",
+	Long: `Renames the current environment (the branch part of
+~/.odooctl/<project>/<branch>), e.g. after renaming the git branch it was
+created for. The environment directory moves to the new name, the state and
+project links follow it, and the Docker files are regenerated.
+
+The Docker Compose project, which containers and volumes are named after,
+follows the new name: containers are removed and the database, filestore and
+other volumes are copied to the new project. The old volumes are left in place
+until you remove them. Environments created before compose project names were
+stored share "{version}-{project}" with the project's other branches and keep
+it. Running containers are started again from the new directory.
+
+To rename the project in all of its environments, use
+'odooctl config rename-project'.
+
+Examples:
+  git branch -m 17.0-feature 17.0-invoicing
+  odooctl docker rename 17.0-invoicing`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRename,
+}
+
+func init() {
+	renameCmd.Flags().BoolVar(&flagRenameJSON, "json", false, "Print JSON output")
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	newName := args[0]
+	if config.EnvironmentExists(state.ProjectName, newName) {
+		return fmt.Errorf("environment '%s/%s' already exists. Choose a different name or remove it with 'odooctl docker reset'", state.ProjectName, newName)
+	}
+	if err := config.ValidateEnvironmentRename(state, newName); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	report := renameReport{Project: state.ProjectName, OldName: state.Branch, NewName: newName}
+	// Bind mounts point into the environment directory, so running
+	// containers are recreated from the new one
+	oldProject := state.ComposeProjectName()
+	running := docker.IsRunning(state)
+	if running {
+		if !flagRenameJSON {
+			fmt.Printf("%s Stopping containers...\n", yellow("→"))
+		}
+		if err := docker.Compose(state, "down"); err != nil {
+			return fmt.Errorf("failed to stop containers: %w", err)
+		}
+	} else if state.ComposeProject != "" {
+		// Stopped containers keep the old project's names
+		_, _ = docker.ComposeOutput(state, "down")
+	}
+
+	if err := config.RenameEnvironment(state, newName); err != nil {
+		return err
+	}
+	report.ComposeProject = state.ComposeProjectName()
+	if report.ComposeProject != oldProject {
+		volumes, err := docker.ProjectVolumes(oldProject)
+		if err != nil && !flagRenameJSON {
+			fmt.Printf("%s %v\n", yellow("⚠️"), err)
+		}
+		report.OldVolumes = volumes
+		for _, volume := range volumes {
+			if !flagRenameJSON {
+				fmt.Printf("  Copying volume %s...\n", volume)
+			}
+			copied, err := docker.CopyProjectVolume(volume, oldProject, report.ComposeProject)
+			if err != nil {
+				return fmt.Errorf("environment renamed, but copying volumes failed: %w", err)
+			}
+			report.CopiedVolumes = append(report.CopiedVolumes, copied)
+		}
+	}
+	if err := templates.Render(state); err != nil {
+		return fmt.Errorf("failed to render templates: %w", err)
+	}
+	report.EnvDir, _ = config.EnvironmentDir(state.ProjectName, state.Branch)
+
+	if running {
+		if !flagRenameJSON {
+			fmt.Printf("%s Starting containers...\n", yellow("→"))
+		}
+		if err := docker.Compose(state, "up", "-d"); err != nil {
+			return fmt.Errorf("environment renamed, but starting containers failed: %w", err)
+		}
+		report.Restarted = true
+	}
+
+	if flagRenameJSON {
+		return output.PrintJSON(report)
+	}
+	fmt.Printf("%s Renamed %s/%s to %s\n", green("✓"), state.ProjectName, report.OldName, cyan(newName))
+	fmt.Printf("  Files: %s\n", cyan(report.EnvDir))
+	if len(report.OldVolumes) > 0 {
+		fmt.Println("\nVolumes were copied. Once the renamed environment works, remove the old ones with:")
+		fmt.Printf("  docker volume rm %s\n", strings.Join(report.OldVolumes, " "))
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package docker
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/mart337i/odooctl/internal/config"
@@ -17,14 +20,29 @@ var (
 	flagResetVolumes bool
 	flagResetFiles   bool
 	flagResetJSON    bool
+	flagResetDryRun  bool
 )
 
 type resetReport struct {
-	ContainersStopped bool   `json:"containers_stopped"`
-	VolumesRemoved    bool   `json:"volumes_removed"`
-	FilesRemoved      bool   `json:"files_removed"`
-	DockerOutput      string `json:"docker_output,omitempty"`
-	Warning           string `json:"warning,omitempty"`
+	ContainersStopped bool          `json:"containers_stopped"`
+	VolumesRemoved    bool          `json:"volumes_removed"`
+	FilesRemoved      bool          `json:"files_removed"`
+	DockerOutput      string        `json:"docker_output,omitempty"`
+	Warning           string        `json:"warning,omitempty"`
+	DryRun            bool          `json:"dry_run,omitempty"`
+	Volumes           []resetVolume `json:"volumes,omitempty"`
+	LatestDump        *dumpFile     `json:"latest_dump,omitempty"`
+}
+
+type resetVolume struct {
+	Name string `json:"name"`
+	Size string `json:"size,omitempty"`
+}
+
+type dumpFile struct {
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+	Contents  []string  `json:"contents"` // what the archive backs up: database, filestore or both
 }
 
 var resetCmd = &cobra.Command{
@@ -37,9 +55,14 @@ By default, only stops containers. Use flags to also remove data:
   -v  Remove Docker volumes (database, filestore)
   -c  Remove config files (~/.odooctl/{project}/)
 
+With -v, the confirmation lists the volumes with their sizes and the most
+recent 'odooctl docker dump' archive found in the project root or the
+current directory. --dry-run shows the same without removing anything.
+
 Examples:
   odooctl docker reset           # Stop containers only
   odooctl docker reset -v        # Stop containers and remove volumes
+  odooctl docker reset -v --dry-run  # Show what -v would delete
   odooctl docker reset -c        # Stop containers and remove config files
   odooctl docker reset -v -c     # Full cleanup (containers, volumes, files)
   odooctl docker reset -v -c -f  # Full cleanup without confirmation`,
@@ -51,6 +74,7 @@ func init() {
 	resetCmd.Flags().BoolVarP(&flagResetVolumes, "volumes", "v", false, "Remove Docker volumes (database, filestore)")
 	resetCmd.Flags().BoolVarP(&flagResetFiles, "files", "c", false, "Remove config files")
 	resetCmd.Flags().BoolVar(&flagResetJSON, "json", false, "Print JSON output")
+	resetCmd.Flags().BoolVar(&flagResetDryRun, "dry-run", false, "Show what would be removed without removing anything")
 }
 
 func runReset(cmd *cobra.Command, args []string) error {
@@ -65,8 +89,18 @@ func runReset(cmd *cobra.Command, args []string) error {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	green := color.New(color.FgGreen).SprintFunc()
 
+	if flagResetDryRun {
+		return printResetDryRun(state)
+	}
+
 	// Confirm if removing data
 	if (flagResetVolumes || flagResetFiles) && !flagResetYes {
+		if flagResetVolumes {
+			printResetVolumes(state)
+			if state.FilestoreBindPath != "" {
+				fmt.Printf("%s The filestore in %s is a host directory and is kept\n", color.CyanString("ℹ"), state.FilestoreBindPath)
+			}
+		}
 		msg := "This will delete containers"
 		if flagResetVolumes {
 			msg += ", volumes (database)"
@@ -147,6 +181,14 @@ func runReset(cmd *cobra.Command, args []string) error {
 }
 
 func runResetJSON(state *config.State) error {
+	if flagResetDryRun {
+		report := resetReport{DryRun: true}
+		if flagResetVolumes {
+			report.Volumes = resetVolumes(state)
+			report.LatestDump = latestDump(dumpSearchDirs(state)...)
+		}
+		return output.PrintJSON(report)
+	}
 	if (flagResetVolumes || flagResetFiles) && !flagResetYes {
 		return fmt.Errorf("--json with destructive reset flags requires --force")
 	}
@@ -189,6 +231,114 @@ func runResetJSON(state *config.State) error {
 	return output.PrintJSON(report)
 }
 
+func printResetDryRun(state *config.State) error {
+	fmt.Printf("Dry run: 'odooctl docker reset' would\n")
+	fmt.Printf("  - stop and remove the containers of %q\n", state.ProjectName)
+	if flagResetVolumes {
+		fmt.Println("  - remove the Docker volumes:")
+		printResetVolumes(state)
+	}
+	if flagResetFiles {
+		dir, err := config.EnvironmentDir(state.ProjectName, state.Branch)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  - remove the config files in %s\n", dir)
+	}
+	return nil
+}
+
+// printResetVolumes lists the volumes -v deletes with their sizes and points
+// at the latest dump. Lookups are best-effort: reset never fails on them.
+func printResetVolumes(state *config.State) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	volumes := resetVolumes(state)
+	if len(volumes) == 0 {
+		fmt.Println("    (no volumes found)")
+	}
+	var total int64
+	known := true
+	for _, volume := range volumes {
+		size := volume.Size
+		if bytes, ok := docker.ParseSize(size); ok {
+			total += bytes
+		} else {
+			size, known = "size unknown", false
+		}
+		fmt.Printf("    %-45s %s\n", volume.Name, size)
+	}
+	if len(volumes) > 1 && known {
+		fmt.Printf("    %-45s %s\n", "total", formatBytes(total))
+	}
+
+	if dump := latestDump(dumpSearchDirs(state)...); dump != nil {
+		fmt.Printf("%s Latest dump: %s (%s, %s)\n", cyan("ℹ"), dump.Path, dump.CreatedAt.Format("2006-01-02 15:04"), strings.Join(dump.Contents, " and "))
+		if len(dump.Contents) == 1 {
+			fmt.Printf("%s It backs up only the %s\n", yellow("⚠️"), dump.Contents[0])
+		}
+	} else {
+		fmt.Printf("%s No dump found. Back up first with 'odooctl docker dump'\n", yellow("⚠️"))
+	}
+}
+
+// resetVolumes lists the environment's volumes with their sizes when Docker
+// reports them
+func resetVolumes(state *config.State) []resetVolume {
+	names, err := docker.ProjectVolumes(state.ComposeProjectName())
+	if err != nil {
+		return nil
+	}
+	sizes, _ := docker.VolumeSizes()
+	volumes := make([]resetVolume, 0, len(names))
+	for _, name := range names {
+		volumes = append(volumes, resetVolume{Name: name, Size: sizes[name]})
+	}
+	return volumes
+}
+
+// dumpSearchDirs are the places 'odooctl docker dump' writes to by default
+func dumpSearchDirs(state *config.State) []string {
+	dirs := []string{state.ProjectRoot}
+	if cwd, err := os.Getwd(); err == nil && cwd != state.ProjectRoot {
+		dirs = append(dirs, cwd)
+	}
+	return dirs
+}
+
+// dumpPatterns match every default archive name dumpFileName produces, with
+// what each kind backs up
+var dumpPatterns = []struct {
+	glob     string
+	contents []string
+}{
+	{"odoo-backup-*.zip", []string{dumpComponentDatabase, dumpComponentFilestore}},
+	{"odoo-db-*.zip", []string{dumpComponentDatabase}},
+	{"odoo-filestore-*.zip", []string{dumpComponentFilestore}},
+}
+
+// latestDump returns the most recent dump archive in dirs: a full backup, or
+// one made with --db-only or --filestore-only
+func latestDump(dirs ...string) *dumpFile {
+	var latest *dumpFile
+	for _, dir := range dirs {
+		for _, pattern := range dumpPatterns {
+			matches, _ := filepath.Glob(filepath.Join(dir, pattern.glob))
+			for _, match := range matches {
+				info, err := os.Stat(match)
+				if err != nil || info.IsDir() {
+					continue
+				}
+				if latest == nil || info.ModTime().After(latest.CreatedAt) {
+					latest = &dumpFile{Path: match, CreatedAt: info.ModTime(), Contents: pattern.contents}
+				}
+			}
+		}
+	}
+	return latest
+}
+
 func shouldKeepConfigAfterDockerCleanupError(dockerErr error, removeVolumes, removeFiles bool) bool {
 	return dockerErr != nil && removeVolumes && removeFiles
 }
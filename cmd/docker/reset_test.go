@@ -2,7 +2,11 @@ package docker
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestShouldKeepConfigAfterDockerCleanupError(t *testing.T) {
@@ -30,3 +34,53 @@ func TestShouldReturnDockerCleanupError(t *testing.T) {
 		t.Fatal("successful docker cleanup should not return an error")
 	}
 }
+
+func TestLatestDump(t *testing.T) {
+	projectDir, cwd := t.TempDir(), t.TempDir()
+	if dump := latestDump(projectDir, cwd); dump != nil {
+		t.Fatalf("latestDump() = %+v, want nil", dump)
+	}
+
+	older := filepath.Join(projectDir, "odoo-backup-20260101-120000.zip")
+	newer := filepath.Join(cwd, "odoo-backup-20260301-120000.zip")
+	for i, path := range []string{older, newer, filepath.Join(cwd, "notes.zip")} {
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := time.Date(2026, time.Month(i+1), 1, 12, 0, 0, 0, time.UTC)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dump := latestDump(projectDir, cwd)
+	if dump == nil || dump.Path != newer {
+		t.Fatalf("latestDump() = %+v, want %s", dump, newer)
+	}
+}
+
+func TestLatestDumpPartialArchives(t *testing.T) {
+	dir := t.TempDir()
+	for i, tt := range []struct {
+		name     string
+		contents string
+	}{
+		{"odoo-backup-20260101-120000.zip", "database,filestore"},
+		{"odoo-db-20260201-120000.sql.zip", "database"},
+		{"odoo-db-20260301-120000.dump.zip", "database"},
+		{"odoo-filestore-20260401-120000.zip", "filestore"},
+	} {
+		path := filepath.Join(dir, tt.name)
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := time.Date(2026, time.Month(i+1), 1, 12, 0, 0, 0, time.UTC)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		dump := latestDump(dir)
+		if dump == nil || dump.Path != path || strings.Join(dump.Contents, ",") != tt.contents {
+			t.Fatalf("latestDump() = %+v, want %s holding %s", dump, tt.name, tt.contents)
+		}
+	}
+}
@@ -2,17 +2,23 @@ package docker
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
 	dockerlib "github.com/mart337i/odooctl/internal/docker"
 	"github.com/mart337i/odooctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
-var flagRestartJSON bool
+var (
+	flagRestartJSON bool
+	flagRestartHard bool
+)
 
 type restartReport struct {
 	Services []string `json:"services"`
+	Hard     bool     `json:"hard,omitempty"`
 	Output   string   `json:"output,omitempty"`
 }
 
@@ -21,13 +27,24 @@ var restartCmd = &cobra.Command{
 	Short:        "Restart one or more services",
 	SilenceUsage: true,
 	Long: `Restart services in the current environment. Defaults to restarting only
-the Odoo service, which is usually what a developer needs after Python changes.`,
+the Odoo service, which is usually what a developer needs after Python changes.
+
+A plain restart keeps the containers, so changes to the generated compose file
+(ports, mounts, environment) are not picked up. --hard recreates them instead:
+without services the whole stack is taken down and started again, otherwise
+only the named services are removed and recreated. Volumes are kept.
+
+Examples:
+  odooctl docker restart
+  odooctl docker restart db mailhog
+  odooctl docker restart --hard`,
 	Args: cobra.ArbitraryArgs,
 	RunE: runRestart,
 }
 
 func init() {
 	restartCmd.Flags().BoolVar(&flagRestartJSON, "json", false, "Print JSON output")
+	restartCmd.Flags().BoolVar(&flagRestartHard, "hard", false, "Recreate containers (down + up -d) instead of restarting them")
 }
 
 func runRestart(cmd *cobra.Command, args []string) error {
@@ -35,6 +52,9 @@ func runRestart(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if flagRestartHard {
+		return hardRestart(state, args)
+	}
 	services := args
 	if len(services) == 0 {
 		services = []string{"odoo"}
@@ -55,6 +75,50 @@ func runRestart(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// hardRestart recreates the containers of services, or of the whole stack
+func hardRestart(state *config.State, services []string) error {
+	steps := hardRestartSteps(services)
+	label := "all services"
+	if len(services) > 0 {
+		label = joinServices(services)
+	}
+	if !flagRestartJSON {
+		fmt.Printf("Recreating %s...\n", color.CyanString(label))
+	}
+
+	var combined strings.Builder
+	for _, step := range steps {
+		if flagRestartJSON {
+			text, err := dockerlib.ComposeOutput(state, step...)
+			combined.WriteString(text)
+			if err != nil {
+				return fmt.Errorf("failed to recreate %s: %s", label, strings.TrimSpace(text))
+			}
+			continue
+		}
+		if err := dockerlib.Compose(state, step...); err != nil {
+			return fmt.Errorf("failed to recreate %s: %w", label, err)
+		}
+	}
+	if flagRestartJSON {
+		return output.PrintJSON(restartReport{Services: services, Hard: true, Output: combined.String()})
+	}
+	fmt.Printf("%s Recreated %s\n", color.GreenString("✓"), label)
+	return nil
+}
+
+// hardRestartSteps returns the compose commands that recreate services, or
+// the whole stack when none are given
+func hardRestartSteps(services []string) [][]string {
+	if len(services) == 0 {
+		return [][]string{{"down"}, {"up", "-d"}}
+	}
+	return [][]string{
+		append([]string{"rm", "--stop", "--force"}, services...),
+		append([]string{"up", "-d"}, services...),
+	}
+}
+
 func joinServices(services []string) string {
 	if len(services) == 1 {
 		return services[0]
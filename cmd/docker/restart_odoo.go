@@ -0,0 +1,101 @@
+package docker
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	dockerlib "github.com/mart337i/odooctl/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagRestartOdooTimeout time.Duration
+	flagRestartOdooQuiet   bool
+)
+
+var restartOdooCmd = &cobra.Command{
+	Use:          "restart-odoo",
+	Short:        "Restart only Odoo and wait until it is serving",
+	SilenceUsage: true,
+	Long: `Restarts the odoo container without touching the database, then tails its
+startup logs until the HTTP service is up. This is the quick feedback loop
+after Python changes when auto-reload is not available.
+
+Examples:
+  odooctl docker restart-odoo
+  odooctl docker restart-odoo --quiet --timeout 2m`,
+	Args: cobra.NoArgs,
+	RunE: runRestartOdoo,
+}
+
+func init() {
+	restartOdooCmd.Flags().DurationVar(&flagRestartOdooTimeout, "timeout", 90*time.Second, "How long to wait for Odoo to start serving")
+	restartOdooCmd.Flags().BoolVarP(&flagRestartOdooQuiet, "quiet", "q", false, "Only print warnings, errors and tracebacks while waiting")
+}
+
+func runRestartOdoo(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+
+	started := time.Now()
+	since := started.UTC().Format(time.RFC3339)
+	fmt.Printf("Restarting %s...\n", color.CyanString("odoo"))
+	if text, err := dockerlib.ComposeOutput(state, "restart", "odoo"); err != nil {
+		return fmt.Errorf("failed to restart odoo: %s", strings.TrimSpace(text))
+	}
+
+	followCmd := dockerlib.ComposeCommand(state, "logs", "-f", "--no-log-prefix", "--since", since, "odoo")
+	stdout, err := followCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	followCmd.Stderr = followCmd.Stdout
+	if err := followCmd.Start(); err != nil {
+		return fmt.Errorf("failed to follow odoo logs: %w", err)
+	}
+	defer func() {
+		_ = followCmd.Process.Kill()
+		_ = followCmd.Wait()
+	}()
+
+	ready := make(chan bool, 1)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		var filter odooLogFilter
+		for scanner.Scan() {
+			line := scanner.Text()
+			if keep := filter.Keep(line); !flagRestartOdooQuiet || keep {
+				fmt.Println(line)
+			}
+			if isOdooServingLine(line) {
+				ready <- true
+				return
+			}
+		}
+		ready <- false
+	}()
+
+	select {
+	case ok := <-ready:
+		if !ok {
+			return fmt.Errorf("odoo stopped before it started serving; run 'odooctl docker logs' for details")
+		}
+	case <-time.After(flagRestartOdooTimeout):
+		return fmt.Errorf("odoo did not start serving within %s", flagRestartOdooTimeout)
+	}
+
+	fmt.Printf("%s Odoo is serving at http://localhost:%d (%.1fs)\n", color.GreenString("✓"), state.Ports.Odoo, time.Since(started).Seconds())
+	return nil
+}
+
+// isOdooServingLine matches the log line Odoo prints once its HTTP server
+// accepts requests, in both threaded and prefork (workers) mode.
+func isOdooServingLine(line string) bool {
+	return strings.Contains(line, "HTTP service (werkzeug) running on")
+}
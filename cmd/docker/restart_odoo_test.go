@@ -0,0 +1,14 @@
+package docker
+
+import "testing"
+
+func TestRestartOdooLogMatchers(t *testing.T) {
+	serving := "2026-10-16 09:12:01,512 1 INFO ? odoo.service.server: HTTP service (werkzeug) running on 0.0.0.0:8069"
+	if !isOdooServingLine(serving) {
+		t.Fatalf("isOdooServingLine(%q) = false, want true", serving)
+	}
+	if isOdooServingLine("2026-10-16 09:12:00,100 1 INFO ? odoo: Odoo version 17.0") {
+		t.Fatal("isOdooServingLine(version line) = true, want false")
+	}
+
+}
@@ -0,0 +1,16 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHardRestartSteps(t *testing.T) {
+	if got, want := hardRestartSteps(nil), [][]string{{"down"}, {"up", "-d"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hardRestartSteps(nil) = %v, want %v", got, want)
+	}
+	want := [][]string{{"rm", "--stop", "--force", "odoo", "db"}, {"up", "-d", "odoo", "db"}}
+	if got := hardRestartSteps([]string{"odoo", "db"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("hardRestartSteps(odoo, db) = %v, want %v", got, want)
+	}
+}
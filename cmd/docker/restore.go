@@ -0,0 +1,408 @@
+package docker
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagRestoreForce bool
+	flagRestoreJobs  int
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <archive.zip>",
+	Short: "Restore a backup archive created by dump",
+	Long: `Replaces the environment's database and filestore with the contents of a
+backup archive created by 'odooctl docker dump'.
+
+The database is dropped and recreated and the dump is loaded: plain SQL dumps
+with psql, custom-format dumps (dump --format custom) with pg_restore using
+--jobs parallel jobs. The archive's filestore/ directory replaces the
+database's filestore. Archives without a filestore (e.g. of a fresh database,
+or made with dump --db-only) only restore the database, and archives made with
+dump --filestore-only only restore the filestore. Containers are started first
+when they are not running.
+
+When the archive has a metadata.json, the dump is checked against its SHA256
+before anything is replaced, and a backup from another Odoo version is
+reported.
+
+Examples:
+  odooctl docker restore odoo-backup-20240501-100000.zip
+  odooctl docker restore ~/backups/prod.zip --force`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRestore,
+}
+
+func init() {
+	restoreCmd.Flags().BoolVarP(&flagRestoreForce, "force", "f", false, "Skip confirmation prompt")
+	restoreCmd.Flags().IntVarP(&flagRestoreJobs, "jobs", "j", runtime.NumCPU(), "Parallel pg_restore jobs for custom-format dumps")
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	archivePath := args[0]
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", archivePath, err)
+	}
+	defer archive.Close()
+
+	contents, err := inspectDumpArchive(&archive.Reader)
+	if err != nil {
+		return err
+	}
+	filestoreFiles := contents.FilestoreFiles
+	restoreDB := contents.Dump != nil
+	if !restoreDB && filestoreFiles == 0 {
+		return fmt.Errorf("%s holds neither a database dump nor filestore files", archivePath)
+	}
+
+	dbName := state.DBName()
+	fmt.Printf("%s Restoring %s into project: %s\n", cyan("📦"), archivePath, state.ProjectName)
+	replaced := fmt.Sprintf("database %q and its filestore", dbName)
+	switch {
+	case !restoreDB:
+		fmt.Printf("%s Database: %s\n", cyan("📊"), dbName)
+		fmt.Printf("%s The archive has no database dump; only the filestore is restored\n", cyan("ℹ"))
+		replaced = fmt.Sprintf("the filestore of database %q", dbName)
+	case filestoreFiles == 0:
+		fmt.Printf("%s Database: %s (%s dump)\n", cyan("📊"), dbName, contents.Manifest.Format)
+		fmt.Printf("%s The archive has no filestore; only the database is restored\n", cyan("ℹ"))
+		replaced = fmt.Sprintf("database %q", dbName)
+	default:
+		fmt.Printf("%s Database: %s (%s dump)\n", cyan("📊"), dbName, contents.Manifest.Format)
+	}
+	if metadata := contents.Metadata; metadata != nil {
+		fmt.Printf("%s Dumped %s from Odoo %s (odooctl %s)\n", cyan("ℹ"), metadata.CreatedAt.Local().Format("2006-01-02 15:04"), metadata.OdooVersion, metadata.OdooctlVersion)
+		if restoreDB && metadata.OdooVersion != "" && metadata.OdooVersion != state.OdooVersion {
+			fmt.Printf("%s The backup is from Odoo %s but this environment runs %s; Odoo may fail to load the database\n", yellow("⚠️"), metadata.OdooVersion, state.OdooVersion)
+		}
+	}
+	fmt.Println()
+
+	if restoreDB && contents.Metadata != nil && contents.Metadata.DumpSHA256 != "" {
+		fmt.Printf("%s Verifying %s...\n", yellow("→"), contents.Dump.Name)
+		if err := verifyDumpChecksum(contents.Dump, contents.Metadata.DumpSHA256); err != nil {
+			return err
+		}
+		fmt.Printf("%s Checksum matches\n", green("✓"))
+	}
+
+	if !flagRestoreForce {
+		if prompt.NonInteractive() {
+			return fmt.Errorf("restoring replaces %s; pass --force to confirm in non-interactive mode", replaced)
+		}
+		confirmed, err := prompt.Confirm(fmt.Sprintf("This will replace %s for %q. Continue?", replaced, state.ProjectName), false)
+		if err != nil || !confirmed {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if !docker.IsRunning(state) {
+		fmt.Printf("%s Starting containers...\n", yellow("→"))
+		if err := docker.Compose(state, "up", "-d", "--wait"); err != nil {
+			return fmt.Errorf("failed to start containers: %w", err)
+		}
+	}
+
+	if restoreDB {
+		// Recreate the database with Odoo stopped, so nothing reconnects
+		fmt.Printf("%s Stopping Odoo container...\n", yellow("→"))
+		if err := docker.Compose(state, "stop", "odoo"); err != nil {
+			fmt.Printf("%s Warning: failed to stop odoo container: %v\n", yellow("!"), err)
+		}
+		fmt.Printf("%s Restoring database...\n", yellow("→"))
+		restoreErr := restoreDatabase(state, contents, dbName)
+
+		// Always restart the odoo container, even if the restore failed
+		fmt.Println("Restarting Odoo container...")
+		if err := docker.Compose(state, "up", "-d", "odoo"); err != nil {
+			fmt.Printf("%s Warning: failed to restart odoo container: %v\n", yellow("!"), err)
+		}
+		// The filestore is left as it was, matching the old database
+		if restoreErr != nil {
+			return fmt.Errorf("failed to restore database: %w", restoreErr)
+		}
+		fmt.Printf("%s Database restored\n", green("✓"))
+	}
+
+	// Replace the filestore once the database is in place, with the odoo
+	// container up to run in
+	if filestoreFiles > 0 {
+		fmt.Printf("%s Restoring filestore (%d files)...\n", yellow("→"), filestoreFiles)
+		if err := restoreFilestore(state, &archive.Reader, dbName); err != nil {
+			return fmt.Errorf("failed to restore filestore: %w", err)
+		}
+		fmt.Printf("%s Filestore restored\n", green("✓"))
+	}
+	if !restoreDB {
+		fmt.Printf("\n%s Filestore restored into %s\n", green("✓"), cyan(dbName))
+		return nil
+	}
+
+	fmt.Printf("\n%s Backup restored into %s\n", green("✓"), cyan(dbName))
+	return nil
+}
+
+// dumpArchive is the content of a backup archive created by dump
+type dumpArchive struct {
+	Manifest dumpManifest
+	// Metadata is nil for archives made before metadata.json was added
+	Metadata *dumpMetadata
+	// Dump is nil for filestore-only archives
+	Dump           *zip.File
+	FilestoreFiles int
+}
+
+// inspectDumpArchive finds the database dump of an archive and counts the
+// files in its filestore. Archives without a manifest hold a plain SQL dump;
+// those whose manifest lists only the filestore hold no dump.
+func inspectDumpArchive(archive *zip.Reader) (dumpArchive, error) {
+	result := dumpArchive{Manifest: newDumpManifest(dumpFormatPlain)}
+	for _, file := range archive.File {
+		switch file.Name {
+		case dumpManifestName:
+			var manifest dumpManifest
+			if err := readZipJSON(file, &manifest); err != nil {
+				return result, err
+			}
+			result.Manifest = manifest
+			if manifest.DumpFile == "" && manifest.Has(dumpComponentDatabase) {
+				result.Manifest.DumpFile = newDumpManifest(manifest.Format).DumpFile
+			}
+		case dumpMetadataName:
+			var metadata dumpMetadata
+			if err := readZipJSON(file, &metadata); err != nil {
+				return result, err
+			}
+			result.Metadata = &metadata
+		}
+	}
+	hasDatabase := result.Manifest.Has(dumpComponentDatabase)
+	if hasDatabase && result.Manifest.Format != dumpFormatPlain && result.Manifest.Format != dumpFormatCustom {
+		return result, fmt.Errorf("unsupported dump format %q in %s", result.Manifest.Format, dumpManifestName)
+	}
+
+	for _, file := range archive.File {
+		switch {
+		case hasDatabase && file.Name == result.Manifest.DumpFile:
+			result.Dump = file
+		case strings.HasPrefix(file.Name, "filestore/") && !file.FileInfo().IsDir():
+			result.FilestoreFiles++
+		}
+	}
+	if hasDatabase && result.Dump == nil {
+		return result, fmt.Errorf("archive has no %s; is it a backup created by 'odooctl docker dump'?", result.Manifest.DumpFile)
+	}
+	return result, nil
+}
+
+func readZipJSON(file *zip.File, v any) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if err := json.NewDecoder(reader).Decode(v); err != nil {
+		return fmt.Errorf("invalid %s: %w", file.Name, err)
+	}
+	return nil
+}
+
+// verifyDumpChecksum compares the SHA256 of the dump entry with the one
+// recorded in metadata.json
+func verifyDumpChecksum(dump *zip.File, want string) error {
+	reader, err := dump.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return fmt.Errorf("failed to read %s: %w", dump.Name, err)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != want {
+		return fmt.Errorf("%s is corrupt: SHA256 is %s, metadata.json records %s", dump.Name, got, want)
+	}
+	return nil
+}
+
+// restoreDatabase drops and recreates dbName and loads the dump into it
+func restoreDatabase(state *config.State, archive dumpArchive, dbName string) error {
+	if err := recreateDatabase(state, dbName); err != nil {
+		return err
+	}
+	if archive.Manifest.Format == dumpFormatCustom {
+		return pgRestore(state, archive.Dump, dbName)
+	}
+
+	reader, err := archive.Dump.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	cmd := docker.ComposeCommand(state, "exec", "-T", "db", "psql", "-U", "odoo", "-d", dbName, "-q", "-v", "ON_ERROR_STOP=1")
+	cmd.Stdin = reader
+	cmd.Stdout = io.Discard
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// recreateDatabase replaces dbName with an empty database owned by odoo.
+// Open connections are terminated first: dropdb --force needs Postgres 13.
+func recreateDatabase(state *config.State, dbName string) error {
+	dropArgs := []string{"exec", "-T", "db", "dropdb", "-U", "odoo", "--if-exists"}
+	if config.PostgresMajor(state.PostgresImageVersion()) >= 13 {
+		dropArgs = append(dropArgs, "--force")
+	} else {
+		terminate := fmt.Sprintf("SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = '%s' AND pid <> pg_backend_pid();", strings.ReplaceAll(dbName, "'", "''"))
+		if text, err := docker.ComposeOutput(state, "exec", "-T", "db", "psql", "-U", "odoo", "-d", "postgres", "-q", "-c", terminate); err != nil {
+			return fmt.Errorf("failed to close connections to %s: %s", dbName, strings.TrimSpace(text))
+		}
+	}
+	if text, err := docker.ComposeOutput(state, append(dropArgs, dbName)...); err != nil {
+		return fmt.Errorf("dropdb failed: %s", strings.TrimSpace(text))
+	}
+	if text, err := docker.ComposeOutput(state, "exec", "-T", "db", "createdb", "-U", "odoo", "-O", "odoo", dbName); err != nil {
+		return fmt.Errorf("createdb failed: %s", strings.TrimSpace(text))
+	}
+	return nil
+}
+
+// pgRestore loads a custom-format dump from the archive with parallel jobs
+func pgRestore(state *config.State, dump *zip.File, dbName string) error {
+	tmpDir, err := os.MkdirTemp("", "odooctl-restore-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	localFile := filepath.Join(tmpDir, "database.dump")
+	if err := extractZipFile(dump, localFile); err != nil {
+		return err
+	}
+	return pgRestoreFile(state, localFile, dbName, flagRestoreJobs)
+}
+
+// pgRestoreFile loads a local custom-format dump into dbName. pg_restore -j
+// cannot read from stdin, so the dump is copied into the db container first.
+func pgRestoreFile(state *config.State, localFile, dbName string, jobs int) error {
+	containerFile := "/tmp/odooctl-restore.dump"
+	if text, err := docker.ComposeOutput(state, "cp", localFile, "db:"+containerFile); err != nil {
+		return fmt.Errorf("docker cp failed: %s", strings.TrimSpace(text))
+	}
+	defer docker.ComposeOutput(state, "exec", "-T", "db", "rm", "-f", containerFile)
+
+	if jobs < 1 {
+		jobs = 1
+	}
+	return docker.Compose(state, "exec", "-T", "db", "pg_restore", "-U", "odoo", "-d", dbName, "--no-owner", "--no-acl", "-j", strconv.Itoa(jobs), containerFile)
+}
+
+// restoreFilestore replaces the database's filestore in the odoo container
+// with the archive's filestore/ directory
+func restoreFilestore(state *config.State, archive *zip.Reader, dbName string) error {
+	tmpDir, err := os.MkdirTemp("", "odooctl-restore-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	localDir := filepath.Join(tmpDir, dbName)
+	if err := extractFilestore(archive, localDir); err != nil {
+		return err
+	}
+	return replaceFilestore(state, localDir, dbName)
+}
+
+// replaceFilestore replaces the database's filestore in the odoo container
+// with the contents of localDir
+func replaceFilestore(state *config.State, localDir, dbName string) error {
+	containerDir := "/var/lib/odoo/filestore/" + dbName
+	if text, err := docker.ComposeOutput(state, "exec", "-T", "odoo", "rm", "-rf", containerDir); err != nil {
+		return fmt.Errorf("failed to remove the current filestore: %s", strings.TrimSpace(text))
+	}
+	if text, err := docker.ComposeOutput(state, "cp", localDir, "odoo:"+containerDir); err != nil {
+		return fmt.Errorf("docker cp failed: %s", strings.TrimSpace(text))
+	}
+	// docker cp creates the files as root
+	if text, err := docker.ComposeOutput(state, "exec", "-T", "-u", "root", "odoo", "chown", "-R", "odoo:odoo", containerDir); err != nil {
+		return fmt.Errorf("failed to hand the filestore to the odoo user: %s", strings.TrimSpace(text))
+	}
+	return nil
+}
+
+// extractFilestore writes the files under filestore/ in archive to dest
+func extractFilestore(archive *zip.Reader, dest string) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	for _, file := range archive.File {
+		rel, ok := strings.CutPrefix(file.Name, "filestore/")
+		if !ok || rel == "" || file.FileInfo().IsDir() {
+			continue
+		}
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+		if !sameOrInside(target, dest) {
+			return fmt.Errorf("archive entry %q is outside the filestore", file.Name)
+		}
+		if err := extractZipFile(file, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func sameOrInside(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,133 @@
+package docker
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func buildZip(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("Create(%s) error = %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Write(%s) error = %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	return reader
+}
+
+func TestInspectDumpArchive(t *testing.T) {
+	archive := buildZip(t, map[string]string{
+		"database.sql":        "SELECT 1;",
+		"filestore/ab/abc123": "data",
+		"filestore/cd/cdef45": "data",
+	})
+	contents, err := inspectDumpArchive(archive)
+	if err != nil {
+		t.Fatalf("inspectDumpArchive() error = %v", err)
+	}
+	if contents.Dump.Name != "database.sql" || contents.Manifest.Format != dumpFormatPlain || contents.FilestoreFiles != 2 {
+		t.Fatalf("inspectDumpArchive() = %s, %s, %d, want database.sql, plain, 2", contents.Dump.Name, contents.Manifest.Format, contents.FilestoreFiles)
+	}
+
+	if _, err := inspectDumpArchive(buildZip(t, map[string]string{"filestore/ab/abc123": "data"})); err == nil {
+		t.Fatal("inspectDumpArchive(no database.sql) error = nil, want an error")
+	}
+}
+
+func TestInspectDumpArchiveCustomFormat(t *testing.T) {
+	archive := buildZip(t, map[string]string{
+		"manifest.json": `{"format": "custom", "dump_file": "database.dump"}`,
+		"database.dump": "PGDMP",
+	})
+	contents, err := inspectDumpArchive(archive)
+	if err != nil {
+		t.Fatalf("inspectDumpArchive() error = %v", err)
+	}
+	if contents.Dump.Name != "database.dump" || contents.Manifest.Format != dumpFormatCustom || contents.FilestoreFiles != 0 {
+		t.Fatalf("inspectDumpArchive() = %s, %s, %d, want database.dump, custom, 0", contents.Dump.Name, contents.Manifest.Format, contents.FilestoreFiles)
+	}
+
+	unknown := buildZip(t, map[string]string{"manifest.json": `{"format": "tar"}`, "database.sql": ""})
+	if _, err := inspectDumpArchive(unknown); err == nil {
+		t.Fatal("inspectDumpArchive(tar format) error = nil, want an error")
+	}
+}
+
+func TestInspectDumpArchiveFilestoreOnly(t *testing.T) {
+	archive := buildZip(t, map[string]string{
+		"manifest.json":       `{"components": ["filestore"]}`,
+		"filestore/ab/abc123": "data",
+	})
+	contents, err := inspectDumpArchive(archive)
+	if err != nil {
+		t.Fatalf("inspectDumpArchive() error = %v", err)
+	}
+	if contents.Dump != nil || contents.FilestoreFiles != 1 {
+		t.Fatalf("inspectDumpArchive() = %v, %d, want no dump and 1 filestore file", contents.Dump, contents.FilestoreFiles)
+	}
+	if contents.Manifest.Has(dumpComponentDatabase) {
+		t.Fatal("Manifest.Has(database) = true for a filestore-only archive")
+	}
+}
+
+func TestVerifyDumpChecksum(t *testing.T) {
+	// sha256 of "SELECT 1;"
+	sum := "17db4fd369edb9244b9f91d9aeed145c3d04ad8ba6e95d06247f07a63527d11a"
+	archive := buildZip(t, map[string]string{
+		"database.sql":  "SELECT 1;",
+		"metadata.json": `{"odoo_version": "17.0", "dump_sha256": "` + sum + `"}`,
+	})
+	contents, err := inspectDumpArchive(archive)
+	if err != nil {
+		t.Fatalf("inspectDumpArchive() error = %v", err)
+	}
+	if contents.Metadata == nil || contents.Metadata.OdooVersion != "17.0" {
+		t.Fatalf("inspectDumpArchive() metadata = %+v, want odoo_version 17.0", contents.Metadata)
+	}
+	if err := verifyDumpChecksum(contents.Dump, contents.Metadata.DumpSHA256); err != nil {
+		t.Fatalf("verifyDumpChecksum() error = %v", err)
+	}
+	if err := verifyDumpChecksum(contents.Dump, strings.Repeat("0", 64)); err == nil {
+		t.Fatal("verifyDumpChecksum(wrong sum) error = nil, want an error")
+	}
+}
+
+func TestExtractFilestore(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "odoo-17")
+	archive := buildZip(t, map[string]string{
+		"database.sql":        "SELECT 1;",
+		"filestore/ab/abc123": "data",
+	})
+	if err := extractFilestore(archive, dest); err != nil {
+		t.Fatalf("extractFilestore() error = %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dest, "ab", "abc123"))
+	if err != nil || string(content) != "data" {
+		t.Fatalf("extracted file = %q, %v, want data", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "database.sql")); !os.IsNotExist(err) {
+		t.Fatalf("database.sql was extracted into the filestore")
+	}
+
+	escaping := buildZip(t, map[string]string{"filestore/../../evil": "data"})
+	if err := extractFilestore(escaping, dest); err == nil {
+		t.Fatal("extractFilestore(../) error = nil, want an error")
+	}
+}
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,16 +11,29 @@ import (
 	"github.com/fatih/color"
 	"github.com/mart337i/odooctl/internal/config"
 	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/project"
 	"github.com/mart337i/odooctl/internal/templates"
 	"github.com/mart337i/odooctl/pkg/prompt"
 	"github.com/spf13/cobra"
 )
 
+// How long run -i waits for the database after init, and how often it tries
+// to set report.url when the environment predates init-params.py
+const (
+	dbReadyTimeout    = 30 * time.Second
+	reportURLAttempts = 5
+)
+
 var (
-	flagRunBuild    bool
-	flagRunInit     bool
-	flagRunDetach   bool
-	flagRunNoPrompt bool
+	flagRunBuild            bool
+	flagRunInit             bool
+	flagRunDetach           bool
+	flagRunNoPrompt         bool
+	flagRunCacheFrom        string
+	flagRunDebug            bool
+	flagRunInteractivePorts bool
+	flagRunWait             bool
+	flagRunWaitTimeout      time.Duration
 )
 
 var runCmd = &cobra.Command{
@@ -33,7 +47,22 @@ By default, just starts the containers. Use -i to initialize the database first.
 Examples:
   odooctl docker run              # Start containers
   odooctl docker run -i           # Initialize database and start
-  odooctl docker run --build      # Rebuild before starting`,
+  odooctl docker run --build      # Rebuild before starting
+  odooctl docker run --build --cache-from ghcr.io/acme/odoo-dev:17.0
+  odooctl docker run --debug      # Start Odoo under debugpy for IDE attach
+  odooctl docker run --interactive-ports  # Pick ports yourself on a conflict
+  odooctl docker run --wait       # Return once Odoo answers HTTP (e.g. in CI)
+
+On a port conflict the environment moves to the next free set of ports. With
+--interactive-ports (or 'odooctl config set interactive-ports true' on a
+terminal) you can accept that set, type your own ports, or abort.
+
+--debug keeps applying until the next 'odooctl docker run' without it. Odoo's
+auto-reload is off while debugging, so restart Odoo to load Python changes.
+
+--wait polls Odoo's login page after starting until it answers or
+--wait-timeout elapses; on timeout the end of the odoo log is shown and the
+command fails.`,
 	RunE: runRun,
 }
 
@@ -42,9 +71,17 @@ func init() {
 	runCmd.Flags().BoolVarP(&flagRunInit, "init", "i", false, "Initialize database before starting")
 	runCmd.Flags().BoolVarP(&flagRunDetach, "detach", "d", true, "Run in background")
 	runCmd.Flags().BoolVar(&flagRunNoPrompt, "no-prompt", false, "Skip interactive prompts (for CI/automation)")
+	runCmd.Flags().BoolVar(&flagRunDebug, "debug", false, "Start Odoo under debugpy, listening on the environment's debug port")
+	runCmd.Flags().BoolVar(&flagRunInteractivePorts, "interactive-ports", false, "Ask how to resolve port conflicts instead of moving to the next free ports")
+	runCmd.Flags().BoolVar(&flagRunWait, "wait", false, "Wait until Odoo answers HTTP requests before returning")
+	runCmd.Flags().DurationVar(&flagRunWaitTimeout, "wait-timeout", 120*time.Second, "How long --wait waits for Odoo")
+	runCmd.Flags().StringVar(&flagRunCacheFrom, "cache-from", "", "Image to seed the build cache from (saved for this environment, 'none' to disable)")
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
+	if flagRunWait && !flagRunDetach {
+		return fmt.Errorf("--wait needs detached mode; it cannot be combined with --detach=false")
+	}
 	state, err := loadState()
 	if err != nil {
 		return err
@@ -57,14 +94,39 @@ func runRun(cmd *cobra.Command, args []string) error {
 	cyan := color.New(color.FgCyan).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 
+	if cmd.Flags().Changed("cache-from") {
+		if err := applyCacheFromFlag(state, flagRunCacheFrom); err != nil {
+			return err
+		}
+		if err := state.Save(); err != nil {
+			return fmt.Errorf("failed to save state: %w", err)
+		}
+	}
+
+	if flagRunDebug != state.DebugpyEnabled {
+		state.DebugpyEnabled = flagRunDebug
+		if err := templates.Render(state); err != nil {
+			return fmt.Errorf("failed to regenerate templates: %w", err)
+		}
+		if err := state.Save(); err != nil {
+			return fmt.Errorf("failed to save state: %w", err)
+		}
+	}
+
 	// Check for port conflicts
 	available, conflicting := state.Ports.CheckPortsAvailable()
 	if !available {
 		fmt.Printf("%s Port conflict detected: %v\n", yellow("⚠️"), conflicting)
-		fmt.Println("Regenerating configuration with available ports...")
 
-		newPorts := config.FindAvailablePorts(state.OdooVersion)
-		state.Ports = newPorts
+		if interactivePorts() {
+			state.Ports, err = choosePorts(state, conflicting)
+			if err != nil {
+				return err
+			}
+		} else {
+			fmt.Println("Regenerating configuration with available ports...")
+			state.Ports = config.FindAvailablePorts(state.OdooVersion, state.ProjectName, state.Branch)
+		}
 
 		// Regenerate templates with new ports
 		if err := templates.Render(state); err != nil {
@@ -113,6 +175,18 @@ func runRun(cmd *cobra.Command, args []string) error {
 		if refreshed {
 			fmt.Printf("%s Refreshed Docker configuration to avoid system pip conflicts\n", green("✓"))
 		}
+		if err := prepareBuildCache(state); err != nil {
+			return fmt.Errorf("failed to prepare build cache: %w", err)
+		}
+	}
+
+	// odoo-init sets report.url from init-params.py; configuration rendered
+	// by older versions lacks it and falls back to setting it with SQL
+	initParams := false
+	if flagRunInit {
+		if initParams, err = ensureInitParams(state); err != nil {
+			fmt.Printf("%s Warning: failed to regenerate Docker configuration: %v\n", yellow("⚠️"), err)
+		}
 	}
 
 	fmt.Println("Starting containers...")
@@ -128,6 +202,11 @@ func runRun(cmd *cobra.Command, args []string) error {
 	if err := docker.Compose(state, upArgs...); err != nil {
 		return fmt.Errorf("failed to start containers: %w", err)
 	}
+	startedAt := time.Now()
+	state.LastStartedAt = &startedAt
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
 	depsSynced, err := ensureConfiguredPythonDepsSynced(state)
 	if err != nil {
 		return err
@@ -160,17 +239,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to initialize: %w", err)
 		}
 
-		// Ensure db is running before configuring report.url
-		// (--abort-on-container-exit may have stopped it along with odoo-init)
+		// --abort-on-container-exit may have stopped db along with odoo-init
 		if err := docker.Compose(state, "up", "-d", "db"); err != nil {
 			fmt.Printf("%s Warning: failed to restart db: %v\n", yellow("⚠️"), err)
 		}
-
-		// Configure report.url parameter
-		fmt.Println("Configuring report.url parameter...")
-		sql := "INSERT INTO ir_config_parameter (key, value) VALUES ('report.url', 'http://odoo:8069') ON CONFLICT (key) DO UPDATE SET value = 'http://odoo:8069';"
-		if err := docker.Compose(state, "exec", "-T", "db", "psql", "-U", "odoo", "-d", state.DBName(), "-c", sql); err != nil {
-			fmt.Printf("%s Warning: failed to configure report.url: %v\n", yellow("⚠️"), err)
+		if !initParams {
+			setReportURL(state)
 		}
 
 		// Track that initialization has been done
@@ -185,11 +259,20 @@ func runRun(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s Database initialized\n\n", green("✓"))
 	}
 
+	if flagRunWait {
+		if err := waitForOdoo(state, flagRunWaitTimeout); err != nil {
+			return err
+		}
+	}
+
 	if flagRunDetach {
 		fmt.Println()
 		fmt.Printf("%s Containers started!\n\n", green("✓"))
 		fmt.Printf("  Odoo:     %s\n", cyan(fmt.Sprintf("http://localhost:%d", state.Ports.Odoo)))
 		fmt.Printf("  Mailhog:  %s\n", cyan(fmt.Sprintf("http://localhost:%d", state.Ports.Mailhog)))
+		if state.DebugpyEnabled {
+			fmt.Printf("  Debugpy:  %s (attach your IDE; see 'odooctl docker debug-info')\n", cyan(fmt.Sprintf("localhost:%d", state.Ports.Debug)))
+		}
 		fmt.Println()
 	}
 
@@ -221,7 +304,53 @@ func refreshStaleDockerfile(state *config.State) (bool, error) {
 	return true, nil
 }
 
+// ensureInitParams makes sure the environment has init-params.py, rendering
+// the templates again for environments created before it existed. It reports
+// whether odoo-init sets the system parameters itself.
+func ensureInitParams(state *config.State) (bool, error) {
+	dir, err := config.EnvironmentDir(state.ProjectName, state.Branch)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "init-params.py")); err == nil {
+		return true, nil
+	}
+	if err := templates.Render(state); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// setReportURL sets report.url with SQL after odoo-init has run, for when
+// init-params.py could not be rendered
+func setReportURL(state *config.State) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	if err := docker.WaitForService(state, "db", dbReadyTimeout); err != nil {
+		fmt.Printf("%s Warning: %v\n", yellow("⚠️"), err)
+	}
+
+	fmt.Println("Configuring report.url parameter...")
+	value := strings.ReplaceAll(state.ReportURLParam(), "'", "''")
+	sql := fmt.Sprintf("INSERT INTO ir_config_parameter (key, value) VALUES ('report.url', '%s') ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value;", value)
+	var psqlOutput string
+	err := docker.Retry(reportURLAttempts, func() error {
+		var err error
+		psqlOutput, err = docker.ComposeOutput(state, "exec", "-T", "db", "psql", "-U", "odoo", "-d", state.DBName(), "-c", sql)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("%s Warning: failed to configure report.url: %v\n%s\n", yellow("⚠️"), err, strings.TrimSpace(psqlOutput))
+	}
+}
+
+// loadState loads the environment chosen with --project/--environment, or
+// the current directory's
 func loadState() (*config.State, error) {
+	if flagProject != "" {
+		return loadSelectedState(flagProject, flagEnvironment)
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		return nil, err
@@ -229,8 +358,54 @@ func loadState() (*config.State, error) {
 
 	state, err := config.LoadFromDir(cwd)
 	if err != nil {
+		var stale *config.StaleProjectLinkError
+		if errors.As(err, &stale) {
+			return nil, fmt.Errorf("no Docker environment found (%v). Run 'odooctl docker create' first", err)
+		}
+		if flagEnvironment != "" {
+			return loadSelectedState(project.Detect(cwd).Name, flagEnvironment)
+		}
 		return nil, fmt.Errorf("no Docker environment found. Run 'odooctl docker create' first")
 	}
 
+	if flagEnvironment != "" && flagEnvironment != state.Branch {
+		return loadSelectedState(state.ProjectName, flagEnvironment)
+	}
 	return state, nil
 }
+
+// loadSelectedState loads a project's environment by name. Without an
+// environment name the project must have exactly one.
+func loadSelectedState(projectName, environment string) (*config.State, error) {
+	if environment != "" {
+		if !config.EnvironmentExists(projectName, environment) {
+			return nil, fmt.Errorf("no Docker environment %s/%s found. See 'odooctl docker list'", projectName, environment)
+		}
+		state, err := config.Load(projectName, environment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load environment %s/%s: %w", projectName, environment, err)
+		}
+		return state, nil
+	}
+
+	environments, err := config.AllEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	var found *config.State
+	for _, env := range environments {
+		if env.State.ProjectName == projectName {
+			names = append(names, env.State.Branch)
+			found = env.State
+		}
+	}
+	switch len(names) {
+	case 0:
+		return nil, fmt.Errorf("no Docker environments found for project %q. See 'odooctl docker list'", projectName)
+	case 1:
+		return found, nil
+	default:
+		return nil, fmt.Errorf("project %q has several environments (%s); pick one with --environment", projectName, strings.Join(names, ", "))
+	}
+}
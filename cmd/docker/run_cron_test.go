@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCronScriptLooksUpXMLIDs(t *testing.T) {
+	script := cronScript([]string{"my_module.ir_cron_sync", "base.autovacuum_job"}, false)
+
+	for _, want := range []string{
+		`for xml_id in ["my_module.ir_cron_sync", "base.autovacuum_job"]:`,
+		"env.ref(xml_id, raise_if_not_found=False)",
+		"cron.method_direct_trigger()",
+		"env.cr.commit()",
+	} {
+		if !strings.Contains(script, want) {
+			t.Fatalf("cronScript() missing %q:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "search([])") {
+		t.Fatal("cronScript() searches all crons when XML IDs were given")
+	}
+}
+
+func TestCronScriptAll(t *testing.T) {
+	script := cronScript(nil, true)
+	if !strings.Contains(script, "crons = env['ir.cron'].search([])") {
+		t.Fatalf("cronScript(all) does not search active crons:\n%s", script)
+	}
+	if strings.Contains(script, "env.ref(") {
+		t.Fatal("cronScript(all) looks up XML IDs")
+	}
+}
+
+func TestCronXMLIDPattern(t *testing.T) {
+	for _, valid := range []string{"base.autovacuum_job", "my_module.ir_cron_sync_orders"} {
+		if !cronXMLIDPattern.MatchString(valid) {
+			t.Errorf("cronXMLIDPattern rejects %q", valid)
+		}
+	}
+	for _, invalid := range []string{"ir_cron_sync", "my_module.", "x'); import os; ('y.z"} {
+		if cronXMLIDPattern.MatchString(invalid) {
+			t.Errorf("cronXMLIDPattern accepts %q", invalid)
+		}
+	}
+}
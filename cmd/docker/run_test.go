@@ -64,3 +64,29 @@ func TestRunSilencesUsageOnRuntimeErrors(t *testing.T) {
 		t.Fatal("docker run should not print usage for runtime errors")
 	}
 }
+
+func TestLoadSelectedState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, env := range []struct{ project, branch string }{{"shop", "main"}, {"shop", "feature"}, {"blog", "main"}} {
+		state := &config.State{ProjectName: env.project, OdooVersion: "17.0", Branch: env.branch, ProjectRoot: "/src/" + env.project}
+		if err := state.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state, err := loadSelectedState("shop", "feature")
+	if err != nil || state.ProjectName != "shop" || state.Branch != "feature" {
+		t.Fatalf("loadSelectedState(shop, feature) = %+v, %v", state, err)
+	}
+	// A project with a single environment needs no --environment
+	state, err = loadSelectedState("blog", "")
+	if err != nil || state.Branch != "main" {
+		t.Fatalf("loadSelectedState(blog) = %+v, %v", state, err)
+	}
+	if _, err := loadSelectedState("shop", ""); err == nil || !strings.Contains(err.Error(), "feature, main") {
+		t.Fatalf("loadSelectedState(shop) error = %v, want the environments to pick from", err)
+	}
+	if _, err := loadSelectedState("shop", "missing"); err == nil {
+		t.Fatal("loadSelectedState(shop, missing) error = nil, want error")
+	}
+}
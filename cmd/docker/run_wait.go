@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+)
+
+// waitLogLines is how much of the odoo log is shown when Odoo never came up
+const waitLogLines = 30
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// waitForOdoo blocks until Odoo's login page answers or timeout elapses. On
+// timeout the end of the odoo log is printed to show why.
+func waitForOdoo(state *config.State, timeout time.Duration) error {
+	url := fmt.Sprintf("http://localhost:%d/web/login", state.Ports.Odoo)
+	spinner := stdoutIsTerminal()
+	if !spinner {
+		fmt.Printf("Waiting for Odoo at %s (up to %s)...\n", url, timeout)
+	}
+
+	frame := 0
+	err := waitForURL(url, timeout, time.Second, func(elapsed time.Duration) {
+		if spinner {
+			fmt.Printf("\r%s Waiting for Odoo at %s... %ds ", color.CyanString(spinnerFrames[frame%len(spinnerFrames)]), url, int(elapsed.Seconds()))
+			frame++
+		}
+	})
+	if spinner {
+		fmt.Print("\r\033[K")
+	}
+	if err == nil {
+		fmt.Printf("%s Odoo is up at %s\n", color.GreenString("✓"), url)
+		return nil
+	}
+
+	fmt.Printf("%s %v. Last %d lines of the odoo log:\n\n", color.YellowString("⚠️"), err, waitLogLines)
+	if text, logErr := docker.ComposeOutput(state, "logs", "--no-color", "--tail", fmt.Sprint(waitLogLines), "odoo"); logErr == nil {
+		fmt.Println(strings.TrimRight(text, "\n"))
+	}
+	return err
+}
+
+// waitForURL polls url every interval until it answers with a status below
+// 500 or timeout elapses, calling tick while it waits
+func waitForURL(url string, timeout, interval time.Duration, tick func(elapsed time.Duration)) error {
+	client := &http.Client{
+		Timeout: interval,
+		// A redirect (e.g. to a database selector) still means Odoo is serving
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	start := time.Now()
+	for {
+		if resp, err := client.Get(url); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 500 {
+				return nil
+			}
+		}
+		elapsed := time.Since(start)
+		if elapsed >= timeout {
+			return fmt.Errorf("odoo did not answer at %s within %s", url, timeout)
+		}
+		tick(elapsed)
+		time.Sleep(min(interval, timeout-elapsed))
+	}
+}
+
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,43 @@
+package docker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForURL(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Odoo answers 500s while the registry is still loading
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		http.Redirect(w, r, "/web/database/selector", http.StatusSeeOther)
+	}))
+	defer server.Close()
+
+	ticks := 0
+	if err := waitForURL(server.URL+"/web/login", 5*time.Second, 10*time.Millisecond, func(time.Duration) { ticks++ }); err != nil {
+		t.Fatalf("waitForURL() error = %v", err)
+	}
+	if ticks != 2 {
+		t.Errorf("ticks = %d, want 2", ticks)
+	}
+}
+
+func TestWaitForURLTimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := waitForURL(server.URL, 50*time.Millisecond, 10*time.Millisecond, func(time.Duration) {})
+	if err == nil || !strings.Contains(err.Error(), "did not answer") {
+		t.Fatalf("waitForURL() error = %v, want a timeout", err)
+	}
+}
@@ -5,26 +5,35 @@ import (
 	"os"
 	"strings"
 
+	"github.com/mart337i/odooctl/internal/config"
 	dockerlib "github.com/mart337i/odooctl/internal/docker"
 	"github.com/spf13/cobra"
 )
 
 var (
-	flagSQLDatabase string
-	flagSQLFile     string
-	flagSQLJSON     bool
+	flagSQLDatabase   string
+	flagSQLFile       string
+	flagSQLCommand    string
+	flagSQLJSON       bool
+	flagSQLTuplesOnly bool
 )
 
 var sqlCmd = &cobra.Command{
 	Use:          "sql [query]",
+	Aliases:      []string{"psql"},
 	Short:        "Run a SQL query against the Odoo database",
 	SilenceUsage: true,
 	Long: `Run quick SQL without opening an interactive psql session.
 
+psql runs without a TTY and stops at the first error, so the exit status can
+be used in scripts and CI. --tuples-only prints bare, unaligned rows (psql -tA).
+Files are run with psql -f, so psql meta-commands work in them.
+
 Examples:
   odooctl docker sql "select id, login from res_users"
   odooctl docker sql --json "select id, name from ir_module_module"
-  odooctl docker sql --file debug.sql`,
+  odooctl docker sql --file debug.sql
+  odooctl docker psql -t -c "select value from ir_config_parameter where key = 'web.base.url'"`,
 	Args: cobra.ArbitraryArgs,
 	RunE: runSQL,
 }
@@ -32,6 +41,8 @@ Examples:
 func init() {
 	sqlCmd.Flags().StringVarP(&flagSQLDatabase, "database", "d", "", "Database name (auto-detected if omitted)")
 	sqlCmd.Flags().StringVarP(&flagSQLFile, "file", "f", "", "Read SQL from a file")
+	sqlCmd.Flags().StringVarP(&flagSQLCommand, "command", "c", "", "SQL to run (instead of the query argument)")
+	sqlCmd.Flags().BoolVarP(&flagSQLTuplesOnly, "tuples-only", "t", false, "Print rows only, without headers, footers or alignment")
 	sqlCmd.Flags().BoolVar(&flagSQLJSON, "json", false, "Wrap a SELECT query and print JSON rows")
 }
 
@@ -44,6 +55,15 @@ func runSQL(cmd *cobra.Command, args []string) error {
 	if database == "" {
 		database = state.DBName()
 	}
+	if flagSQLCommand != "" {
+		if len(args) > 0 || flagSQLFile != "" {
+			return fmt.Errorf("--command cannot be combined with a query argument or --file")
+		}
+		args = []string{flagSQLCommand}
+	}
+	if flagSQLFile != "" && !flagSQLJSON {
+		return runSQLFile(state, database, flagSQLFile)
+	}
 	query, err := sqlQuery(args, flagSQLFile)
 	if err != nil {
 		return err
@@ -60,7 +80,31 @@ func runSQL(cmd *cobra.Command, args []string) error {
 		fmt.Println(strings.TrimSpace(text))
 		return nil
 	}
-	return dockerlib.Compose(state, "exec", "-T", "db", "psql", "-U", "odoo", "-d", database, "-c", query)
+	return dockerlib.Compose(state, append(psqlArgs(database), "-c", query)...)
+}
+
+// psqlArgs builds the non-interactive psql invocation shared by queries and files
+func psqlArgs(database string) []string {
+	args := []string{"exec", "-T", "db", "psql", "-U", "odoo", "-d", database, "-v", "ON_ERROR_STOP=1"}
+	if flagSQLTuplesOnly {
+		args = append(args, "-t", "-A")
+	}
+	return args
+}
+
+// runSQLFile streams a SQL file to psql -f
+func runSQLFile(state *config.State, database, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	cmd := dockerlib.ComposeCommand(state, append(psqlArgs(database), "-f", "-")...)
+	cmd.Stdin = file
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
 }
 
 func sqlQuery(args []string, file string) (string, error) {
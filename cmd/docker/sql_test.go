@@ -0,0 +1,20 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPsqlArgs(t *testing.T) {
+	defer func() { flagSQLTuplesOnly = false }()
+
+	args := strings.Join(psqlArgs("odoo-170"), " ")
+	if args != "exec -T db psql -U odoo -d odoo-170 -v ON_ERROR_STOP=1" {
+		t.Fatalf("psqlArgs() = %q", args)
+	}
+
+	flagSQLTuplesOnly = true
+	if args := strings.Join(psqlArgs("odoo-170"), " "); !strings.HasSuffix(args, "ON_ERROR_STOP=1 -t -A") {
+		t.Fatalf("psqlArgs(--tuples-only) = %q, want -t -A", args)
+	}
+}
@@ -2,38 +2,74 @@ package docker
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/mart337i/odooctl/internal/config"
 	"github.com/mart337i/odooctl/internal/docker"
 	"github.com/mart337i/odooctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
-var flagStatusJSON bool
+var (
+	flagStatusJSON     bool
+	flagStatusExitCode bool
+)
+
+// Exit codes for status --exit-code
+const (
+	statusExitHealthy   = 0
+	statusExitNoStack   = 2
+	statusExitUnhealthy = 3
+)
 
 type statusReport struct {
 	Project  string                `json:"project"`
+	Branch   string                `json:"branch"`
 	Version  string                `json:"version"`
 	Database string                `json:"database"`
+	Ports    config.Ports          `json:"ports"`
+	Running  bool                  `json:"running"`
 	Services []serviceStatusReport `json:"services"`
+	Debugpy  bool                  `json:"debugpy"`
 	URLs     map[string]string     `json:"urls,omitempty"`
+	// LastStartedAt is when 'docker run' last brought the containers up
+	LastStartedAt *time.Time `json:"last_started_at,omitempty"`
 }
 
 type serviceStatusReport struct {
 	Name   string `json:"name"`
 	State  string `json:"state"`
 	Status string `json:"status"`
+	Health string `json:"health,omitempty"`
 	Ports  string `json:"ports"`
 }
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show container status",
-	Long:  `Displays the status of all Docker containers for this project.`,
-	RunE:  runStatus,
+	Long: `Displays the status of all Docker containers for this project.
+
+With --json the services and the environment's name, version, database and
+ports are printed as one JSON object; "running" is true when any container is
+running.
+
+With --exit-code the exit status reflects the stack health, so status can be
+used as a gate in scripts:
+  0  every service is running (and healthy, where a healthcheck exists)
+  1  odooctl error (no environment, Docker unavailable, ...)
+  2  no containers exist for this environment
+  3  at least one service is stopped, missing, or unhealthy
+
+Example:
+  odooctl docker status --exit-code && odooctl docker test -m my_module
+  odooctl docker status --json | jq -e 'all(.services[]; .state == "running")'`,
+	RunE: runStatus,
 }
 
 func init() {
 	statusCmd.Flags().BoolVar(&flagStatusJSON, "json", false, "Print JSON output")
+	statusCmd.Flags().BoolVar(&flagStatusExitCode, "exit-code", false, "Exit non-zero unless all services are running and healthy")
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
@@ -46,20 +82,102 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		urls := make(map[string]string)
-		serviceReports := make([]serviceStatusReport, 0, len(services))
-		for _, svc := range services {
-			serviceReports = append(serviceReports, serviceStatusReport{Name: svc.Name, State: svc.State, Status: svc.Status, Ports: svc.Ports})
-			if svc.State == "running" && svc.Name == "odoo" {
-				urls["odoo"] = fmt.Sprintf("http://localhost:%d", state.Ports.Odoo)
-				urls["debug"] = fmt.Sprintf("localhost:%d", state.Ports.Debug)
-			}
-			if svc.State == "running" && svc.Name == "mailhog" {
-				urls["mailhog"] = fmt.Sprintf("http://localhost:%d", state.Ports.Mailhog)
+		if err := output.PrintJSON(buildStatusReport(state, services)); err != nil {
+			return err
+		}
+		return statusExitCheck(state, services)
+	}
+
+	if err := docker.PrintStatus(state); err != nil {
+		return err
+	}
+	if !flagStatusExitCode {
+		return nil
+	}
+	services, err := docker.GetServicesStatus(state)
+	if err != nil {
+		return err
+	}
+	return statusExitCheck(state, services)
+}
+
+func buildStatusReport(state *config.State, services []docker.ServiceInfo) statusReport {
+	report := statusReport{
+		Project:  state.ProjectName,
+		Branch:   state.Branch,
+		Version:  state.OdooVersion,
+		Database: state.DBName(),
+		Ports:    state.Ports,
+		Services: make([]serviceStatusReport, 0, len(services)),
+		Debugpy:  state.DebugpyEnabled,
+		URLs:     make(map[string]string),
+
+		LastStartedAt: state.LastStartedAt,
+	}
+	for _, svc := range services {
+		report.Services = append(report.Services, serviceStatusReport{Name: svc.Name, State: svc.State, Status: svc.Status, Health: svc.Health, Ports: svc.Ports})
+		if svc.State != "running" {
+			continue
+		}
+		report.Running = true
+		switch svc.Name {
+		case "odoo":
+			report.URLs["odoo"] = fmt.Sprintf("http://localhost:%d", state.Ports.Odoo)
+			if state.DebugpyEnabled {
+				report.URLs["debug"] = fmt.Sprintf("localhost:%d", state.Ports.Debug)
 			}
+		case "mailhog":
+			report.URLs["mailhog"] = fmt.Sprintf("http://localhost:%d", state.Ports.Mailhog)
+		}
+	}
+	return report
+}
+
+func statusExitCheck(state *config.State, services []docker.ServiceInfo) error {
+	if !flagStatusExitCode {
+		return nil
+	}
+	expected, err := docker.ServiceNames(state)
+	if err != nil {
+		return err
+	}
+	code, problems := evaluateStackHealth(expected, services)
+	if code == statusExitHealthy {
+		return nil
+	}
+	return &output.ExitCodeError{Code: code, Message: "stack is not healthy: " + strings.Join(problems, ", ")}
+}
+
+// evaluateStackHealth compares running services against the expected ones
+// and returns the status exit code plus a description of each problem.
+func evaluateStackHealth(expected []string, services []docker.ServiceInfo) (int, []string) {
+	if len(services) == 0 {
+		return statusExitNoStack, []string{"no containers found"}
+	}
+	byName := make(map[string]docker.ServiceInfo, len(services))
+	for _, svc := range services {
+		byName[svc.Name] = svc
+	}
+	if len(expected) == 0 {
+		for _, svc := range services {
+			expected = append(expected, svc.Name)
 		}
-		return output.PrintJSON(statusReport{Project: state.ProjectName, Version: state.OdooVersion, Database: state.DBName(), Services: serviceReports, URLs: urls})
 	}
 
-	return docker.PrintStatus(state)
+	var problems []string
+	for _, name := range expected {
+		svc, ok := byName[name]
+		switch {
+		case !ok:
+			problems = append(problems, name+" missing")
+		case svc.State != "running":
+			problems = append(problems, fmt.Sprintf("%s %s", name, svc.State))
+		case svc.Health != "" && svc.Health != "healthy":
+			problems = append(problems, fmt.Sprintf("%s %s", name, svc.Health))
+		}
+	}
+	if len(problems) > 0 {
+		return statusExitUnhealthy, problems
+	}
+	return statusExitHealthy, nil
 }
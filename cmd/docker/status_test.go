@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+)
+
+func TestEvaluateStackHealth(t *testing.T) {
+	expected := []string{"db", "odoo", "mailhog"}
+
+	code, problems := evaluateStackHealth(expected, nil)
+	if code != statusExitNoStack {
+		t.Fatalf("evaluateStackHealth(no containers) code = %d, want %d", code, statusExitNoStack)
+	}
+
+	healthy := []docker.ServiceInfo{
+		{Name: "db", State: "running", Health: "healthy"},
+		{Name: "odoo", State: "running"},
+		{Name: "mailhog", State: "running"},
+	}
+	code, problems = evaluateStackHealth(expected, healthy)
+	if code != statusExitHealthy || len(problems) != 0 {
+		t.Fatalf("evaluateStackHealth(healthy) = %d, %v, want %d, none", code, problems, statusExitHealthy)
+	}
+
+	degraded := []docker.ServiceInfo{
+		{Name: "db", State: "running", Health: "unhealthy"},
+		{Name: "odoo", State: "exited"},
+	}
+	code, problems = evaluateStackHealth(expected, degraded)
+	want := []string{"db unhealthy", "odoo exited", "mailhog missing"}
+	if code != statusExitUnhealthy || !reflect.DeepEqual(problems, want) {
+		t.Fatalf("evaluateStackHealth(degraded) = %d, %v, want %d, %v", code, problems, statusExitUnhealthy, want)
+	}
+}
+
+func TestBuildStatusReport(t *testing.T) {
+	state := &config.State{ProjectName: "shop", Branch: "main", OdooVersion: "17.0", Ports: config.CalculatePorts("17.0")}
+	services := []docker.ServiceInfo{
+		{Name: "db", State: "running", Health: "healthy"},
+		{Name: "odoo", State: "running"},
+		{Name: "mailhog", State: "exited"},
+	}
+
+	report := buildStatusReport(state, services)
+	if !report.Running || report.Database != "odoo-170" || report.Ports.Odoo != 9700 || len(report.Services) != 3 {
+		t.Errorf("report = %+v", report)
+	}
+	if report.URLs["odoo"] != "http://localhost:9700" || report.URLs["mailhog"] != "" {
+		t.Errorf("URLs = %v, want only odoo", report.URLs)
+	}
+	if stopped := buildStatusReport(state, nil); stopped.Running || stopped.Services == nil {
+		t.Errorf("report without containers = %+v, want running false and an empty service list", stopped)
+	}
+	if data, _ := json.Marshal(report); strings.Contains(string(data), "last_started_at") {
+		t.Errorf("report of a never-started environment has last_started_at: %s", data)
+	}
+
+	startedAt := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	state.LastStartedAt = &startedAt
+	if started := buildStatusReport(state, services); started.LastStartedAt == nil || !started.LastStartedAt.Equal(startedAt) {
+		t.Errorf("LastStartedAt = %v, want %v", started.LastStartedAt, startedAt)
+	}
+}
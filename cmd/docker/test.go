@@ -2,10 +2,15 @@ package docker
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	internalbrowser "github.com/mart337i/odooctl/internal/browser"
+	"github.com/mart337i/odooctl/internal/config"
 	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/module"
 	"github.com/mart337i/odooctl/pkg/prompt"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +20,12 @@ var (
 	flagTestTags     string
 	flagTestLogLevel string
 	flagTestWeb      bool
+	flagTestAll      bool
+
+	flagTestCoverage       bool
+	flagTestCoverageOutput string
+	flagTestJUnit          string
+	flagTestOdooArgs       []string
 )
 
 var testCmd = &cobra.Command{
@@ -23,7 +34,13 @@ var testCmd = &cobra.Command{
 	SilenceUsage: true,
 	Long: `Run Odoo tests with advanced filtering.
 
+Without --modules or --test-tags, the local modules in the project root are
+tested. Use --all to run every installed module's tests instead.
+
 Examples:
+  # Test the project's local modules
+  odooctl docker test
+
   # Run only post_install tests
   odooctl docker test --modules your_module --test-tags post_install
 
@@ -52,7 +69,20 @@ Examples:
   odooctl docker test --web --test-tags /web
 
   # Run with verbose output
-  odooctl docker test --modules your_module --log-level=test:DEBUG`,
+  odooctl docker test --modules your_module --log-level=test:DEBUG
+
+  # Measure coverage of the tested modules and write coverage.xml
+  odooctl docker test --modules your_module --coverage
+
+  # Write a JUnit XML report for CI
+  odooctl docker test --modules your_module --junit test-results.xml
+
+  # Pass extra options to odoo-bin
+  odooctl docker test --modules your_module --odoo-arg=--limit-time-cpu=600
+
+--odoo-arg appends an option to odoo-bin, before --stop-after-init. Options
+odooctl sets itself (-c, -d, -i, --test-enable, --test-tags, --log-level,
+--stop-after-init) are rejected; use the flags above instead.`,
 	RunE: runTest,
 }
 
@@ -61,9 +91,17 @@ func init() {
 	testCmd.Flags().StringVar(&flagTestTags, "test-tags", "", "Test filter tags: [-][tag][/module][:class][.method]")
 	testCmd.Flags().StringVar(&flagTestLogLevel, "log-level", "", "Logging level (e.g., 'test:DEBUG', 'odoo.tests:DEBUG')")
 	testCmd.Flags().BoolVar(&flagTestWeb, "web", false, "Run browser readiness check first and default tags to /web")
+	testCmd.Flags().BoolVar(&flagTestAll, "all", false, "Run all tests instead of the project's local modules")
+	testCmd.Flags().BoolVar(&flagTestCoverage, "coverage", false, "Measure Python coverage of the tested modules")
+	testCmd.Flags().StringVar(&flagTestCoverageOutput, "coverage-output", "coverage.xml", "Host path for the coverage XML report, relative to the project root")
+	testCmd.Flags().StringVar(&flagTestJUnit, "junit", "", "Write a JUnit XML report of the test results to this path")
+	testCmd.Flags().StringArrayVar(&flagTestOdooArgs, "odoo-arg", nil, "Extra odoo-bin argument (can specify multiple times)")
 }
 
 func runTest(cmd *cobra.Command, args []string) error {
+	if err := validateOdooArgs(flagTestOdooArgs, "-i", "--init", "--test-enable", "--test-tags", "--log-level"); err != nil {
+		return err
+	}
 	state, err := loadState()
 	if err != nil {
 		return err
@@ -72,6 +110,10 @@ func runTest(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if flagTestAll && (flagTestModules != "" || flagTestTags != "") {
+		return fmt.Errorf("--all cannot be combined with --modules or --test-tags")
+	}
+
 	green := color.New(color.FgGreen).SprintFunc()
 	red := color.New(color.FgRed).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -86,12 +128,24 @@ func runTest(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s Browser runtime ready (%s)\n", cyan("🌐"), check.PlaywrightVersion)
 	}
 
+	// Default to the project's own modules
+	if flagTestModules == "" && flagTestTags == "" && !flagTestAll {
+		modules, err := module.FindModules(state.ProjectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to scan %s for modules: %w", state.ProjectRoot, err)
+		}
+		if len(modules) == 0 {
+			return fmt.Errorf("no modules found in %s; pass --modules, --test-tags or --all", state.ProjectRoot)
+		}
+		flagTestModules = strings.Join(modules, ",")
+		fmt.Printf("%s Auto-selected local modules: %s\n", cyan("ℹ"), flagTestModules)
+	}
+
 	// Build odoo-bin command
 	database := state.DBName()
 
 	testArgs := []string{
-		"run", "--rm", "odoo",
-		"odoo", "-c", "/etc/odoo/odoo.conf",
+		"-c", "/etc/odoo/odoo.conf",
 		"-d", database,
 		"--test-enable",
 	}
@@ -116,9 +170,8 @@ func runTest(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Warn if no modules or tags specified
-	if flagTestModules == "" && flagTestTags == "" {
-		fmt.Printf("%s No modules or test-tags specified. This will run ALL tests!\n", color.YellowString("⚠️"))
+	if flagTestAll {
+		fmt.Printf("%s --all runs the tests of every installed module. This can take a long time!\n", color.YellowString("⚠️"))
 		confirmed, err := prompt.Confirm("Continue?", false)
 		if err != nil || !confirmed {
 			fmt.Println("Test cancelled.")
@@ -126,14 +179,66 @@ func runTest(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	testArgs = append(testArgs, flagTestOdooArgs...)
 	testArgs = append(testArgs, "--stop-after-init")
 
+	composeArgs := []string{"run", "--rm", "odoo", "odoo"}
+	if flagTestCoverage {
+		if err := ensureCoveragePackage(state); err != nil {
+			return err
+		}
+		includes := coverageIncludePatterns(state, splitCSV(flagTestModules))
+		composeArgs = []string{"run", "--rm", "-e", "COVERAGE_FILE=" + coverageDataFile, "odoo"}
+		composeArgs = append(composeArgs, coverageRunCommand(includes, testArgs)...)
+		fmt.Printf("%s Coverage enabled\n", cyan("📊"))
+	} else {
+		composeArgs = append(composeArgs, testArgs...)
+	}
+
 	fmt.Println()
-	if err := docker.Compose(state, testArgs...); err != nil {
+	results, testErr := runTestCommand(state, composeArgs)
+	total, failed, errored := results.Counts()
+	fmt.Printf("\n%s Ran %d test(s): %d failed, %d error(s)\n", cyan("🧪"), total, failed, errored)
+
+	if flagTestJUnit != "" {
+		if err := results.WriteJUnit(flagTestJUnit); err != nil {
+			fmt.Printf("%s Failed to write JUnit report: %v\n", color.YellowString("⚠"), err)
+		} else {
+			fmt.Printf("%s JUnit report written to %s\n", cyan("📝"), flagTestJUnit)
+		}
+	}
+
+	if flagTestCoverage {
+		fmt.Println()
+		rate, err := exportCoverageReport(state, flagTestCoverageOutput)
+		if err != nil {
+			fmt.Printf("%s %v\n", color.YellowString("⚠"), err)
+		} else {
+			fmt.Printf("%s Coverage: %.1f%% (report written to %s)\n", cyan("📊"), rate*100, coverageOutputPath(state, flagTestCoverageOutput))
+		}
+	}
+
+	if testErr != nil {
 		fmt.Printf("\n%s Tests failed!\n", red("✗"))
-		return fmt.Errorf("tests failed: %w", err)
+		return fmt.Errorf("tests failed: %w", testErr)
 	}
 
 	fmt.Printf("\n%s Tests completed!\n", green("✓"))
 	return nil
 }
+
+// runTestCommand runs the tests, echoing their output while collecting the
+// results from it. Odoo logs to stderr, so both streams are read.
+func runTestCommand(state *config.State, composeArgs []string) (*testResults, error) {
+	results := newTestResults()
+	testCmd := docker.ComposeCommand(state, composeArgs...)
+	logWriter := &testLogWriter{results: results}
+	output := io.MultiWriter(os.Stdout, logWriter)
+	testCmd.Stdout = output
+	testCmd.Stderr = output
+	testCmd.Stdin = os.Stdin
+
+	err := testCmd.Run()
+	logWriter.Flush()
+	return results, err
+}
@@ -0,0 +1,197 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// "odoo.addons.sale.tests.test_sale: Starting TestSale.test_confirm ..."
+	testStartLine = regexp.MustCompile(`(odoo\.addons\.(\w+)\.\S+): Starting (\w+)\.(\w+) \.\.\.`)
+	// "odoo.addons.sale.tests.test_sale: FAIL: TestSale.test_confirm"
+	testFailLine = regexp.MustCompile(`(odoo\.addons\.(\w+)\.\S+): (FAIL|ERROR): (\w+)\.(\w+)`)
+	// "odoo.tests.result: 1 failed, 0 error(s) of 42 tests when loading database 'x'"
+	testResultLine = regexp.MustCompile(`odoo\.tests\.result: (\d+) failed, (\d+) error\(s\) of (\d+) tests`)
+)
+
+// testCase is one test method seen in the Odoo log
+type testCase struct {
+	Module  string
+	Class   string // dotted test module path and class name
+	Name    string
+	Outcome string // "", "FAIL" or "ERROR"
+	Message string // the traceback logged with a failure
+}
+
+// testResults collects test cases from an Odoo test run's log, line by line
+type testResults struct {
+	Cases   []*testCase
+	index   map[string]*testCase
+	current *testCase // failure whose traceback is being read
+
+	// Totals from odoo.tests.result, when Odoo logged them
+	Summarized bool
+	Failed     int
+	Errors     int
+	Total      int
+}
+
+func newTestResults() *testResults {
+	return &testResults{index: map[string]*testCase{}}
+}
+
+// Feed processes one log line
+func (r *testResults) Feed(line string) {
+	line = strings.TrimRight(line, "\r")
+	if parseOdooLogLine(line).IsRecord() {
+		r.current = nil
+	} else if r.current != nil {
+		r.current.Message += line + "\n"
+		return
+	}
+
+	if match := testStartLine.FindStringSubmatch(line); match != nil {
+		r.testCase(match[2], match[1]+"."+match[3], match[4])
+	} else if match := testFailLine.FindStringSubmatch(line); match != nil {
+		test := r.testCase(match[2], match[1]+"."+match[4], match[5])
+		test.Outcome = match[3]
+		r.current = test
+	} else if match := testResultLine.FindStringSubmatch(line); match != nil {
+		r.Summarized = true
+		r.Failed, _ = strconv.Atoi(match[1])
+		r.Errors, _ = strconv.Atoi(match[2])
+		r.Total, _ = strconv.Atoi(match[3])
+	}
+}
+
+func (r *testResults) testCase(module, class, name string) *testCase {
+	key := class + "." + name
+	if test, ok := r.index[key]; ok {
+		return test
+	}
+	test := &testCase{Module: module, Class: class, Name: name}
+	r.index[key] = test
+	r.Cases = append(r.Cases, test)
+	return test
+}
+
+// Counts returns the number of tests run, failed and errored, preferring
+// Odoo's own totals
+func (r *testResults) Counts() (total, failed, errors int) {
+	if r.Summarized {
+		return r.Total, r.Failed, r.Errors
+	}
+	for _, test := range r.Cases {
+		switch test.Outcome {
+		case "FAIL":
+			failed++
+		case "ERROR":
+			errors++
+		}
+	}
+	return len(r.Cases), failed, errors
+}
+
+// testLogWriter feeds everything written to it to testResults, a line at a
+// time
+type testLogWriter struct {
+	results *testResults
+	partial []byte
+}
+
+func (w *testLogWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.results.Feed(string(w.partial[:i]))
+		w.partial = w.partial[i+1:]
+	}
+}
+
+// Flush feeds an unterminated last line
+func (w *testLogWriter) Flush() {
+	if len(w.partial) > 0 {
+		w.results.Feed(string(w.partial))
+		w.partial = nil
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit renders the results as JUnit XML, one test suite per Odoo module
+func (r *testResults) JUnit() ([]byte, error) {
+	report := junitTestSuites{}
+	suites := map[string]int{}
+	for _, test := range r.Cases {
+		i, ok := suites[test.Module]
+		if !ok {
+			i = len(report.Suites)
+			suites[test.Module] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: test.Module})
+		}
+		suite := &report.Suites[i]
+		testCase := junitTestCase{ClassName: test.Class, Name: test.Name}
+		problem := &junitProblem{Message: test.Outcome + ": " + test.Name, Text: test.Message}
+		switch test.Outcome {
+		case "FAIL":
+			testCase.Failure = problem
+			suite.Failures++
+			report.Failures++
+		case "ERROR":
+			testCase.Error = problem
+			suite.Errors++
+			report.Errors++
+		}
+		suite.Tests++
+		report.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// WriteJUnit writes the JUnit XML report to path
+func (r *testResults) WriteJUnit(path string) error {
+	data, err := r.JUnit()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
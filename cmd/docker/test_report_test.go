@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testLog = `2026-10-16 09:00:00,001 1 INFO db odoo.addons.sale.tests.test_sale: Starting TestSale.test_confirm ...
+2026-10-16 09:00:00,002 1 INFO db odoo.addons.sale.tests.test_sale: Starting TestSale.test_cancel ...
+2026-10-16 09:00:00,003 1 ERROR db odoo.addons.sale.tests.test_sale: FAIL: TestSale.test_cancel
+Traceback (most recent call last):
+AssertionError: 1 != 2
+2026-10-16 09:00:00,004 1 INFO db odoo.addons.stock.tests.test_move: Starting TestMove.test_done ...
+2026-10-16 09:00:00,005 1 ERROR db odoo.addons.stock.tests.test_move: ERROR: TestMove.test_done
+Traceback (most recent call last):
+KeyError: 'x'
+2026-10-16 09:00:00,006 1 INFO db odoo.tests.stats: sale: 2 tests 0.10s 42 queries
+`
+
+func feedTestLog(log string) *testResults {
+	results := newTestResults()
+	writer := &testLogWriter{results: results}
+	// Write in uneven chunks, the way a pipe delivers output
+	for len(log) > 0 {
+		n := min(len(log), 37)
+		fmt.Fprint(writer, log[:n])
+		log = log[n:]
+	}
+	writer.Flush()
+	return results
+}
+
+func TestTestResults(t *testing.T) {
+	results := feedTestLog(testLog)
+	if total, failed, errored := results.Counts(); total != 3 || failed != 1 || errored != 1 {
+		t.Fatalf("Counts() = %d, %d, %d, want 3, 1, 1", total, failed, errored)
+	}
+	cancel := results.Cases[1]
+	if cancel.Class != "odoo.addons.sale.tests.test_sale.TestSale" || cancel.Name != "test_cancel" || cancel.Outcome != "FAIL" {
+		t.Errorf("Cases[1] = %+v", cancel)
+	}
+	if want := "Traceback (most recent call last):\nAssertionError: 1 != 2\n"; cancel.Message != want {
+		t.Errorf("Message = %q, want %q", cancel.Message, want)
+	}
+
+	results = feedTestLog(testLog + "2026-10-16 09:00:01,000 1 INFO db odoo.tests.result: 2 failed, 1 error(s) of 10 tests when loading database 'db'\r\n")
+	if total, failed, errored := results.Counts(); total != 10 || failed != 2 || errored != 1 {
+		t.Fatalf("Counts() with odoo.tests.result = %d, %d, %d, want 10, 2, 1", total, failed, errored)
+	}
+}
+
+func TestTestResultsJUnit(t *testing.T) {
+	data, err := feedTestLog(testLog).JUnit()
+	if err != nil {
+		t.Fatalf("JUnit() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), xml.Header) {
+		t.Errorf("JUnit() is missing the XML header")
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("JUnit() produced invalid XML: %v", err)
+	}
+	if report.Tests != 3 || report.Failures != 1 || report.Errors != 1 || len(report.Suites) != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	sale := report.Suites[0]
+	if sale.Name != "sale" || sale.Tests != 2 || sale.Failures != 1 || sale.Cases[0].Failure != nil {
+		t.Errorf("sale suite = %+v", sale)
+	}
+	if failure := sale.Cases[1].Failure; failure == nil || !strings.Contains(failure.Text, "AssertionError") {
+		t.Errorf("test_cancel failure = %+v", failure)
+	}
+	if problem := report.Suites[1].Cases[0].Error; problem == nil || !strings.Contains(problem.Text, "KeyError") {
+		t.Errorf("test_done error = %+v", problem)
+	}
+}
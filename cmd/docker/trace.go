@@ -0,0 +1,261 @@
+package docker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagTraceFollow bool
+	flagTraceTail   int
+	flagTraceSince  string
+	flagTracePath   string
+	flagTraceSlow   time.Duration
+)
+
+var traceCmd = &cobra.Command{
+	Use:          "trace",
+	Short:        "Show Odoo logs grouped by HTTP request",
+	SilenceUsage: true,
+	Long: `Reads the odoo service logs and highlights every HTTP request Odoo logs
+through werkzeug, with its status, SQL query count and timings:
+
+  10:00:01  GET  /web/dataset/call_kw/sale.order/web_read  200  87 queries  0.041s sql  0.312s total
+
+Odoo logs a request once it has been served, so the lines logged by the same
+process since its previous request are shown under it. With --path or --slow
+only the matching requests and their lines are printed. In threaded mode
+(workers = 0) concurrent requests share a process, so keep other tabs quiet
+while tracing.
+
+Examples:
+  odooctl docker trace -f
+  odooctl docker trace -f --path /shop
+  odooctl docker trace -f --slow 500ms
+  odooctl docker trace --since 10m --slow 2s`,
+	Args: cobra.NoArgs,
+	RunE: runTrace,
+}
+
+func init() {
+	traceCmd.Flags().BoolVarP(&flagTraceFollow, "follow", "f", false, "Follow log output")
+	traceCmd.Flags().IntVar(&flagTraceTail, "tail", 200, "Number of lines to read from the end of the logs")
+	traceCmd.Flags().StringVar(&flagTraceSince, "since", "", "Read logs since a duration or timestamp, passed to docker compose logs")
+	traceCmd.Flags().StringVar(&flagTracePath, "path", "", "Only show requests whose path contains this text")
+	traceCmd.Flags().DurationVar(&flagTraceSlow, "slow", 0, "Only show requests that took at least this long (e.g. 500ms)")
+}
+
+func runTrace(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	if reason := werkzeugSilenced(state.ExtraConfOptions); reason != "" {
+		fmt.Fprintf(os.Stderr, "%s HTTP requests are not logged: %s. Enable them with 'odooctl docker reconfigure --conf log_handler=:INFO,werkzeug:INFO'\n", color.YellowString("⚠️"), reason)
+	}
+
+	logArgs := []string{"logs", "--no-log-prefix"}
+	if flagTraceFollow {
+		logArgs = append(logArgs, "-f")
+	}
+	if flagTraceTail > 0 {
+		logArgs = append(logArgs, "--tail", strconv.Itoa(flagTraceTail))
+	}
+	if flagTraceSince != "" {
+		logArgs = append(logArgs, "--since", flagTraceSince)
+	}
+	logArgs = append(logArgs, "odoo")
+
+	logCmd := docker.ComposeCommand(state, logArgs...)
+	logCmd.Stderr = os.Stderr
+	stdout, err := logCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := logCmd.Start(); err != nil {
+		return fmt.Errorf("failed to read logs: %w", err)
+	}
+
+	tracer := newRequestTracer(os.Stdout, flagTracePath, flagTraceSlow)
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		tracer.Line(scanner.Text())
+	}
+	return logCmd.Wait()
+}
+
+// werkzeugSilenced explains why extra odoo.conf options keep werkzeug from
+// logging requests at INFO, or returns "" when requests are logged
+func werkzeugSilenced(options map[string]string) string {
+	// Later entries win, as in Odoo: log_level's presets, then log_handler
+	var handlers []string
+	switch strings.ToLower(options["log_level"]) {
+	case "warn":
+		handlers = append(handlers, ":WARNING", "werkzeug:WARNING")
+	case "error":
+		handlers = append(handlers, ":ERROR", "werkzeug:ERROR")
+	case "critical":
+		handlers = append(handlers, ":CRITICAL", "werkzeug:CRITICAL")
+	}
+	if value, ok := options["log_handler"]; ok {
+		handlers = append(handlers, strings.Split(value, ",")...)
+	}
+
+	level, source := "INFO", ""
+	for _, handler := range handlers {
+		logger, handlerLevel, ok := strings.Cut(strings.TrimSpace(handler), ":")
+		if !ok || (logger != "" && logger != "werkzeug") {
+			continue
+		}
+		if logger == "" && source == "werkzeug" {
+			continue // a werkzeug entry overrides the root logger
+		}
+		level, source = strings.ToUpper(handlerLevel), logger
+	}
+	if level == "DEBUG" || level == "INFO" || level == "NOTSET" {
+		return ""
+	}
+	if source == "werkzeug" {
+		return "werkzeug logs at " + level
+	}
+	return "the log level is " + level
+}
+
+// werkzeugRequest is a request line Odoo logs through werkzeug:
+// `... werkzeug: 172.18.0.1 - - [01/May/2024 10:00:01] "GET /web HTTP/1.1" 200 - 87 0.041 0.271`
+// The last three numbers (query count, SQL time, remaining time) are absent
+// on some versions and for static files.
+type werkzeugRequest struct {
+	Time      string
+	Method    string
+	Path      string
+	Status    int
+	Queries   int
+	SQLTime   time.Duration
+	TotalTime time.Duration
+	HasPerf   bool
+}
+
+var werkzeugLogRecord = regexp.MustCompile(` werkzeug: .*"([A-Z]+) (\S+) [^"]*" (\d{3}) \S+(?: (\d+) ([\d.]+) ([\d.]+))?\s*$`)
+
+func parseWerkzeugRequest(line string) (werkzeugRequest, bool) {
+	header := parseOdooLogLine(line)
+	match := werkzeugLogRecord.FindStringSubmatch(line)
+	if !header.IsRecord() || match == nil {
+		return werkzeugRequest{}, false
+	}
+	request := werkzeugRequest{Time: header.Time, Method: match[1], Path: match[2]}
+	request.Status, _ = strconv.Atoi(match[3])
+	if match[4] != "" {
+		request.HasPerf = true
+		request.Queries, _ = strconv.Atoi(match[4])
+		sql, _ := strconv.ParseFloat(match[5], 64)
+		remaining, _ := strconv.ParseFloat(match[6], 64)
+		request.SQLTime = secondsDuration(sql)
+		request.TotalTime = secondsDuration(sql + remaining)
+	}
+	return request, true
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// maxTraceLines bounds the lines kept per process while waiting for the
+// request they belong to
+const maxTraceLines = 500
+
+// requestTracer prints request lines as summaries. With a filter, it holds
+// each process's lines until its next request line and prints them only if
+// that request matches.
+type requestTracer struct {
+	out     io.Writer
+	path    string
+	slow    time.Duration
+	pending map[string][]string
+	lastPID string
+}
+
+func newRequestTracer(out io.Writer, path string, slow time.Duration) *requestTracer {
+	return &requestTracer{out: out, path: path, slow: slow, pending: make(map[string][]string)}
+}
+
+func (t *requestTracer) filtering() bool {
+	return t.path != "" || t.slow > 0
+}
+
+// Line handles one log line
+func (t *requestTracer) Line(line string) {
+	pid := t.lastPID
+	if header := parseOdooLogLine(line); header.IsRecord() {
+		pid = header.PID
+		t.lastPID = pid
+	}
+
+	request, isRequest := parseWerkzeugRequest(line)
+	if !isRequest {
+		if !t.filtering() {
+			fmt.Fprintln(t.out, line)
+			return
+		}
+		lines := append(t.pending[pid], line)
+		if len(lines) > maxTraceLines {
+			lines = lines[len(lines)-maxTraceLines:]
+		}
+		t.pending[pid] = lines
+		return
+	}
+
+	lines := t.pending[pid]
+	delete(t.pending, pid)
+	if !t.matches(request) {
+		return
+	}
+	if t.filtering() {
+		fmt.Fprintln(t.out)
+		fmt.Fprintln(t.out, formatWerkzeugRequest(request))
+		for _, held := range lines {
+			fmt.Fprintf(t.out, "    %s\n", held)
+		}
+		return
+	}
+	fmt.Fprintln(t.out, formatWerkzeugRequest(request))
+}
+
+func (t *requestTracer) matches(request werkzeugRequest) bool {
+	if t.path != "" && !strings.Contains(request.Path, t.path) {
+		return false
+	}
+	if t.slow > 0 && (!request.HasPerf || request.TotalTime < t.slow) {
+		return false
+	}
+	return true
+}
+
+func formatWerkzeugRequest(request werkzeugRequest) string {
+	status := strconv.Itoa(request.Status)
+	switch {
+	case request.Status >= 500:
+		status = color.RedString(status)
+	case request.Status >= 400:
+		status = color.YellowString(status)
+	default:
+		status = color.GreenString(status)
+	}
+	line := fmt.Sprintf("%s  %s  %s  %s", request.Time, color.New(color.Bold).Sprint(request.Method), color.CyanString(request.Path), status)
+	if request.HasPerf {
+		line += fmt.Sprintf("  %d queries  %.3fs sql  %.3fs total", request.Queries, request.SQLTime.Seconds(), request.TotalTime.Seconds())
+	}
+	return line
+}
@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWerkzeugRequest(t *testing.T) {
+	line := `2024-05-01 10:00:01,456 7 INFO shop werkzeug: 172.18.0.1 - - [01/May/2024 10:00:01] "POST /web/dataset/call_kw/sale.order/web_read HTTP/1.1" 200 - 87 0.041 0.271`
+	request, ok := parseWerkzeugRequest(line)
+	if !ok {
+		t.Fatal("expected a request line")
+	}
+	if request.Time != "10:00:01" || request.Method != "POST" || request.Path != "/web/dataset/call_kw/sale.order/web_read" || request.Status != 200 {
+		t.Fatalf("parseWerkzeugRequest() = %+v", request)
+	}
+	if !request.HasPerf || request.Queries != 87 || request.SQLTime != 41*time.Millisecond || request.TotalTime != 312*time.Millisecond {
+		t.Fatalf("parseWerkzeugRequest() perf = %+v", request)
+	}
+
+	static := `2024-05-01 10:00:02,000 7 INFO ? werkzeug: 172.18.0.1 - - [01/May/2024 10:00:02] "GET /web/static/img/logo.png HTTP/1.1" 304 -`
+	if request, ok := parseWerkzeugRequest(static); !ok || request.HasPerf || request.Status != 304 {
+		t.Fatalf("parseWerkzeugRequest(static) = %+v, %v", request, ok)
+	}
+	if _, ok := parseWerkzeugRequest("2024-05-01 10:00:00,123 7 INFO shop odoo.modules.loading: loading 42 modules..."); ok {
+		t.Fatal("expected a non-request line to be rejected")
+	}
+}
+
+func TestRequestTracerGroupsLinesUnderSlowRequests(t *testing.T) {
+	var out bytes.Buffer
+	tracer := newRequestTracer(&out, "", time.Second)
+	for _, line := range []string{
+		`2024-05-01 10:00:00,100 7 INFO shop odoo.addons.shop: computing prices`,
+		`2024-05-01 10:00:01,456 7 INFO shop werkzeug: 172.18.0.1 - - [01/May/2024 10:00:01] "GET /shop HTTP/1.1" 200 - 950 0.900 0.600`,
+		`2024-05-01 10:00:02,000 7 INFO shop odoo.addons.shop: fast path`,
+		`2024-05-01 10:00:02,100 7 INFO shop werkzeug: 172.18.0.1 - - [01/May/2024 10:00:02] "GET /contactus HTTP/1.1" 200 - 12 0.010 0.020`,
+	} {
+		tracer.Line(line)
+	}
+	text := out.String()
+	for _, want := range []string{"/shop", "950 queries", "1.500s total", "    2024-05-01 10:00:00,100 7 INFO shop odoo.addons.shop: computing prices"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("trace output missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "/contactus") || strings.Contains(text, "fast path") {
+		t.Fatalf("fast request should be filtered out:\n%s", text)
+	}
+}
+
+func TestWerkzeugSilenced(t *testing.T) {
+	cases := []struct {
+		name    string
+		options map[string]string
+		silent  bool
+	}{
+		{"default", nil, false},
+		{"warn level", map[string]string{"log_level": "warn"}, true},
+		{"werkzeug re-enabled", map[string]string{"log_level": "warn", "log_handler": ":WARNING,werkzeug:INFO"}, false},
+		{"werkzeug silenced", map[string]string{"log_handler": ":INFO,werkzeug:WARNING"}, true},
+		{"root after werkzeug", map[string]string{"log_handler": "werkzeug:INFO,:ERROR"}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := werkzeugSilenced(tc.options) != ""; got != tc.silent {
+				t.Fatalf("werkzeugSilenced(%v) silent = %v, want %v", tc.options, got, tc.silent)
+			}
+		})
+	}
+}
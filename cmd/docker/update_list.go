@@ -0,0 +1,83 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+var updateListCmd = &cobra.Command{
+	Use:          "update-list",
+	Short:        "Refresh Odoo's apps list without updating modules",
+	SilenceUsage: true,
+	Long: `Refreshes the apps list, like Apps → Update Apps List in the UI, so modules
+in newly added addons paths can be installed. No module is upgraded.
+
+The Odoo container is stopped while the registry is scanned in a one-off
+container and started again afterwards, as with 'odooctl docker install'.
+
+Examples:
+  odooctl docker reconfigure --addons-path ../extra-addons
+  odooctl docker update-list`,
+	Args: cobra.NoArgs,
+	RunE: runUpdateList,
+}
+
+// updateListScript refreshes ir.module.module from the addons paths.
+// update_list returns the number of updated and added modules.
+const updateListScript = `updated, added = env['ir.module.module'].update_list()
+env.cr.commit()
+print('Apps list updated: %d updated, %d added' % (updated, added))
+`
+
+func runUpdateList(cmd *cobra.Command, args []string) error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	if err := ensureDockerProjectAccess(state); err != nil {
+		return err
+	}
+
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Println("Stopping Odoo container...")
+	if err := docker.Compose(state, "stop", "odoo"); err != nil {
+		fmt.Printf("%s Warning: failed to stop odoo container: %v\n", yellow("!"), err)
+	}
+
+	fmt.Println("Updating apps list...")
+	updateErr := runUpdateListScript(state)
+
+	// Always restart the odoo container, even if the update failed
+	fmt.Println("Restarting Odoo container...")
+	if err := docker.Compose(state, "up", "-d", "odoo"); err != nil {
+		fmt.Printf("%s Warning: failed to restart odoo container: %v\n", yellow("!"), err)
+		if updateErr == nil {
+			return fmt.Errorf("apps list updated but failed to restart container: %w", err)
+		}
+	}
+	if updateErr != nil {
+		return updateErr
+	}
+
+	fmt.Printf("\n%s Apps list updated\n", color.GreenString("✓"))
+	return nil
+}
+
+func runUpdateListScript(state *config.State) error {
+	shellCmd := docker.ComposeCommand(state, "run", "--rm", "-T", "odoo",
+		"odoo", "shell", "-c", "/etc/odoo/odoo.conf", "-d", state.DBName(), "--log-level=warn")
+	shellCmd.Stdin = strings.NewReader(updateListScript)
+	shellCmd.Stdout = os.Stdout
+	shellCmd.Stderr = os.Stderr
+	if err := shellCmd.Run(); err != nil {
+		return fmt.Errorf("failed to update apps list: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/mart337i/odooctl/internal/config"
 	modlib "github.com/mart337i/odooctl/internal/module"
@@ -41,14 +42,14 @@ func runChanged(cmd *cobra.Command, args []string) error {
 	}
 	stored, _ := loadModuleHashes(state)
 	var newModules, changedModules []string
-	for _, name := range modules {
-		hash, err := modlib.Hash(filepath.Join(state.ProjectRoot, name))
-		if err != nil {
-			return err
+	for _, result := range modlib.HashModules(state.ProjectRoot, modules, runtime.NumCPU()) {
+		if result.Err != nil {
+			return result.Err
 		}
+		name := result.Module
 		if stored[name] == "" {
 			newModules = append(newModules, name)
-		} else if stored[name] != hash {
+		} else if stored[name] != result.Hash {
 			changedModules = append(changedModules, name)
 		}
 	}
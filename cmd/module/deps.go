@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mart337i/odooctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
-var flagDepsJSON bool
+var (
+	flagDepsJSON   bool
+	flagDepsOutput string
+)
 
 var depsCmd = &cobra.Command{
 	Use:   "deps [modules...]",
@@ -18,9 +22,14 @@ var depsCmd = &cobra.Command{
 
 func init() {
 	depsCmd.Flags().BoolVar(&flagDepsJSON, "json", false, "Print JSON output")
+	depsCmd.Flags().StringVarP(&flagDepsOutput, "output", "o", "table", output.FormatFlagUsage)
 }
 
 func runDeps(cmd *cobra.Command, args []string) error {
+	format, err := output.ResolveFormat(flagDepsOutput, flagDepsJSON)
+	if err != nil {
+		return err
+	}
 	dirs, _, err := moduleScanDirs()
 	if err != nil {
 		return err
@@ -29,19 +38,19 @@ func runDeps(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if flagDepsJSON {
-		return printJSON(manifests)
-	}
-	if len(manifests) == 0 {
+	if format == output.FormatTable && len(manifests) == 0 {
 		fmt.Println("No matching Odoo modules found")
 		return nil
 	}
+	table := output.Table{Headers: []string{"module", "depends", "python"}}
 	for _, manifest := range manifests {
-		fmt.Println(manifest.Module)
-		fmt.Printf("  depends: %s\n", joinOrDash(manifest.Depends))
-		fmt.Printf("  python:  %s\n", joinOrDash(manifest.ExternalPython))
+		depends, python := strings.Join(manifest.Depends, ","), strings.Join(manifest.ExternalPython, ",")
+		if format == output.FormatTable {
+			depends, python = joinOrDash(manifest.Depends), joinOrDash(manifest.ExternalPython)
+		}
+		table.Rows = append(table.Rows, []string{manifest.Module, depends, python})
 	}
-	return nil
+	return output.Print(format, table, manifests)
 }
 
 func joinOrDash(values []string) string {
@@ -0,0 +1,140 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	modlib "github.com/mart337i/odooctl/internal/module"
+	"github.com/mart337i/odooctl/internal/odoo"
+	"github.com/mart337i/odooctl/internal/project"
+	"github.com/mart337i/odooctl/internal/scaffold"
+	"github.com/mart337i/odooctl/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagCIProvider    string
+	flagCIOutput      string
+	flagCIOdooVersion string
+	flagCIModules     string
+	flagCIEnterprise  bool
+	flagCIForce       bool
+)
+
+var generateCICmd = &cobra.Command{
+	Use:          "generate-ci",
+	Short:        "Generate a CI pipeline that tests the project's modules",
+	SilenceUsage: true,
+	Long: `Generates a CI pipeline that installs odooctl, creates an environment for the
+project's Odoo version, initializes the database, installs the modules and
+runs their tests:
+
+  odooctl --no-interaction docker create --odoo-version <version> --modules <modules>
+  odooctl docker run --init --no-prompt
+  odooctl docker install all
+  odooctl docker test --modules <modules>
+
+The Odoo version and Enterprise flag come from the current environment, or the
+branch name / .odooversion when there is none. Modules default to the modules
+in the project root. The pipeline is printed, or written with --output (use
+--output default for the provider's usual path).
+
+Enterprise pipelines read a token with access to odoo/enterprise from the
+ODOO_ENTERPRISE_TOKEN secret (GitHub) or CI/CD variable (GitLab).
+
+Examples:
+  odooctl module generate-ci
+  odooctl module generate-ci --output .github/workflows/odoo.yml
+  odooctl module generate-ci --provider gitlab --output default
+  odooctl module generate-ci -v 17.0 -m my_module,my_other_module`,
+	Args: cobra.NoArgs,
+	RunE: runGenerateCI,
+}
+
+func init() {
+	generateCICmd.Flags().StringVar(&flagCIProvider, "provider", "github", "CI provider ("+strings.Join(scaffold.CIProviders, ", ")+")")
+	generateCICmd.Flags().StringVarP(&flagCIOutput, "output", "o", "", "Write the pipeline to this file ('default' for the provider's usual path)")
+	generateCICmd.Flags().StringVarP(&flagCIOdooVersion, "odoo-version", "v", "", "Odoo version ("+odoo.VersionsString()+")")
+	_ = generateCICmd.RegisterFlagCompletionFunc("odoo-version", cobra.FixedCompletions(odoo.OdooVersions, cobra.ShellCompDirectiveNoFileComp))
+	generateCICmd.Flags().StringVarP(&flagCIModules, "modules", "m", "", "Modules to install and test (comma-separated, default: modules in the project root)")
+	generateCICmd.Flags().BoolVarP(&flagCIEnterprise, "enterprise", "e", false, "Build the environment with Odoo Enterprise")
+	generateCICmd.Flags().BoolVar(&flagCIForce, "force", false, "Overwrite an existing --output file")
+}
+
+func runGenerateCI(cmd *cobra.Command, args []string) error {
+	_, state, err := moduleScanDirs()
+	if err != nil {
+		return err
+	}
+	ctx := project.Detect(".")
+
+	ci := scaffold.CIConfig{
+		Provider:    flagCIProvider,
+		OdooVersion: flagCIOdooVersion,
+		Enterprise:  flagCIEnterprise,
+	}
+	root := ctx.Root
+	if state != nil {
+		root = state.ProjectRoot
+		if ci.OdooVersion == "" {
+			ci.OdooVersion = state.OdooVersion
+		}
+		if !cmd.Flags().Changed("enterprise") {
+			ci.Enterprise = state.Enterprise
+		}
+	}
+	if ci.OdooVersion == "" {
+		ci.OdooVersion = strings.TrimSpace(ctx.OdooVersion)
+	}
+	if ci.OdooVersion == "" {
+		if ci.OdooVersion, err = prompt.SelectVersion(); err != nil {
+			return err
+		}
+	}
+
+	if flagCIModules != "" {
+		for _, module := range strings.Split(flagCIModules, ",") {
+			if module = strings.TrimSpace(module); module != "" {
+				ci.Modules = append(ci.Modules, module)
+			}
+		}
+	} else {
+		if ci.Modules, err = modlib.FindModules(root); err != nil {
+			return err
+		}
+	}
+
+	text, err := scaffold.RenderCI(ci)
+	if err != nil {
+		return err
+	}
+	if len(ci.Modules) == 0 {
+		fmt.Fprintf(os.Stderr, "%s No modules found in %s; the pipeline tests whatever 'docker test' picks by default\n", color.YellowString("⚠️"), root)
+	}
+	if flagCIOutput == "" {
+		fmt.Print(text)
+		return nil
+	}
+
+	path := flagCIOutput
+	if path == "default" {
+		path = filepath.Join(root, scaffold.DefaultCIPath(ci.Provider))
+	}
+	if _, err := os.Stat(path); err == nil && !flagCIForce {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	fmt.Printf("%s %s pipeline for Odoo %s written to %s\n", color.GreenString("✓"), ci.Provider, ci.OdooVersion, color.CyanString(path))
+	if ci.Enterprise {
+		fmt.Printf("  Add an %s secret with read access to github.com/odoo/enterprise\n", color.CyanString("ODOO_ENTERPRISE_TOKEN"))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package module
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,6 +21,10 @@ func loadModuleState() (*config.State, error) {
 	}
 	state, err := config.LoadFromDir(cwd)
 	if err != nil {
+		var stale *config.StaleProjectLinkError
+		if errors.As(err, &stale) {
+			return nil, fmt.Errorf("no Docker environment found (%v). Run 'odooctl docker create' first", err)
+		}
 		return nil, fmt.Errorf("no Docker environment found. Run 'odooctl docker create' first")
 	}
 	return state, nil
@@ -0,0 +1,108 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+// i18nExportDir is written by the odoo container and copied out from there
+const i18nExportDir = "/var/lib/odoo/filestore"
+
+var (
+	flagI18nLang   string
+	flagI18nOutput string
+)
+
+var i18nExportCmd = &cobra.Command{
+	Use:   "i18n-export <module>",
+	Short: "Export a module's translation template from the running environment",
+	Long: `Export the terms of an installed module with odoo-bin --i18n-export, run in
+the environment's odoo container.
+
+Without --lang the template is written to <module>/i18n/<module>.pot; with
+--lang the terms and their current translations go to <module>/i18n/<lang>.po.
+
+Examples:
+  odooctl module i18n-export my_module
+  odooctl module i18n-export my_module --lang fr
+  odooctl module i18n-export sale --output ./sale.pot`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runI18nExport,
+}
+
+func init() {
+	i18nExportCmd.Flags().StringVarP(&flagI18nLang, "lang", "l", "", "Export translations for this installed language (e.g. fr) as a .po file")
+	i18nExportCmd.Flags().StringVarP(&flagI18nOutput, "output", "o", "", "Host path of the exported file (default: the module's i18n directory)")
+}
+
+func runI18nExport(cmd *cobra.Command, args []string) error {
+	moduleName := args[0]
+	state, err := loadModuleState()
+	if err != nil {
+		return err
+	}
+	if err := docker.CheckDaemon(); err != nil {
+		return err
+	}
+
+	outputPath, err := i18nOutputPath(state, moduleName, flagI18nLang, flagI18nOutput)
+	if err != nil {
+		return err
+	}
+	if !docker.IsRunning(state) {
+		return fmt.Errorf("containers are not running. Start them with 'odooctl docker run'")
+	}
+
+	containerFile := i18nExportDir + "/.odooctl-i18n-" + filepath.Base(outputPath)
+	exportArgs := []string{
+		"exec", "-T", "odoo",
+		"odoo", "-c", "/etc/odoo/odoo.conf",
+		"-d", state.DBName(),
+		"--i18n-export=" + containerFile,
+		"--modules=" + moduleName,
+	}
+	if flagI18nLang != "" {
+		exportArgs = append(exportArgs, "--language="+flagI18nLang)
+	}
+	exportArgs = append(exportArgs, "--stop-after-init")
+
+	fmt.Printf("%s Exporting terms of %s...\n", color.CyanString("🌐"), moduleName)
+	if err := docker.Compose(state, exportArgs...); err != nil {
+		return fmt.Errorf("i18n export failed (is %s installed?): %w", moduleName, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return err
+	}
+	if text, err := docker.ComposeOutput(state, "cp", "odoo:"+containerFile, outputPath); err != nil {
+		return fmt.Errorf("failed to copy the export from the odoo container: %s", strings.TrimSpace(text))
+	}
+	_, _ = docker.ComposeOutput(state, "exec", "-T", "odoo", "rm", "-f", containerFile)
+
+	fmt.Printf("%s Written to %s\n", color.GreenString("✓"), outputPath)
+	return nil
+}
+
+// i18nOutputPath picks the exported file: the given output, or the .pot or
+// <lang>.po in the local module's i18n directory
+func i18nOutputPath(state *config.State, moduleName, lang, output string) (string, error) {
+	if output != "" {
+		return filepath.Abs(output)
+	}
+	moduleDir, ok := findModuleDir(moduleName, append([]string{state.ProjectRoot}, state.AddonsPaths...))
+	if !ok {
+		return "", fmt.Errorf("module %q not found locally; pass --output to choose where to write the export", moduleName)
+	}
+	name := moduleName + ".pot"
+	if lang != "" {
+		name = lang + ".po"
+	}
+	return filepath.Join(moduleDir, "i18n", name), nil
+}
@@ -2,24 +2,63 @@ package module
 
 import (
 	"fmt"
+	"path/filepath"
+	"runtime"
 	"strings"
 
+	"github.com/fatih/color"
+	modlib "github.com/mart337i/odooctl/internal/module"
+	"github.com/mart337i/odooctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
-var flagListJSON bool
+var (
+	flagListJSON   bool
+	flagListOutput string
+	flagListStatus bool
+)
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List Odoo modules in the current project",
-	RunE:  runList,
+	Long: `Lists the Odoo modules in the project and its addons paths.
+
+With --status, the project's own modules are compared with the hashes stored
+by the last 'odooctl docker install' and reported as new, changed or
+unchanged. Only files are read, so it works before the containers are built.
+
+Examples:
+  odooctl module list
+  odooctl module list --status
+  odooctl module list --status --json`,
+	RunE: runList,
+}
+
+type moduleStatusEntry struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+}
+
+type moduleStatusReport struct {
+	New       []moduleStatusEntry `json:"new"`
+	Changed   []moduleStatusEntry `json:"changed"`
+	Unchanged []moduleStatusEntry `json:"unchanged"`
 }
 
 func init() {
 	listCmd.Flags().BoolVar(&flagListJSON, "json", false, "Print JSON output")
+	listCmd.Flags().StringVarP(&flagListOutput, "output", "o", "table", output.FormatFlagUsage)
+	listCmd.Flags().BoolVar(&flagListStatus, "status", false, "Report local modules as new, changed or unchanged since the last install")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	format, err := output.ResolveFormat(flagListOutput, flagListJSON)
+	if err != nil {
+		return err
+	}
+	if flagListStatus {
+		return runListStatus(format)
+	}
 	dirs, _, err := moduleScanDirs()
 	if err != nil {
 		return err
@@ -28,30 +67,80 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if flagListJSON {
-		return printJSON(manifests)
-	}
-	if len(manifests) == 0 {
+	if format == output.FormatTable && len(manifests) == 0 {
 		fmt.Println("No Odoo modules found")
 		return nil
 	}
-	fmt.Printf("%-32s %-28s %-12s %s\n", "MODULE", "NAME", "VERSION", "DEPENDS")
-	fmt.Println(strings.Repeat("-", 92))
+	table := output.Table{Headers: []string{"module", "name", "version", "depends", "path"}}
 	for _, manifest := range manifests {
-		fmt.Printf("%-32s %-28s %-12s %s\n", manifest.Module, trimForTable(manifest.Name, 28), manifest.Version, strings.Join(manifest.Depends, ","))
+		table.Rows = append(table.Rows, []string{manifest.Module, manifest.Name, manifest.Version, strings.Join(manifest.Depends, ","), filepath.Dir(manifest.Path)})
 	}
-	return nil
+	return output.Print(format, table, manifests)
 }
 
-func trimForTable(value string, max int) string {
-	if len(value) <= max {
-		return value
+func runListStatus(format output.Format) error {
+	state, err := loadModuleState()
+	if err != nil {
+		return err
 	}
-	if max <= 1 {
-		return value[:max]
+	modules, err := modlib.FindModules(state.ProjectRoot)
+	if err != nil {
+		return err
+	}
+	stored, _ := loadModuleHashes(state)
+
+	report := moduleStatusReport{New: []moduleStatusEntry{}, Changed: []moduleStatusEntry{}, Unchanged: []moduleStatusEntry{}}
+	for _, result := range modlib.HashModules(state.ProjectRoot, modules, runtime.NumCPU()) {
+		if result.Err != nil {
+			return fmt.Errorf("failed to hash %s: %w", result.Module, result.Err)
+		}
+		entry := moduleStatusEntry{Module: result.Module}
+		if manifest, err := modlib.ParseManifest(filepath.Join(state.ProjectRoot, result.Module)); err == nil {
+			entry.Version = manifest.Version
+		}
+		switch stored[result.Module] {
+		case "":
+			report.New = append(report.New, entry)
+		case result.Hash:
+			report.Unchanged = append(report.Unchanged, entry)
+		default:
+			report.Changed = append(report.Changed, entry)
+		}
+	}
+
+	if format != output.FormatTable {
+		table := output.Table{Headers: []string{"status", "module", "version"}}
+		for _, section := range []struct {
+			status  string
+			entries []moduleStatusEntry
+		}{{"new", report.New}, {"changed", report.Changed}, {"unchanged", report.Unchanged}} {
+			for _, entry := range section.entries {
+				table.Rows = append(table.Rows, []string{section.status, entry.Module, entry.Version})
+			}
+		}
+		return output.Print(format, table, report)
+	}
+
+	if len(modules) == 0 {
+		fmt.Println("No Odoo modules found")
+		return nil
+	}
+	printModuleStatusSection("New (never installed)", color.CyanString("+"), report.New)
+	printModuleStatusSection("Changed since last install", color.YellowString("~"), report.Changed)
+	printModuleStatusSection("Unchanged", color.New(color.Faint).Sprint("="), report.Unchanged)
+	return nil
+}
+
+func printModuleStatusSection(title, marker string, entries []moduleStatusEntry) {
+	if len(entries) == 0 {
+		return
 	}
-	if max <= 3 {
-		return value[:max]
+	fmt.Printf("\n%s (%d):\n", title, len(entries))
+	for _, entry := range entries {
+		version := entry.Version
+		if version == "" {
+			version = "-"
+		}
+		fmt.Printf("  %s %-40s %s\n", marker, entry.Module, color.New(color.Faint).Sprint(version))
 	}
-	return value[:max-3] + "..."
 }
@@ -17,4 +17,7 @@ func init() {
 	Cmd.AddCommand(testCmd)
 	Cmd.AddCommand(upgradeCmd)
 	Cmd.AddCommand(migrateCmd)
+	Cmd.AddCommand(generateCICmd)
+	Cmd.AddCommand(i18nExportCmd)
+	Cmd.AddCommand(validateCmd)
 }
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	modlib "github.com/mart337i/odooctl/internal/module"
 	"github.com/mart337i/odooctl/internal/odoo"
 	"github.com/mart337i/odooctl/internal/output"
 	"github.com/mart337i/odooctl/internal/project"
@@ -22,16 +24,31 @@ var (
 	flagDescription  string
 	flagWithModel    bool
 	flagScaffoldJSON bool
+	flagPythonVer    string
+	flagDependsFrom  string
+	flagWebsite      bool
+	flagMixin        bool
+	flagMultiCompany bool
+	flagWithWizard   bool
+	flagWithReport   bool
+	flagI18n         bool
+	flagScaffoldPath string
 )
 
 type scaffoldReport struct {
-	Module      string   `json:"module"`
-	Location    string   `json:"location"`
-	OdooVersion string   `json:"odoo_version"`
-	Depends     []string `json:"depends"`
-	WithModel   bool     `json:"with_model"`
-	Model       string   `json:"model,omitempty"`
-	NextSteps   []string `json:"next_steps"`
+	Module       string   `json:"module"`
+	Location     string   `json:"location"`
+	OdooVersion  string   `json:"odoo_version"`
+	Depends      []string `json:"depends"`
+	WithModel    bool     `json:"with_model"`
+	Website      bool     `json:"website,omitempty"`
+	Model        string   `json:"model,omitempty"`
+	Mixin        string   `json:"mixin,omitempty"`
+	MultiCompany bool     `json:"multi_company,omitempty"`
+	Wizard       string   `json:"wizard,omitempty"`
+	Report       bool     `json:"report,omitempty"`
+	I18n         bool     `json:"i18n,omitempty"`
+	NextSteps    []string `json:"next_steps"`
 }
 
 var scaffoldCmd = &cobra.Command{
@@ -42,17 +59,66 @@ var scaffoldCmd = &cobra.Command{
 Examples:
   odooctl module scaffold my_module
   odooctl module scaffold my_module --author "My Company"
-  odooctl module scaffold my_module --depends sale,purchase --model`,
+  odooctl module scaffold my_module --depends sale,purchase --model
+  odooctl module scaffold my_module_extra --depends-from my_module
+  odooctl module scaffold my_landing_page --website
+  odooctl module scaffold my_tracking --mixin
+  odooctl module scaffold my_contracts --model --multi-company
+  odooctl module scaffold my_invoicing --model --with-wizard --with-report
+  odooctl module scaffold my_module --model --i18n
+  odooctl module scaffold my_module --path ../shared-addons
+
+The module is created in the current directory, or in --path. Inside an
+environment, scaffolding from a subdirectory offers to create it in the
+project root instead, where Odoo finds it.
+
+Generated code follows the target version: Odoo 17+ models compute
+display_name, older ones override name_get (with @api.multi on 12.0), and
+type hints are emitted for Python 3.10+.
+
+--website adds a controller, a QWeb page, SCSS/JS stubs and their frontend
+asset registration (the manifest 'assets' key on 15.0+, an assets.xml template
+before that), and depends on website.
+
+--mixin adds an AbstractModel (my.module.mixin) for shared behavior. The model
+from --model inherits it; without --model an example model (my.module.example)
+shows how.
+
+--multi-company adds a company_id field (defaulting to the current company)
+to the generated model, shows it in the form for multi-company users, and adds
+a global record rule so users only see records of their allowed companies.
+It needs --model or --mixin.
+
+--with-wizard adds a transient model (my.module.wizard) in wizard/ with a form
+view and a window action. With a generated model, the action appears in its
+Action menu and the wizard receives the selected records.
+
+--with-report adds a QWeb PDF report on the generated model in report/, with a
+report action in its Print menu, and depends on web. It needs --model or --mixin.
+
+--i18n adds i18n/<module>.pot with the translation template header. Fill it
+with 'odooctl module i18n-export <module>' once the module is installed.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runScaffold,
 }
 
 func init() {
-	scaffoldCmd.Flags().StringVarP(&flagAuthor, "author", "a", "", "Module author")
+	scaffoldCmd.Flags().StringVarP(&flagAuthor, "author", "a", "", "Module author (default: odooctl config default-author)")
 	scaffoldCmd.Flags().StringVarP(&flagVersion, "odoo-version", "v", "", "Odoo version ("+odoo.VersionsString()+")")
+	_ = scaffoldCmd.RegisterFlagCompletionFunc("odoo-version", cobra.FixedCompletions(odoo.OdooVersions, cobra.ShellCompDirectiveNoFileComp))
 	scaffoldCmd.Flags().StringVarP(&flagDepends, "depends", "d", "base", "Dependencies (comma-separated)")
+	scaffoldCmd.Flags().StringVar(&flagDependsFrom, "depends-from", "", "Copy dependencies from an existing local module (merged with --depends)")
 	scaffoldCmd.Flags().StringVar(&flagDescription, "description", "", "Module description")
 	scaffoldCmd.Flags().BoolVarP(&flagWithModel, "model", "m", false, "Include a model with the same name")
+	scaffoldCmd.Flags().BoolVar(&flagMixin, "mixin", false, "Include an AbstractModel mixin and a model inheriting it")
+	scaffoldCmd.Flags().BoolVar(&flagMultiCompany, "multi-company", false, "Add company_id and a multi-company record rule to the generated model")
+	scaffoldCmd.Flags().BoolVar(&flagWithWizard, "with-wizard", false, "Include a wizard (transient model, form view and action)")
+	scaffoldCmd.Flags().BoolVar(&flagWithReport, "with-report", false, "Include a QWeb PDF report and its print action")
+	scaffoldCmd.Flags().BoolVar(&flagI18n, "i18n", false, "Include an i18n/<module>.pot translation template")
+	scaffoldCmd.Flags().BoolVar(&flagWebsite, "website", false, "Generate a website module (controller, page template, frontend assets)")
+	scaffoldCmd.Flags().StringVar(&flagPythonVer, "python-version", "", "Target Python version for generated code (default: minimum for the Odoo version)")
+	scaffoldCmd.Flags().StringVar(&flagScaffoldPath, "path", "", "Directory to create the module in (e.g. an addons path of the environment)")
+	_ = scaffoldCmd.MarkFlagDirname("path")
 	scaffoldCmd.Flags().BoolVar(&flagScaffoldJSON, "json", false, "Print JSON output")
 }
 
@@ -64,20 +130,32 @@ func runScaffold(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid module name %q: use lowercase letters, numbers, and underscores", moduleName)
 	}
 
+	if flagMultiCompany && !flagWithModel && !flagMixin {
+		return fmt.Errorf("--multi-company needs a model: add --model or --mixin")
+	}
+	if flagWithReport && !flagWithModel && !flagMixin {
+		return fmt.Errorf("--with-report needs a model to print: add --model or --mixin")
+	}
+
+	parentDir, err := scaffoldParentDir(flagScaffoldPath)
+	if err != nil {
+		return err
+	}
+	moduleDir := filepath.Join(parentDir, moduleName)
+
 	// Check if directory already exists
-	if _, err := os.Stat(moduleName); err == nil {
-		return fmt.Errorf("Module %q already exists", moduleName)
+	if _, err := os.Stat(moduleDir); err == nil {
+		return fmt.Errorf("Module %q already exists", moduleDir)
 	}
 
 	// Detect Odoo version from context
 	odooVersion := flagVersion
 	if odooVersion == "" {
-		ctx := project.Detect(".")
+		ctx := project.Detect(parentDir)
 		if ctx.OdooVersion != "" {
 			odooVersion = ctx.OdooVersion
 		} else {
 			// Prompt for version
-			var err error
 			odooVersion, err = prompt.SelectVersion()
 			if err != nil {
 				return err
@@ -93,30 +171,70 @@ func runScaffold(cmd *cobra.Command, args []string) error {
 			depends[i] = strings.TrimSpace(depends[i])
 		}
 	}
+	if flagDependsFrom != "" {
+		inherited, err := inheritedDepends(flagDependsFrom)
+		if err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("depends") {
+			depends = nil
+		}
+		depends = mergeDepends(depends, inherited)
+		if len(depends) == 0 {
+			depends = []string{"base"}
+		}
+	}
+	if flagWebsite {
+		if len(depends) == 1 && depends[0] == "base" {
+			depends = nil
+		}
+		depends = mergeDepends(depends, []string{"website"})
+	}
+	if flagWithReport {
+		if len(depends) == 1 && depends[0] == "base" {
+			depends = nil
+		}
+		depends = mergeDepends(depends, []string{"web"})
+	}
 
 	config := scaffold.ModuleConfig{
-		Name:        moduleName,
-		Author:      flagAuthor,
-		Version:     odooVersion,
-		Depends:     depends,
-		Description: flagDescription,
-		WithModel:   flagWithModel,
+		Name:         moduleName,
+		Author:       flagAuthor,
+		Version:      odooVersion,
+		Depends:      depends,
+		Description:  flagDescription,
+		WithModel:    flagWithModel,
+		Website:      flagWebsite,
+		Mixin:        flagMixin,
+		MultiCompany: flagMultiCompany,
+		WithWizard:   flagWithWizard,
+		WithReport:   flagWithReport,
+		I18n:         flagI18n,
+
+		PythonVersion: flagPythonVer,
 	}
 
 	// Set defaults
 	if config.Author == "" {
-		config.Author = "My Company"
+		config.Author = defaultAuthor()
 	}
 	if config.Description == "" {
 		config.Description = fmt.Sprintf("%s module", toTitle(moduleName))
 	}
 
 	// Create module
-	if err := scaffold.CreateModule(moduleName, config); err != nil {
+	if err := scaffold.CreateModule(moduleDir, config); err != nil {
 		return fmt.Errorf("failed to create module: %w", err)
 	}
 	if flagScaffoldJSON {
-		return output.PrintJSON(buildScaffoldReport(moduleName, odooVersion, depends, flagWithModel))
+		report := buildScaffoldReport(moduleName, moduleDir, odooVersion, depends, flagWithModel, flagWebsite, flagMixin)
+		report.MultiCompany = flagMultiCompany
+		if flagWithWizard {
+			report.Wizard = strings.ReplaceAll(moduleName, "_", ".") + ".wizard"
+		}
+		report.Report = flagWithReport
+		report.I18n = flagI18n
+		return output.PrintJSON(report)
 	}
 
 	// Print summary
@@ -125,44 +243,169 @@ func runScaffold(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 	fmt.Printf("%s Module created: %s\n\n", green("✓"), cyan(moduleName))
-	fmt.Printf("  Location:  %s\n", cyan(filepath.Join(".", moduleName)))
+	fmt.Printf("  Location:  %s\n", cyan(moduleDir))
 	fmt.Printf("  Version:   %s\n", cyan(odooVersion))
 	fmt.Printf("  Depends:   %s\n", cyan(strings.Join(depends, ", ")))
 
 	if flagWithModel {
 		fmt.Printf("  Model:     %s\n", cyan(strings.ReplaceAll(moduleName, "_", ".")))
 	}
+	if flagMixin {
+		fmt.Printf("  Mixin:     %s\n", cyan(strings.ReplaceAll(moduleName, "_", ".")+".mixin"))
+	}
+	if flagMultiCompany {
+		fmt.Printf("  Companies: %s\n", cyan("company_id + record rule in "+filepath.Join("security", moduleName+"_security.xml")))
+	}
+	if flagWithWizard {
+		fmt.Printf("  Wizard:    %s\n", cyan(strings.ReplaceAll(moduleName, "_", ".")+".wizard"))
+	}
+	if flagWithReport {
+		fmt.Printf("  Report:    %s\n", cyan(filepath.Join("report", moduleName+"_report.xml")))
+	}
+	if flagI18n {
+		fmt.Printf("  I18n:      %s\n", cyan(filepath.Join("i18n", moduleName+".pot")))
+	}
 
 	fmt.Println()
 	fmt.Println("Next steps:")
-	fmt.Printf("  1. Edit %s to customize the module\n", cyan(filepath.Join(moduleName, "__manifest__.py")))
+	fmt.Printf("  1. Edit %s to customize the module\n", cyan(filepath.Join(moduleDir, "__manifest__.py")))
+	step := 2
 	if flagWithModel {
-		fmt.Printf("  2. Edit %s to add fields\n", cyan(filepath.Join(moduleName, "models", moduleName+".py")))
+		fmt.Printf("  %d. Edit %s to add fields\n", step, cyan(filepath.Join(moduleDir, "models", moduleName+".py")))
+		step++
+	}
+	if flagMixin {
+		fmt.Printf("  %d. Edit %s to add the shared fields and methods\n", step, cyan(filepath.Join(moduleDir, "models", moduleName+"_mixin.py")))
+		step++
+	}
+	if flagWithWizard {
+		fmt.Printf("  %d. Edit %s to implement action_apply\n", step, cyan(filepath.Join(moduleDir, "wizard", moduleName+"_wizard.py")))
+		step++
+	}
+	if flagWithReport {
+		fmt.Printf("  %d. Edit %s to lay out the report\n", step, cyan(filepath.Join(moduleDir, "report", moduleName+"_report.xml")))
+		step++
+	}
+	if flagWebsite {
+		fmt.Printf("  %d. Edit %s, install, and open %s\n", step, cyan(filepath.Join(moduleDir, "views", "templates.xml")), cyan("/"+strings.ReplaceAll(moduleName, "_", "-")))
 	}
 	fmt.Println()
 
 	return nil
 }
 
-func buildScaffoldReport(moduleName, odooVersion string, depends []string, withModel bool) scaffoldReport {
+func buildScaffoldReport(moduleName, moduleDir, odooVersion string, depends []string, withModel, website, mixin bool) scaffoldReport {
 	report := scaffoldReport{
 		Module:      moduleName,
-		Location:    filepath.Join(".", moduleName),
+		Location:    moduleDir,
 		OdooVersion: odooVersion,
 		Depends:     append([]string{}, depends...),
 		WithModel:   withModel,
+		Website:     website,
 		NextSteps: []string{
-			fmt.Sprintf("Edit %s", filepath.Join(moduleName, "__manifest__.py")),
+			fmt.Sprintf("Edit %s", filepath.Join(moduleDir, "__manifest__.py")),
 			fmt.Sprintf("odooctl docker install %s", moduleName),
 		},
 	}
 	if withModel {
 		report.Model = strings.ReplaceAll(moduleName, "_", ".")
-		report.NextSteps = append(report.NextSteps, fmt.Sprintf("Edit %s", filepath.Join(moduleName, "models", moduleName+".py")))
+		report.NextSteps = append(report.NextSteps, fmt.Sprintf("Edit %s", filepath.Join(moduleDir, "models", moduleName+".py")))
+	}
+	if mixin {
+		report.Mixin = strings.ReplaceAll(moduleName, "_", ".") + ".mixin"
+		report.NextSteps = append(report.NextSteps, fmt.Sprintf("Edit %s", filepath.Join(moduleDir, "models", moduleName+"_mixin.py")))
+	}
+	if website {
+		report.NextSteps = append(report.NextSteps, fmt.Sprintf("Edit %s", filepath.Join(moduleDir, "views", "templates.xml")))
 	}
 	return report
 }
 
+// scaffoldParentDir resolves the directory the module is created in: --path
+// when given, otherwise the current directory, or the project root when
+// inside an environment and the user accepts it.
+func scaffoldParentDir(path string) (string, error) {
+	if path != "" {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return "", err
+		}
+		info, err := os.Stat(absPath)
+		if err != nil {
+			return "", fmt.Errorf("--path: %w", err)
+		}
+		if !info.IsDir() {
+			return "", fmt.Errorf("--path: %s is not a directory", absPath)
+		}
+		if modlib.IsModule(absPath) {
+			return "", fmt.Errorf("--path: %s is a module; pass the addons directory that should contain the new module", absPath)
+		}
+		return absPath, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	if modlib.IsModule(cwd) {
+		return "", fmt.Errorf("the current directory is a module; run scaffold from its addons directory or pass --path")
+	}
+	state, err := config.LoadFromDir(cwd)
+	if err != nil || state.ProjectRoot == "" || sameDir(cwd, state.ProjectRoot) {
+		return ".", nil
+	}
+	useRoot, err := prompt.Confirm(fmt.Sprintf("Create the module in the project root (%s)?", state.ProjectRoot), true)
+	if err != nil {
+		return "", err
+	}
+	if useRoot {
+		return state.ProjectRoot, nil
+	}
+	return ".", nil
+}
+
+// sameDir reports whether two paths name the same directory
+func sameDir(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// inheritedDepends reads the depends list of a local module, looking in the
+// current directory first and then the environment's module paths.
+func inheritedDepends(name string) ([]string, error) {
+	dirs, _, err := moduleScanDirs()
+	if err != nil {
+		return nil, err
+	}
+	moduleDir, ok := findModuleDir(name, append([]string{"."}, dirs...))
+	if !ok {
+		return nil, fmt.Errorf("--depends-from: module %q not found locally", name)
+	}
+	manifest, err := modlib.ParseManifest(moduleDir)
+	if err != nil {
+		return nil, fmt.Errorf("--depends-from: failed to read manifest of %q: %w", name, err)
+	}
+	return manifest.Depends, nil
+}
+
+// mergeDepends joins dependency lists, keeping the first occurrence of each
+func mergeDepends(lists ...[]string) []string {
+	var merged []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, dep := range list {
+			dep = strings.TrimSpace(dep)
+			if dep == "" || seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			merged = append(merged, dep)
+		}
+	}
+	return merged
+}
+
 func isValidModuleName(name string) bool {
 	if name == "" {
 		return false
@@ -191,3 +434,12 @@ func toTitle(s string) string {
 	}
 	return strings.Join(words, " ")
 }
+
+// defaultAuthor is the author saved with 'odooctl config set default-author',
+// or a placeholder
+func defaultAuthor() string {
+	if globalCfg, err := config.LoadGlobalConfig(); err == nil && globalCfg.DefaultAuthor != "" {
+		return globalCfg.DefaultAuthor
+	}
+	return "My Company"
+}
@@ -0,0 +1,133 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	modlib "github.com/mart337i/odooctl/internal/module"
+	"github.com/spf13/cobra"
+)
+
+var flagValidateJSON bool
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [path]",
+	Short: "Check module manifests for common mistakes",
+	Long: `Lints __manifest__.py: a missing name, an invalid version, depends entries
+that aren't module names, version specifiers in external_dependencies, and
+data or demo files that don't exist. Security CSV files that are never
+listed in data are reported too.
+
+The path is a module, a directory of modules (default: the current
+directory), or the name of a module in the environment's addons paths.
+Errors make the command exit non-zero, so it works as a pre-commit check.
+
+Examples:
+  odooctl module validate
+  odooctl module validate my_module
+  odooctl module validate ./addons --json`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runValidate,
+}
+
+func init() {
+	validateCmd.Flags().BoolVar(&flagValidateJSON, "json", false, "Print JSON output")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	target := "."
+	if len(args) == 1 {
+		target = args[0]
+	}
+	moduleDirs, err := validateTargets(target)
+	if err != nil {
+		return err
+	}
+
+	results := make([]modlib.ValidationResult, 0, len(moduleDirs))
+	failed := 0
+	for _, dir := range moduleDirs {
+		result, err := modlib.Validate(dir)
+		if err != nil {
+			return err
+		}
+		if result.Errors() > 0 {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if flagValidateJSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		printValidationResults(results)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d module(s) failed validation", failed)
+	}
+	return nil
+}
+
+// validateTargets resolves the validate argument to module directories
+func validateTargets(target string) ([]string, error) {
+	info, err := os.Stat(target)
+	if err != nil {
+		dirs, _, scanErr := moduleScanDirs()
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		if moduleDir, ok := findModuleDir(target, dirs); ok {
+			return []string{moduleDir}, nil
+		}
+		return nil, fmt.Errorf("%s is not a directory or a known module", target)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", target)
+	}
+	if modlib.IsModule(target) {
+		return []string{target}, nil
+	}
+
+	modules, err := modlib.FindModules(target)
+	if err != nil {
+		return nil, err
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no modules found in %s", target)
+	}
+	moduleDirs := make([]string, 0, len(modules))
+	for _, name := range modules {
+		moduleDirs = append(moduleDirs, filepath.Join(target, name))
+	}
+	return moduleDirs, nil
+}
+
+func printValidationResults(results []modlib.ValidationResult) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	for _, result := range results {
+		errors, warnings := result.Errors(), result.Warnings()
+		switch {
+		case errors > 0:
+			fmt.Printf("%s %s: %d error(s), %d warning(s)\n", red("✗"), result.Module, errors, warnings)
+		case warnings > 0:
+			fmt.Printf("%s %s: %d warning(s)\n", yellow("⚠️"), result.Module, warnings)
+		default:
+			fmt.Printf("%s %s\n", green("✓"), result.Module)
+		}
+		for _, issue := range result.Issues {
+			marker := yellow("⚠️")
+			if issue.Severity == modlib.SeverityError {
+				marker = red("✗")
+			}
+			fmt.Printf("    %s %s: %s\n", marker, issue.Field, issue.Message)
+		}
+	}
+}
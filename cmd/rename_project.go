@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/docker"
+	"github.com/mart337i/odooctl/internal/output"
+	"github.com/mart337i/odooctl/internal/templates"
+	"github.com/mart337i/odooctl/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagRenameCopyVolumes bool
+	flagRenameForce       bool
+	flagRenameJSON        bool
+)
+
+type renameProjectReport struct {
+	OldName      string                   `json:"old_name"`
+	NewName      string                   `json:"new_name"`
+	Environments []renamedEnvironmentInfo `json:"environments"`
+}
+
+type renamedEnvironmentInfo struct {
+	Branch         string   `json:"branch"`
+	EnvDir         string   `json:"env_dir"`
+	ComposeProject string   `json:"compose_project"`
+	CopiedVolumes  []string `json:"copied_volumes,omitempty"`
+	OldVolumes     []string `json:"old_volumes,omitempty"`
+}
+
+var renameProjectCmd = &cobra.Command{
+	Use:          "rename-project <old> <new>",
+	Short:        "Rename a project across all of its environments",
+	SilenceUsage: true,
+	Long: `Renames a project in every environment (branch) at once.
+
+The environment directory moves from ~/.odooctl/<old> to ~/.odooctl/<new>, each
+state file and project link is updated, and the Docker files are regenerated.
+
+The project name is part of the Docker Compose project and container names, so
+running containers are stopped and removed first and are recreated under the new
+name by the next 'odooctl docker run'. Database, filestore and other volumes are
+copied to the new compose project (skip with --copy-volumes=false); the old
+volumes are left in place until you remove them.
+
+Examples:
+  odooctl config rename-project shop webshop
+  odooctl config rename-project shop webshop --copy-volumes=false --force`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRenameProject,
+}
+
+func init() {
+	renameProjectCmd.Flags().BoolVar(&flagRenameCopyVolumes, "copy-volumes", true, "Copy Docker volumes (database, filestore) to the new compose project")
+	renameProjectCmd.Flags().BoolVarP(&flagRenameForce, "force", "f", false, "Skip confirmation prompt")
+	renameProjectCmd.Flags().BoolVar(&flagRenameJSON, "json", false, "Print JSON output")
+	configCmd.AddCommand(renameProjectCmd)
+}
+
+func runRenameProject(cmd *cobra.Command, args []string) error {
+	oldName, newName := args[0], args[1]
+	if err := config.ValidateProjectRename(oldName, newName); err != nil {
+		return err
+	}
+	states, err := config.ProjectEnvironments(oldName)
+	if err != nil {
+		return err
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	if !flagRenameJSON {
+		fmt.Printf("Renaming project %s → %s (%d environment(s))\n", cyan(oldName), cyan(newName), len(states))
+	}
+	if len(states) > 0 && !flagRenameForce {
+		if prompt.NonInteractive() {
+			return fmt.Errorf("renaming removes the containers of %d environment(s); pass --force to confirm in non-interactive mode", len(states))
+		}
+		confirmed, err := prompt.Confirm("Containers of these environments will be removed and recreated under the new name. Continue?", false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("rename cancelled")
+		}
+	}
+
+	dockerErr := docker.CheckDaemon()
+	if dockerErr != nil && !flagRenameJSON {
+		fmt.Printf("%s %v\n", yellow("⚠️"), dockerErr)
+		fmt.Printf("%s Containers and volumes are left as they are\n", yellow("⚠️"))
+	}
+
+	// Stop the old stacks while their compose files still live under the old name
+	oldProjects := make(map[string]string)
+	stopped := make(map[string]bool)
+	for _, state := range states {
+		oldProjects[state.Branch] = state.ComposeProjectName()
+		if dockerErr != nil || stopped[state.ComposeProjectName()] {
+			continue
+		}
+		stopped[state.ComposeProjectName()] = true
+		if out, err := docker.ComposeOutput(state, "down"); err != nil && !flagRenameJSON {
+			fmt.Printf("%s Failed to stop %s: %s\n", yellow("⚠️"), state.Branch, strings.TrimSpace(out))
+		}
+	}
+
+	renamed, err := config.RenameProject(oldName, newName)
+	if err != nil {
+		return err
+	}
+
+	// Branches of a legacy project share one compose project: list its
+	// volumes once and copy them once per new compose project
+	oldVolumes := make(map[string][]string)
+	copied := make(map[[2]string][]string)
+	report := renameProjectReport{OldName: oldName, NewName: newName}
+	for _, state := range renamed {
+		envDir, err := config.EnvironmentDir(state.ProjectName, state.Branch)
+		if err != nil {
+			return err
+		}
+		if err := templates.Render(state); err != nil {
+			return fmt.Errorf("failed to regenerate files for %s: %w", state.Branch, err)
+		}
+
+		info := renamedEnvironmentInfo{Branch: state.Branch, EnvDir: envDir, ComposeProject: state.ComposeProjectName()}
+		oldProject := oldProjects[state.Branch]
+		if dockerErr == nil {
+			volumes, listed := oldVolumes[oldProject]
+			if !listed {
+				volumes, err = docker.ProjectVolumes(oldProject)
+				if err != nil && !flagRenameJSON {
+					fmt.Printf("%s %v\n", yellow("⚠️"), err)
+				}
+				oldVolumes[oldProject] = volumes
+			}
+			info.OldVolumes = volumes
+			pair := [2]string{oldProject, info.ComposeProject}
+			if _, done := copied[pair]; flagRenameCopyVolumes && !done {
+				for _, volume := range volumes {
+					if !flagRenameJSON {
+						fmt.Printf("  Copying volume %s...\n", volume)
+					}
+					target, err := docker.CopyProjectVolume(volume, oldProject, info.ComposeProject)
+					if err != nil {
+						return err
+					}
+					copied[pair] = append(copied[pair], target)
+				}
+			}
+			info.CopiedVolumes = copied[pair]
+		}
+		report.Environments = append(report.Environments, info)
+	}
+
+	if flagRenameJSON {
+		return output.PrintJSON(report)
+	}
+
+	fmt.Printf("\n%s Project renamed to %s\n\n", green("✓"), cyan(newName))
+	for _, info := range report.Environments {
+		fmt.Printf("  %-20s %s\n", info.Branch, cyan(info.EnvDir))
+	}
+
+	var leftover []string
+	for _, volumes := range oldVolumes {
+		leftover = append(leftover, volumes...)
+	}
+	sort.Strings(leftover)
+	if len(leftover) > 0 {
+		fmt.Println()
+		if flagRenameCopyVolumes {
+			fmt.Println("Volumes were copied. Once the renamed environments work, remove the old ones with:")
+		} else {
+			fmt.Printf("%s Volumes were not copied; the renamed environments start with empty databases.\n", yellow("⚠️"))
+			fmt.Println("The old volumes can be removed with:")
+		}
+		fmt.Printf("  docker volume rm %s\n", strings.Join(leftover, " "))
+	}
+	fmt.Printf("\nStart an environment again with: %s\n", cyan("odooctl docker run"))
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -10,21 +11,31 @@ import (
 	"github.com/mart337i/odooctl/cmd/module"
 	odoocmd "github.com/mart337i/odooctl/cmd/odoo"
 	"github.com/mart337i/odooctl/internal/output"
+	"github.com/mart337i/odooctl/pkg/prompt"
 	"github.com/spf13/cobra"
 )
 
 var version = "0.2.5"
 
+var flagNoInteraction bool
+
 var rootCmd = &cobra.Command{
 	Use:           "odooctl",
 	Short:         "CLI tool for Odoo Docker development environments",
 	Long:          `odooctl helps you create and manage Docker-based Odoo development environments.`,
 	SilenceErrors: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		prompt.SetNonInteractive(flagNoInteraction)
+	},
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		var coded *output.ExitCodeError
+		if errors.As(err, &coded) {
+			os.Exit(coded.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -32,12 +43,14 @@ func Execute() {
 func init() {
 	rootCmd.Version = version
 	rootCmd.SetVersionTemplate("odooctl {{.Version}}\n")
+	rootCmd.PersistentFlags().BoolVarP(&flagNoInteraction, "no-interaction", "y", false, "Answer every prompt with its default (for CI and scripts)")
 	rootCmd.AddCommand(ai.Cmd)
 	rootCmd.AddCommand(browsercmd.Cmd)
 	rootCmd.AddCommand(docker.Cmd)
 	rootCmd.AddCommand(module.Cmd)
 	rootCmd.AddCommand(odoocmd.Cmd)
 	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
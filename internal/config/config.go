@@ -8,6 +8,8 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -22,6 +24,16 @@ const legacyMarkerFileName = ".odooctl"
 type GlobalConfig struct {
 	SSHKeyPath  string `json:"ssh_key_path,omitempty"` // Path to SSH private key (e.g. ~/.ssh/id_ed25519)
 	GitHubToken string `json:"github_token,omitempty"` // GitHub Personal Access Token for enterprise repo
+	CacheFrom   string `json:"cache_from,omitempty"`   // Default image used to seed the Docker build cache
+	// ComposeParallelism sets COMPOSE_PARALLEL_LIMIT for docker compose (0 keeps compose's default)
+	ComposeParallelism int `json:"compose_parallelism,omitempty"`
+	// InteractivePorts asks how to resolve port conflicts in 'docker run' on a terminal
+	InteractivePorts bool `json:"interactive_ports,omitempty"`
+	// DefaultOdooVersion is used by 'docker create' when the version is not
+	// passed or detected from the branch, instead of prompting
+	DefaultOdooVersion string `json:"default_odoo_version,omitempty"`
+	// DefaultAuthor is the author of modules created by 'module scaffold'
+	DefaultAuthor string `json:"default_author,omitempty"`
 }
 
 // GlobalConfigPath returns ~/.odooctl/config.json
@@ -90,26 +102,46 @@ type ProjectLink struct {
 }
 
 type State struct {
-	ProjectName           string     `json:"project_name"`
-	OdooVersion           string     `json:"odoo_version"`
-	Branch                string     `json:"branch"`
-	IsGitRepo             bool       `json:"is_git_repo"`
-	ProjectRoot           string     `json:"project_root"`
-	Modules               []string   `json:"modules"`
-	Enterprise            bool       `json:"enterprise"`
-	EnterpriseGitHubToken string     `json:"enterprise_github_token,omitempty"` // GitHub token for enterprise repo access
-	EnterpriseSSHKeyPath  string     `json:"enterprise_ssh_key_path,omitempty"` // Path to SSH private key for enterprise repo
-	WithoutDemo           bool       `json:"without_demo"`
-	PipPackages           []string   `json:"pip_packages"`
-	PythonDepsHash        string     `json:"python_deps_hash,omitempty"`
-	PythonDepsSyncedAt    *time.Time `json:"python_deps_synced_at,omitempty"`
-	BrowserEnabled        bool       `json:"browser_enabled,omitempty"`
-	BrowserProvider       string     `json:"browser_provider,omitempty"`
-	AddonsPaths           []string   `json:"addons_paths"`
-	Ports                 Ports      `json:"ports"`
-	CreatedAt             time.Time  `json:"created_at"`
-	InitializedAt         *time.Time `json:"initialized_at,omitempty"` // When database was first initialized with -i
-	BuiltAt               *time.Time `json:"built_at,omitempty"`       // When containers were first built with --build
+	ProjectName           string   `json:"project_name"`
+	ComposeProject        string   `json:"compose_project,omitempty"` // docker compose project name, "{version}-{project}" when empty
+	OdooVersion           string   `json:"odoo_version"`
+	Branch                string   `json:"branch"`
+	IsGitRepo             bool     `json:"is_git_repo"`
+	ProjectRoot           string   `json:"project_root"`
+	Modules               []string `json:"modules"`
+	Enterprise            bool     `json:"enterprise"`
+	EnterpriseGitHubToken string   `json:"enterprise_github_token,omitempty"` // GitHub token for enterprise repo access
+	EnterpriseSSHKeyPath  string   `json:"enterprise_ssh_key_path,omitempty"` // Path to SSH private key for enterprise repo
+	EnterpriseRepoURL     string   `json:"enterprise_repo_url,omitempty"`     // Enterprise repository to clone, DefaultEnterpriseRepoURL when empty
+	// UseGlobalEnterpriseAuth resolves the enterprise token from the global
+	// config instead of keeping a copy in this state file
+	UseGlobalEnterpriseAuth bool              `json:"use_global_enterprise_auth,omitempty"`
+	WithoutDemo             bool              `json:"without_demo"`
+	WithoutDemoModules      []string          `json:"without_demo_modules,omitempty"` // Modules initialized without demo data (before 19.0)
+	PipPackages             []string          `json:"pip_packages"`
+	PythonDepsHash          string            `json:"python_deps_hash,omitempty"`
+	PythonDepsSyncedAt      *time.Time        `json:"python_deps_synced_at,omitempty"`
+	BrowserEnabled          bool              `json:"browser_enabled,omitempty"`
+	BrowserProvider         string            `json:"browser_provider,omitempty"`
+	AddonsPaths             []string          `json:"addons_paths"`
+	ExtraConfOptions        map[string]string `json:"extra_conf_options,omitempty"`  // Additional [options] entries for odoo.conf
+	CacheFrom               string            `json:"cache_from,omitempty"`          // Image used to seed the build cache ("none" ignores the global default)
+	LogMaxSize              string            `json:"log_max_size,omitempty"`        // json-file log rotation size per segment (e.g. 10m)
+	LogMaxFile              int               `json:"log_max_file,omitempty"`        // Number of rotated log segments to keep
+	DebugpyEnabled          bool              `json:"debugpy_enabled,omitempty"`     // Odoo runs under debugpy, attachable on Ports.Debug
+	DBNameOverride          string            `json:"db_name_override,omitempty"`    // Database name chosen with create --db-name
+	PostgresVersion         string            `json:"postgres_version,omitempty"`    // postgres image tag, DefaultPostgresVersion when empty
+	ExternalNetwork         string            `json:"external_network,omitempty"`    // Existing Docker network the odoo service also joins
+	FilestoreBindPath       string            `json:"filestore_bind_path,omitempty"` // Host directory mounted as the filestore instead of a volume
+	ExtraEnv                map[string]string `json:"extra_env,omitempty"`           // Additional environment variables for the odoo service
+	ReportURL               string            `json:"report_url,omitempty"`          // report.url set when the database is initialized, DefaultReportURL when empty
+	WebBaseURL              string            `json:"web_base_url,omitempty"`        // web.base.url set and frozen when the database is initialized
+	Ports                   Ports             `json:"ports"`
+	CreatedAt               time.Time         `json:"created_at"`
+	InitializedAt           *time.Time        `json:"initialized_at,omitempty"`  // When database was first initialized with -i
+	BuiltAt                 *time.Time        `json:"built_at,omitempty"`        // When the image was last built by docker build, or first by run --build
+	LastInstallAt           *time.Time        `json:"last_install_at,omitempty"` // When 'docker install' last had every local module's hash up to date
+	LastStartedAt           *time.Time        `json:"last_started_at,omitempty"` // When 'docker run' last brought the containers up
 }
 
 // ConfigDir returns ~/.odooctl
@@ -268,9 +300,7 @@ func IsPortAvailable(port int) bool {
 // CheckPortsAvailable checks if all ports are available
 func (p Ports) CheckPortsAvailable() (bool, []int) {
 	var conflicting []int
-	ports := []int{p.Odoo, p.Mailhog, p.SMTP, p.Debug}
-
-	for _, port := range ports {
+	for _, port := range p.list() {
 		if !IsPortAvailable(port) {
 			conflicting = append(conflicting, port)
 		}
@@ -279,9 +309,13 @@ func (p Ports) CheckPortsAvailable() (bool, []int) {
 	return len(conflicting) == 0, conflicting
 }
 
-// FindAvailablePorts finds available ports starting from calculated ports
-func FindAvailablePorts(version string) Ports {
+// FindAvailablePorts finds ports for the projectName/branch environment,
+// starting from the calculated ports. A block is skipped when one of its ports
+// is in use or saved for another environment, so a stopped environment keeps
+// its ports and environments of the same version get the next free block.
+func FindAvailablePorts(version, projectName, branch string) Ports {
 	base := CalculatePorts(version)
+	reserved := ReservedPorts(projectName, branch)
 
 	// Try to find available ports, incrementing by 10 if conflict
 	for i := 0; i < 10; i++ {
@@ -293,6 +327,9 @@ func FindAvailablePorts(version string) Ports {
 			Debug:   base.Debug + offset,
 		}
 
+		if candidate.overlaps(reserved) {
+			continue
+		}
 		available, _ := candidate.CheckPortsAvailable()
 		if available {
 			return candidate
@@ -303,6 +340,48 @@ func FindAvailablePorts(version string) Ports {
 	return base
 }
 
+// ReservedPorts returns the ports saved for every environment other than
+// projectName/branch
+func ReservedPorts(projectName, branch string) map[int]bool {
+	reserved := make(map[int]bool)
+	environments, _ := AllEnvironments()
+	for _, env := range environments {
+		if env.State.ProjectName == projectName && env.State.Branch == branch {
+			continue
+		}
+		for _, port := range env.State.Ports.list() {
+			reserved[port] = true
+		}
+	}
+	return reserved
+}
+
+func (p Ports) list() []int {
+	return []int{p.Odoo, p.Mailhog, p.SMTP, p.Debug}
+}
+
+func (p Ports) overlaps(reserved map[int]bool) bool {
+	for _, port := range p.list() {
+		if reserved[port] {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy returns a deep copy of the state
+func (s *State) Copy() (*State, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var copied State
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
 // Save writes state to the environment directory
 func (s *State) Save() error {
 	dir, err := EnvironmentDir(s.ProjectName, s.Branch)
@@ -338,12 +417,15 @@ func Load(projectName, branch string) (*State, error) {
 	if err := json.Unmarshal(data, &state); err != nil {
 		return nil, err
 	}
+	migrateEnterpriseAuth(&state)
 
 	return &state, nil
 }
 
 // LoadFromDir finds state for a project directory using global project links.
-// It never reads or writes repo-local marker files.
+// It never reads or writes repo-local marker files. A link to a deleted
+// environment is removed; when no other environment is found the error is a
+// *StaleProjectLinkError.
 func LoadFromDir(dir string) (*State, error) {
 	absDir, err := filepath.Abs(dir)
 	if err != nil {
@@ -351,12 +433,22 @@ func LoadFromDir(dir string) (*State, error) {
 	}
 	absDir = filepath.Clean(absDir)
 
+	var stale *StaleProjectLinkError
 	for _, candidate := range parentDirs(absDir) {
 		link, err := LoadProjectLink(candidate)
 		if err != nil {
 			continue
 		}
 		state, err := loadStateFromEnvDir(link.EnvDir)
+		if os.IsNotExist(err) {
+			// The environment was deleted: drop the link so it doesn't send
+			// every later lookup down the slow path
+			path, _ := ProjectLinkPath(candidate)
+			if os.Remove(path) == nil && stale == nil {
+				stale = &StaleProjectLinkError{Path: path, EnvDir: link.EnvDir}
+			}
+			continue
+		}
 		if err != nil {
 			continue
 		}
@@ -375,13 +467,13 @@ func LoadFromDir(dir string) (*State, error) {
 	projectEntries, err := os.ReadDir(configDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, os.ErrNotExist
+			return nil, notFound(stale)
 		}
 		return nil, err
 	}
 
 	for _, projectEntry := range projectEntries {
-		if !projectEntry.IsDir() || projectEntry.Name() == ProjectLinksDirName {
+		if !projectEntry.IsDir() || IsReservedProjectName(projectEntry.Name()) {
 			continue
 		}
 
@@ -410,10 +502,87 @@ func LoadFromDir(dir string) (*State, error) {
 		}
 	}
 
-	return nil, os.ErrNotExist
+	return nil, notFound(stale)
+}
+
+// notFound is the LoadFromDir error when no environment matches, naming the
+// stale link removed on the way if there was one
+func notFound(stale *StaleProjectLinkError) error {
+	if stale != nil {
+		return stale
+	}
+	return os.ErrNotExist
+}
+
+// Environment is an environment found in the config directory
+type Environment struct {
+	Dir   string // ~/.odooctl/{project}/{branch}
+	State *State
+}
+
+// AllEnvironments loads every environment under ~/.odooctl, sorted by
+// project and branch. Directories without a readable state file are skipped.
+func AllEnvironments() ([]Environment, error) {
+	return allEnvironments(loadStateFromEnvDir)
+}
+
+func allEnvironments(load func(envDir string) (*State, error)) ([]Environment, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	projectEntries, err := os.ReadDir(configDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var environments []Environment
+	for _, projectEntry := range projectEntries {
+		if !projectEntry.IsDir() || IsReservedProjectName(projectEntry.Name()) {
+			continue
+		}
+		projectDir := filepath.Join(configDir, projectEntry.Name())
+		branchEntries, err := os.ReadDir(projectDir)
+		if err != nil {
+			continue
+		}
+		for _, branchEntry := range branchEntries {
+			if !branchEntry.IsDir() {
+				continue
+			}
+			envDir := filepath.Join(projectDir, branchEntry.Name())
+			state, err := load(envDir)
+			if err != nil {
+				continue
+			}
+			environments = append(environments, Environment{Dir: envDir, State: state})
+		}
+	}
+
+	sort.Slice(environments, func(i, j int) bool {
+		a, b := environments[i].State, environments[j].State
+		if a.ProjectName != b.ProjectName {
+			return a.ProjectName < b.ProjectName
+		}
+		return a.Branch < b.Branch
+	})
+	return environments, nil
 }
 
 func loadStateFromEnvDir(envDir string) (*State, error) {
+	state, err := readStateFile(envDir)
+	if err != nil {
+		return nil, err
+	}
+	migrateEnterpriseAuth(state)
+	return state, nil
+}
+
+// readStateFile reads an environment's state as stored, without migrations
+func readStateFile(envDir string) (*State, error) {
 	data, err := os.ReadFile(filepath.Join(envDir, StateFileName))
 	if err != nil {
 		return nil, err
@@ -471,8 +640,144 @@ func cleanupLegacyMarker(projectRoot string) {
 	}
 }
 
-// DBName returns the database name for this environment based on the Odoo version
+// EnterpriseToken returns the GitHub token used for enterprise access,
+// resolving it from the global config when the state only references it.
+func (s *State) EnterpriseToken() (string, error) {
+	if s.EnterpriseGitHubToken != "" || !s.UseGlobalEnterpriseAuth {
+		return s.EnterpriseGitHubToken, nil
+	}
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		return "", err
+	}
+	if cfg.GitHubToken == "" {
+		return "", fmt.Errorf("environment uses the saved GitHub token for Odoo Enterprise, but none is set; run 'odooctl config set github-token <token>'")
+	}
+	return cfg.GitHubToken, nil
+}
+
+// UsesEnterpriseToken reports whether enterprise access is token based
+func (s *State) UsesEnterpriseToken() bool {
+	return s.Enterprise && (s.EnterpriseGitHubToken != "" || s.UseGlobalEnterpriseAuth)
+}
+
+// migrateEnterpriseAuth replaces a token copied from the global config with
+// a reference to it, so the secret is stored only once.
+func migrateEnterpriseAuth(s *State) {
+	if s.EnterpriseGitHubToken == "" {
+		return
+	}
+	cfg, err := LoadGlobalConfig()
+	if err != nil || cfg.GitHubToken != s.EnterpriseGitHubToken {
+		return
+	}
+	s.EnterpriseGitHubToken = ""
+	s.UseGlobalEnterpriseAuth = true
+	_ = s.Save()
+}
+
+// CacheFromNone disables the global cache-from default for an environment
+const CacheFromNone = "none"
+
+// CacheFromRef returns the image used to seed the build cache: the
+// environment's own setting, else the global default.
+func (s *State) CacheFromRef() string {
+	if s.CacheFrom == CacheFromNone {
+		return ""
+	}
+	if s.CacheFrom != "" {
+		return s.CacheFrom
+	}
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		return ""
+	}
+	return cfg.CacheFrom
+}
+
+// Default container log rotation: 5 segments of 10 MB per service
+const (
+	DefaultLogMaxSize = "10m"
+	DefaultLogMaxFile = 5
+)
+
+// LoggingMaxSize returns the log segment size, defaulting to DefaultLogMaxSize
+func (s *State) LoggingMaxSize() string {
+	if s.LogMaxSize == "" {
+		return DefaultLogMaxSize
+	}
+	return s.LogMaxSize
+}
+
+// LoggingMaxFile returns the number of log segments, defaulting to DefaultLogMaxFile
+func (s *State) LoggingMaxFile() int {
+	if s.LogMaxFile <= 0 {
+		return DefaultLogMaxFile
+	}
+	return s.LogMaxFile
+}
+
+// DefaultReportURL is where wkhtmltopdf fetches report assets from: the odoo
+// service on the compose network
+const DefaultReportURL = "http://odoo:8069"
+
+// ReportURLParam returns the report.url parameter, defaulting to DefaultReportURL
+func (s *State) ReportURLParam() string {
+	if s.ReportURL == "" {
+		return DefaultReportURL
+	}
+	return s.ReportURL
+}
+
+// ComposeProjectName returns the docker compose project name, which prefixes
+// the environment's volumes and containers. Environments created before the
+// name was stored keep the legacy "{version}-{project}", e.g. 170-shop.
+func (s *State) ComposeProjectName() string {
+	if s.ComposeProject != "" {
+		return s.ComposeProject
+	}
+	return composeName(strings.Replace(s.OdooVersion, ".", "", 1) + "-" + s.ProjectName)
+}
+
+// NewComposeProjectName returns the compose project name for a new
+// environment: "{project}-{branch}", e.g. shop-main
+func NewComposeProjectName(project, branch string) string {
+	return composeName(project + "-" + branch)
+}
+
+// CheckComposeProjectFree returns an error when an environment other than
+// projectName/branch already uses the compose project name. Sanitizing can
+// map different branches to one name (fix/login and fix-login).
+func CheckComposeProjectFree(name, projectName, branch string) error {
+	environments, err := AllEnvironments()
+	if err != nil {
+		return err
+	}
+	for _, env := range environments {
+		if env.State.ProjectName == projectName && env.State.Branch == branch {
+			continue
+		}
+		if env.State.ComposeProjectName() == name {
+			return fmt.Errorf("compose project %q is already used by environment '%s/%s'", name, env.State.ProjectName, env.State.Branch)
+		}
+	}
+	return nil
+}
+
+// composeName lowers name and replaces characters compose rejects in project
+// names with -
+func composeName(name string) string {
+	return composeNameInvalidChars.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+var composeNameInvalidChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// DBName returns the database name for this environment: the --db-name
+// override, or a name based on the Odoo version
 func (s *State) DBName() string {
+	if s.DBNameOverride != "" {
+		return s.DBNameOverride
+	}
 	versionSuffix := strings.Replace(s.OdooVersion, ".", "", 1)
 	return "odoo-" + versionSuffix
 }
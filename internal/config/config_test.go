@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -100,3 +101,328 @@ func TestRemoveProjectLink(t *testing.T) {
 		t.Fatalf("project link was not removed: %v", err)
 	}
 }
+
+func TestParseConfOptions(t *testing.T) {
+	options, err := ParseConfOptions([]string{"proxy_mode=True", " limit_memory_hard = 0 ", "server_wide_modules="})
+	if err != nil {
+		t.Fatalf("ParseConfOptions() error = %v", err)
+	}
+	if options["proxy_mode"] != "True" || options["limit_memory_hard"] != "0" {
+		t.Fatalf("unexpected options: %#v", options)
+	}
+	if value, ok := options["server_wide_modules"]; !ok || value != "" {
+		t.Fatalf("empty value should be kept for removal, got %#v", options)
+	}
+
+	for _, invalid := range []string{"proxy_mode", "bad-key=1", "1st=1", "=1"} {
+		if _, err := ParseConfOptions([]string{invalid}); err == nil {
+			t.Fatalf("ParseConfOptions(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestParseEnvVars(t *testing.T) {
+	vars, err := ParseEnvVars([]string{"PGAPPNAME=odoo-dev", "API_URL=https://x.test/?a=b", "OLD_VAR="})
+	if err != nil {
+		t.Fatalf("ParseEnvVars() error = %v", err)
+	}
+	if vars["PGAPPNAME"] != "odoo-dev" || vars["API_URL"] != "https://x.test/?a=b" {
+		t.Fatalf("unexpected vars: %#v", vars)
+	}
+	if value, ok := vars["OLD_VAR"]; !ok || value != "" {
+		t.Fatalf("empty value should be kept for removal, got %#v", vars)
+	}
+
+	for _, invalid := range []string{"PGAPPNAME", "BAD-KEY=1", "1ST=1", "=1", "HOST=db2", "MULTI=a\nb"} {
+		if _, err := ParseEnvVars([]string{invalid}); err == nil {
+			t.Fatalf("ParseEnvVars(%q) expected error", invalid)
+		}
+	}
+
+	if got := MaskEnvValue("STRIPE_API_KEY", "sk_live_1234567890"); got == "sk_live_1234567890" {
+		t.Fatalf("MaskEnvValue() did not mask a secret-looking value: %q", got)
+	}
+	if got := MaskEnvValue("PGAPPNAME", "odoo-dev"); got != "odoo-dev" {
+		t.Fatalf("MaskEnvValue(PGAPPNAME) = %q, want it unmasked", got)
+	}
+}
+
+func TestLoadMigratesCopiedEnterpriseTokenToGlobalReference(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	token := "ghp_example123456"
+	if err := (&GlobalConfig{GitHubToken: token}).Save(); err != nil {
+		t.Fatalf("GlobalConfig.Save() error = %v", err)
+	}
+	state := &State{ProjectName: "repo", OdooVersion: "17.0", Branch: "main", Enterprise: true, EnterpriseGitHubToken: token}
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load("repo", "main")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.EnterpriseGitHubToken != "" || !loaded.UseGlobalEnterpriseAuth {
+		t.Fatalf("Load() token = %q, use global = %v, want reference form", loaded.EnterpriseGitHubToken, loaded.UseGlobalEnterpriseAuth)
+	}
+	dir, _ := EnvironmentDir("repo", "main")
+	data, err := os.ReadFile(filepath.Join(dir, StateFileName))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(data), token) {
+		t.Fatalf("state file still contains the token after migration")
+	}
+	if got, err := loaded.EnterpriseToken(); err != nil || got != token {
+		t.Fatalf("EnterpriseToken() = %q, %v, want %q", got, err, token)
+	}
+
+	if err := (&GlobalConfig{}).Save(); err != nil {
+		t.Fatalf("GlobalConfig.Save() error = %v", err)
+	}
+	if _, err := loaded.EnterpriseToken(); err == nil {
+		t.Fatal("EnterpriseToken() error = nil after global token was removed")
+	}
+}
+
+func TestLoadKeepsEnvironmentSpecificEnterpriseToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	state := &State{ProjectName: "repo", OdooVersion: "17.0", Branch: "main", Enterprise: true, EnterpriseGitHubToken: "ghp_only_here"}
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load("repo", "main")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.EnterpriseGitHubToken != "ghp_only_here" || loaded.UseGlobalEnterpriseAuth {
+		t.Fatalf("Load() migrated a token that is not in the global config")
+	}
+}
+
+func TestValidateImageRef(t *testing.T) {
+	valid := []string{
+		"odoo-dev:17.0",
+		"ghcr.io/acme/odoo-dev:17.0",
+		"localhost:5000/odoo-dev",
+		"registry.example.com/team/odoo@sha256:" + strings.Repeat("a", 64),
+	}
+	for _, ref := range valid {
+		if err := ValidateImageRef(ref); err != nil {
+			t.Errorf("ValidateImageRef(%q) error = %v", ref, err)
+		}
+	}
+
+	invalid := []string{"", "Odoo-Dev:17.0", "ghcr.io/acme/odoo dev", "odoo:", "odoo:-bad", "https://ghcr.io/acme/odoo"}
+	for _, ref := range invalid {
+		if err := ValidateImageRef(ref); err == nil {
+			t.Errorf("ValidateImageRef(%q) error = nil, want error", ref)
+		}
+	}
+}
+
+func TestCacheFromRefFallsBackToGlobalDefault(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := &GlobalConfig{CacheFrom: "ghcr.io/acme/odoo-dev:17.0"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	state := &State{}
+	if got := state.CacheFromRef(); got != "ghcr.io/acme/odoo-dev:17.0" {
+		t.Fatalf("CacheFromRef() = %q, want global default", got)
+	}
+
+	state.CacheFrom = "ghcr.io/acme/odoo-dev:feature"
+	if got := state.CacheFromRef(); got != "ghcr.io/acme/odoo-dev:feature" {
+		t.Fatalf("CacheFromRef() = %q, want environment setting", got)
+	}
+
+	state.CacheFrom = CacheFromNone
+	if got := state.CacheFromRef(); got != "" {
+		t.Fatalf("CacheFromRef() = %q, want empty when disabled", got)
+	}
+}
+
+func TestLoggingDefaultsAndValidation(t *testing.T) {
+	state := &State{}
+	if got := state.LoggingMaxSize(); got != DefaultLogMaxSize {
+		t.Fatalf("LoggingMaxSize() = %q, want %q", got, DefaultLogMaxSize)
+	}
+	if got := state.LoggingMaxFile(); got != DefaultLogMaxFile {
+		t.Fatalf("LoggingMaxFile() = %d, want %d", got, DefaultLogMaxFile)
+	}
+
+	for _, size := range []string{"10m", "512k", "1g"} {
+		if err := ValidateLogMaxSize(size); err != nil {
+			t.Errorf("ValidateLogMaxSize(%q) error = %v", size, err)
+		}
+	}
+	for _, size := range []string{"", "10", "0m", "10mb", "-1m"} {
+		if err := ValidateLogMaxSize(size); err == nil {
+			t.Errorf("ValidateLogMaxSize(%q) error = nil, want error", size)
+		}
+	}
+}
+
+func TestComposeProjectName(t *testing.T) {
+	for project, want := range map[string]string{
+		"shop":       "170-shop",
+		"My.Project": "170-my-project",
+		"acme_erp":   "170-acme_erp",
+	} {
+		state := &State{ProjectName: project, Branch: "main", OdooVersion: "17.0"}
+		if got := state.ComposeProjectName(); got != want {
+			t.Errorf("ComposeProjectName() for %q = %q, want %q", project, got, want)
+		}
+	}
+
+	state := &State{ProjectName: "shop", Branch: "main", OdooVersion: "17.0", ComposeProject: "shop-main"}
+	if got := state.ComposeProjectName(); got != "shop-main" {
+		t.Errorf("ComposeProjectName() with a stored name = %q, want shop-main", got)
+	}
+}
+
+func TestIsReservedProjectName(t *testing.T) {
+	for name, want := range map[string]bool{ProjectLinksDirName: true, PresetsDirName: true, "shop": false, "preset": false} {
+		if got := IsReservedProjectName(name); got != want {
+			t.Errorf("IsReservedProjectName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestNewComposeProjectName(t *testing.T) {
+	for _, tt := range []struct{ project, branch, want string }{
+		{"shop", "main", "shop-main"},
+		{"My.Project", "17.0-Feature", "my-project-17-0-feature"},
+		{"acme_erp", "fix/login", "acme_erp-fix-login"},
+	} {
+		if got := NewComposeProjectName(tt.project, tt.branch); got != tt.want {
+			t.Errorf("NewComposeProjectName(%q, %q) = %q, want %q", tt.project, tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestDBNameOverride(t *testing.T) {
+	state := &State{OdooVersion: "17.0"}
+	if got := state.DBName(); got != "odoo-170" {
+		t.Fatalf("DBName() = %q, want odoo-170", got)
+	}
+	state.DBNameOverride = "acme_prod"
+	if got := state.DBName(); got != "acme_prod" {
+		t.Fatalf("DBName() = %q, want acme_prod", got)
+	}
+
+	for _, name := range []string{"acme_prod", "odoo-170", "_tmp", strings.Repeat("a", 63)} {
+		if err := ValidateDBName(name); err != nil {
+			t.Errorf("ValidateDBName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "17prod", "acme.prod", "acme prod", "acme;drop", strings.Repeat("a", 64)} {
+		if err := ValidateDBName(name); err == nil {
+			t.Errorf("ValidateDBName(%q) error = nil, want error", name)
+		}
+	}
+}
+
+func TestValidateNetworkName(t *testing.T) {
+	for _, name := range []string{"proxy", "traefik_default", "shared.pg-net"} {
+		if err := ValidateNetworkName(name); err != nil {
+			t.Errorf("ValidateNetworkName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "-proxy", "my net", "proxy:1"} {
+		if err := ValidateNetworkName(name); err == nil {
+			t.Errorf("ValidateNetworkName(%q) error = nil, want error", name)
+		}
+	}
+}
+
+func TestValidateParamURL(t *testing.T) {
+	for _, value := range []string{"http://odoo:8069", "https://erp.example.com", "http://localhost:8069/odoo"} {
+		if err := ValidateParamURL(value); err != nil {
+			t.Errorf("ValidateParamURL(%q) error = %v", value, err)
+		}
+	}
+	for _, value := range []string{"", "odoo:8069", "ftp://odoo", "http://", `http://odoo:8069/"x"`, "http://odoo/a b"} {
+		if err := ValidateParamURL(value); err == nil {
+			t.Errorf("ValidateParamURL(%q) error = nil, want error", value)
+		}
+	}
+	if got := (&State{}).ReportURLParam(); got != DefaultReportURL {
+		t.Errorf("ReportURLParam() = %q, want %q", got, DefaultReportURL)
+	}
+}
+
+func TestAllEnvironments(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if environments, err := AllEnvironments(); err != nil || len(environments) != 0 {
+		t.Fatalf("AllEnvironments(no config dir) = %v, %v, want none", environments, err)
+	}
+
+	for _, state := range []*State{
+		{ProjectName: "shop", Branch: "main", OdooVersion: "17.0", ProjectRoot: home},
+		{ProjectName: "crm", Branch: "feature", OdooVersion: "18.0", ProjectRoot: home},
+		{ProjectName: "crm", Branch: "dev", OdooVersion: "18.0", ProjectRoot: home},
+	} {
+		if err := state.Save(); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := SaveProjectLink(state); err != nil {
+			t.Fatalf("SaveProjectLink() error = %v", err)
+		}
+	}
+	// A directory without a state file is not an environment
+	if err := os.MkdirAll(filepath.Join(home, ".odooctl", "shop", "stale"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Nor is anything under the reserved presets directory
+	reserved := &State{ProjectName: PresetsDirName, Branch: "main", OdooVersion: "17.0", ProjectRoot: home}
+	if err := reserved.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	environments, err := AllEnvironments()
+	if err != nil {
+		t.Fatalf("AllEnvironments() error = %v", err)
+	}
+	var names []string
+	for _, env := range environments {
+		names = append(names, env.State.ProjectName+"/"+env.State.Branch)
+	}
+	if got, want := strings.Join(names, " "), "crm/dev crm/feature shop/main"; got != want {
+		t.Fatalf("AllEnvironments() = %s, want %s", got, want)
+	}
+	if want := filepath.Join(home, ".odooctl", "crm", "dev"); environments[0].Dir != want {
+		t.Fatalf("Dir = %s, want %s", environments[0].Dir, want)
+	}
+}
+
+func TestFindAvailablePortsSkipsOtherEnvironments(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	base := CalculatePorts("17.0")
+	first := &State{ProjectName: "shop", OdooVersion: "17.0", Branch: "main", Ports: base, CreatedAt: time.Now()}
+	if err := first.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first environment is stopped, but its ports stay reserved for it
+	second := FindAvailablePorts("17.0", "crm", "main")
+	if second == base {
+		t.Fatalf("FindAvailablePorts() = %+v, want a block other than shop/main's", second)
+	}
+	if second.Odoo%10 != base.Odoo%10 || second.Odoo <= base.Odoo {
+		t.Errorf("FindAvailablePorts() = %+v, want the next block after %+v", second, base)
+	}
+	if own := FindAvailablePorts("17.0", "shop", "main"); own.overlaps(ReservedPorts("shop", "main")) {
+		t.Errorf("FindAvailablePorts(shop/main) = %+v, overlaps another environment", own)
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultEnterpriseRepoURL is Odoo's own Enterprise repository
+const DefaultEnterpriseRepoURL = "https://github.com/odoo/enterprise.git"
+
+// EnterpriseRepo is a parsed Enterprise repository URL. It can be cloned
+// over HTTPS with a token or over SSH, whichever form it was given in.
+type EnterpriseRepo struct {
+	Host      string // host name, e.g. github.com
+	HTTPSHost string // host[:port] for HTTPS clones
+	SSHUser   string
+	SSHPort   string // empty for the default port
+	Path      string // e.g. odoo/enterprise.git
+}
+
+// ParseEnterpriseRepo accepts https://host/path, ssh://[user@]host[:port]/path
+// and scp-like [user@]host:path URLs.
+func ParseEnterpriseRepo(rawURL string) (EnterpriseRepo, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	repo := EnterpriseRepo{SSHUser: "git"}
+
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return repo, fmt.Errorf("invalid repository URL %q: %w", rawURL, err)
+		}
+		repo.Host = u.Hostname()
+		repo.Path = strings.Trim(u.Path, "/")
+		switch u.Scheme {
+		case "https":
+			if u.User != nil {
+				return repo, fmt.Errorf("repository URL %q contains credentials; choose token authentication instead", rawURL)
+			}
+			repo.HTTPSHost = u.Host
+		case "ssh":
+			if u.User != nil {
+				repo.SSHUser = u.User.Username()
+			}
+			repo.SSHPort = u.Port()
+			repo.HTTPSHost = repo.Host
+		default:
+			return repo, fmt.Errorf("unsupported repository URL scheme %q (use https, ssh or user@host:path)", u.Scheme)
+		}
+	} else {
+		hostPart, path, ok := strings.Cut(rawURL, ":")
+		if !ok {
+			return repo, fmt.Errorf("invalid repository URL %q (use https://host/path, ssh://host/path or user@host:path)", rawURL)
+		}
+		if user, host, ok := strings.Cut(hostPart, "@"); ok {
+			repo.SSHUser = user
+			hostPart = host
+		}
+		repo.Host = hostPart
+		repo.HTTPSHost = hostPart
+		repo.Path = strings.Trim(path, "/")
+	}
+
+	if repo.Host == "" || repo.Path == "" || repo.SSHUser == "" {
+		return repo, fmt.Errorf("invalid repository URL %q: host and path are required", rawURL)
+	}
+	return repo, nil
+}
+
+// IsGitHub reports whether the repository is hosted on github.com, where
+// tokens have a known format and keys are managed in GitHub settings
+func (r EnterpriseRepo) IsGitHub() bool {
+	return strings.EqualFold(r.Host, "github.com")
+}
+
+// HTTPSURL returns the repository's HTTPS URL without credentials
+func (r EnterpriseRepo) HTTPSURL() string {
+	return "https://" + r.HTTPSHost + "/" + r.Path
+}
+
+// SSHURL returns the repository's SSH URL, in scp-like form unless a port
+// is needed
+func (r EnterpriseRepo) SSHURL() string {
+	if r.SSHPort != "" {
+		return fmt.Sprintf("ssh://%s@%s:%s/%s", r.SSHUser, r.Host, r.SSHPort, r.Path)
+	}
+	return fmt.Sprintf("%s@%s:%s", r.SSHUser, r.Host, r.Path)
+}
+
+// EnterpriseRepo returns the environment's Enterprise repository, Odoo's
+// GitHub repository unless EnterpriseRepoURL is set
+func (s *State) EnterpriseRepo() (EnterpriseRepo, error) {
+	if s.EnterpriseRepoURL == "" {
+		return ParseEnterpriseRepo(DefaultEnterpriseRepoURL)
+	}
+	return ParseEnterpriseRepo(s.EnterpriseRepoURL)
+}
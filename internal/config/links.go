@@ -0,0 +1,138 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// StaleProjectLinkError is returned by LoadFromDir when the project link for
+// the directory pointed at a deleted environment and no other environment
+// was found. The link has already been removed.
+type StaleProjectLinkError struct {
+	Path   string // the removed link file
+	EnvDir string // the environment it pointed at
+}
+
+func (e *StaleProjectLinkError) Error() string {
+	return fmt.Sprintf("removed stale project link %s: environment %s no longer exists", e.Path, e.EnvDir)
+}
+
+func (e *StaleProjectLinkError) Unwrap() error {
+	return os.ErrNotExist
+}
+
+// ProjectLinkStatus is the result of checking one project link
+type ProjectLinkStatus struct {
+	Path        string `json:"path"`
+	ProjectRoot string `json:"project_root,omitempty"`
+	EnvDir      string `json:"env_dir,omitempty"`
+	// Problem is empty for a valid link
+	Problem string `json:"problem,omitempty"`
+	// RepairEnvDir is another environment of the same project root the link
+	// can point at instead
+	RepairEnvDir string `json:"repair_env_dir,omitempty"`
+}
+
+// Broken reports whether the link needs repairing or removing
+func (s ProjectLinkStatus) Broken() bool {
+	return s.Problem != ""
+}
+
+// CheckProjectLinks validates every project link: it must be readable, its
+// environment must exist and belong to its project root, and the project
+// root must still exist.
+func CheckProjectLinks() ([]ProjectLinkStatus, error) {
+	linksDir, err := ProjectLinksDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(linksDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	environments, err := AllEnvironments()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []ProjectLinkStatus
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		statuses = append(statuses, checkProjectLink(filepath.Join(linksDir, entry.Name()), environments))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ProjectRoot < statuses[j].ProjectRoot
+	})
+	return statuses, nil
+}
+
+func checkProjectLink(path string, environments []Environment) ProjectLinkStatus {
+	status := ProjectLinkStatus{Path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		status.Problem = fmt.Sprintf("unreadable: %v", err)
+		return status
+	}
+	var link ProjectLink
+	if err := json.Unmarshal(data, &link); err != nil {
+		status.Problem = fmt.Sprintf("invalid JSON: %v", err)
+		return status
+	}
+	status.ProjectRoot = link.ProjectRoot
+	status.EnvDir = link.EnvDir
+
+	if _, err := os.Stat(link.ProjectRoot); os.IsNotExist(err) {
+		status.Problem = "project directory no longer exists"
+		return status
+	}
+	state, err := loadStateFromEnvDir(link.EnvDir)
+	switch {
+	case os.IsNotExist(err):
+		status.Problem = "environment no longer exists"
+	case err != nil:
+		status.Problem = fmt.Sprintf("environment state unreadable: %v", err)
+	case !sameOrChild(link.ProjectRoot, state.ProjectRoot):
+		status.Problem = fmt.Sprintf("environment belongs to %s", state.ProjectRoot)
+	default:
+		return status
+	}
+
+	for _, env := range environments {
+		if env.Dir != link.EnvDir && sameOrChild(link.ProjectRoot, env.State.ProjectRoot) {
+			status.RepairEnvDir = env.Dir
+			break
+		}
+	}
+	return status
+}
+
+// Repair points a broken link at RepairEnvDir
+func (s ProjectLinkStatus) Repair() error {
+	if s.RepairEnvDir == "" {
+		return fmt.Errorf("no environment found for %s", s.ProjectRoot)
+	}
+	state, err := loadStateFromEnvDir(s.RepairEnvDir)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return SaveProjectLink(state)
+}
+
+// Remove deletes the link file
+func (s ProjectLinkStatus) Remove() error {
+	if err := os.Remove(s.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func saveLinkedState(t *testing.T, projectRoot, branch string) *State {
+	t.Helper()
+	state := &State{ProjectName: "repo", OdooVersion: "19.0", Branch: branch, ProjectRoot: projectRoot, CreatedAt: time.Now()}
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := SaveProjectLink(state); err != nil {
+		t.Fatalf("SaveProjectLink() error = %v", err)
+	}
+	return state
+}
+
+func TestLoadFromDirRemovesStaleProjectLink(t *testing.T) {
+	home := t.TempDir()
+	projectRoot := filepath.Join(home, "repo")
+	if err := os.MkdirAll(projectRoot, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", home)
+
+	state := saveLinkedState(t, projectRoot, "main")
+	envDir, _ := EnvironmentDir(state.ProjectName, state.Branch)
+	if err := os.RemoveAll(envDir); err != nil {
+		t.Fatal(err)
+	}
+	linkPath, _ := ProjectLinkPath(projectRoot)
+
+	_, err := LoadFromDir(projectRoot)
+	var stale *StaleProjectLinkError
+	if !errors.As(err, &stale) || stale.Path != linkPath || !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("LoadFromDir() error = %v, want a stale link error for %s", err, linkPath)
+	}
+	if _, err := os.Stat(linkPath); !os.IsNotExist(err) {
+		t.Fatalf("stale link still exists: %v", err)
+	}
+}
+
+func TestLoadFromDirReplacesStaleProjectLink(t *testing.T) {
+	home := t.TempDir()
+	projectRoot := filepath.Join(home, "repo")
+	if err := os.MkdirAll(projectRoot, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", home)
+
+	saveLinkedState(t, projectRoot, "feature")
+	saveLinkedState(t, projectRoot, "main")
+	mainDir, _ := EnvironmentDir("repo", "main")
+	if err := os.RemoveAll(mainDir); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadFromDir(projectRoot)
+	if err != nil || loaded.Branch != "feature" {
+		t.Fatalf("LoadFromDir() = %v, %v, want the feature environment", loaded, err)
+	}
+	link, err := LoadProjectLink(projectRoot)
+	if err != nil || link.Branch != "feature" {
+		t.Fatalf("LoadProjectLink() = %v, %v, want a link to feature", link, err)
+	}
+}
+
+func TestCheckProjectLinks(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	valid := filepath.Join(home, "valid")
+	repairable := filepath.Join(home, "repairable")
+	gone := filepath.Join(home, "gone")
+	for _, dir := range []string{valid, repairable, gone} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saveLinkedState(t, valid, "valid")
+	saveLinkedState(t, repairable, "other")
+	saveLinkedState(t, repairable, "deleted")
+	deletedDir, _ := EnvironmentDir("repo", "deleted")
+	if err := os.RemoveAll(deletedDir); err != nil {
+		t.Fatal(err)
+	}
+	saveLinkedState(t, gone, "gone")
+	if err := os.RemoveAll(gone); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := CheckProjectLinks()
+	if err != nil {
+		t.Fatalf("CheckProjectLinks() error = %v", err)
+	}
+	byRoot := map[string]ProjectLinkStatus{}
+	for _, status := range statuses {
+		byRoot[status.ProjectRoot] = status
+	}
+	if status := byRoot[valid]; status.Broken() {
+		t.Errorf("valid link reported broken: %+v", status)
+	}
+	otherDir, _ := EnvironmentDir("repo", "other")
+	status := byRoot[repairable]
+	if !status.Broken() || status.RepairEnvDir != otherDir {
+		t.Fatalf("repairable link = %+v, want a repair to %s", status, otherDir)
+	}
+	if status := byRoot[gone]; !status.Broken() || status.RepairEnvDir != "" {
+		t.Errorf("link to a deleted directory = %+v, want broken without repair", status)
+	}
+
+	if err := status.Repair(); err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+	if link, err := LoadProjectLink(repairable); err != nil || link.EnvDir != otherDir {
+		t.Fatalf("repaired link = %v, %v, want %s", link, err, otherDir)
+	}
+	if err := byRoot[gone].Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(byRoot[gone].Path); !os.IsNotExist(err) {
+		t.Fatalf("removed link still exists: %v", err)
+	}
+}
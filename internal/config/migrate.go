@@ -0,0 +1,52 @@
+package config
+
+// TokenMigration is the outcome of MigrateEnterpriseTokens
+type TokenMigration struct {
+	// GlobalTokenSaved is set when the global config had no token and
+	// took the first one found in an environment
+	GlobalTokenSaved bool
+	// Migrated environments now reference the global token
+	Migrated []*State
+	// Kept environments hold a token other than the global one, which is
+	// left in place as an explicit override
+	Kept []*State
+}
+
+// MigrateEnterpriseTokens moves enterprise tokens stored in environment
+// state files into the global config, leaving a reference in each state.
+func MigrateEnterpriseTokens() (*TokenMigration, error) {
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		return nil, err
+	}
+	environments, err := allEnvironments(readStateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TokenMigration{}
+	for _, env := range environments {
+		state := env.State
+		if state.EnterpriseGitHubToken == "" {
+			continue
+		}
+		if cfg.GitHubToken == "" {
+			cfg.GitHubToken = state.EnterpriseGitHubToken
+			if err := cfg.Save(); err != nil {
+				return result, err
+			}
+			result.GlobalTokenSaved = true
+		}
+		if state.EnterpriseGitHubToken != cfg.GitHubToken {
+			result.Kept = append(result.Kept, state)
+			continue
+		}
+		state.EnterpriseGitHubToken = ""
+		state.UseGlobalEnterpriseAuth = true
+		if err := state.Save(); err != nil {
+			return result, err
+		}
+		result.Migrated = append(result.Migrated, state)
+	}
+	return result, nil
+}
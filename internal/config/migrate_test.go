@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateEnterpriseTokens(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, state := range []*State{
+		{ProjectName: "a", OdooVersion: "17.0", Branch: "main", Enterprise: true, EnterpriseGitHubToken: "ghp_first_token"},
+		{ProjectName: "b", OdooVersion: "17.0", Branch: "main", Enterprise: true, EnterpriseGitHubToken: "ghp_first_token"},
+		{ProjectName: "c", OdooVersion: "17.0", Branch: "main", Enterprise: true, EnterpriseGitHubToken: "ghp_other_token"},
+		{ProjectName: "d", OdooVersion: "17.0", Branch: "main"},
+	} {
+		if err := state.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := MigrateEnterpriseTokens()
+	if err != nil {
+		t.Fatalf("MigrateEnterpriseTokens() error = %v", err)
+	}
+	if !result.GlobalTokenSaved || len(result.Migrated) != 2 || len(result.Kept) != 1 || result.Kept[0].ProjectName != "c" {
+		t.Fatalf("MigrateEnterpriseTokens() = %+v, want a and b migrated and c kept", result)
+	}
+	cfg, err := LoadGlobalConfig()
+	if err != nil || cfg.GitHubToken != "ghp_first_token" {
+		t.Fatalf("global token = %q, %v, want the first environment's", cfg.GitHubToken, err)
+	}
+	for _, project := range []string{"a", "b"} {
+		dir, _ := EnvironmentDir(project, "main")
+		data, err := os.ReadFile(filepath.Join(dir, StateFileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "ghp_first_token") || !strings.Contains(string(data), `"use_global_enterprise_auth": true`) {
+			t.Fatalf("state of %s was not migrated:\n%s", project, data)
+		}
+	}
+
+	// Nothing is left to do on a second run
+	result, err = MigrateEnterpriseTokens()
+	if err != nil || result.GlobalTokenSaved || len(result.Migrated) != 0 || len(result.Kept) != 1 {
+		t.Fatalf("second MigrateEnterpriseTokens() = %+v, %v", result, err)
+	}
+}
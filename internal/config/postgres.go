@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// DefaultPostgresVersion is the postgres image tag used when an environment
+// doesn't choose one. Environments for Odoo versions that don't support it get
+// DefaultPostgresVersionFor instead when they are created.
+const DefaultPostgresVersion = "15"
+
+// postgresSupport is the range of Postgres majors each Odoo version is known
+// to work with. Older Odoo releases use client libraries and SQL that newer
+// servers break, and newer ones rely on features older servers lack.
+var postgresSupport = map[string][2]int{
+	"12.0": {10, 12},
+	"13.0": {10, 13},
+	"14.0": {10, 14},
+	"15.0": {10, 15},
+	"16.0": {12, 16},
+	"17.0": {12, 16},
+	"18.0": {13, 17},
+	"19.0": {13, 17},
+}
+
+var postgresVersionPattern = regexp.MustCompile(`^([1-9][0-9]*)(\.[0-9]+)?(-[a-z0-9.]+)?$`)
+
+// PostgresImageVersion returns the postgres image tag, defaulting to
+// DefaultPostgresVersion
+func (s *State) PostgresImageVersion() string {
+	if s.PostgresVersion == "" {
+		return DefaultPostgresVersion
+	}
+	return s.PostgresVersion
+}
+
+// DefaultPostgresVersionFor returns the postgres image tag for a new
+// environment of odooVersion: DefaultPostgresVersion, or the newest major the
+// Odoo version supports when that is older
+func DefaultPostgresVersionFor(odooVersion string) string {
+	supported, ok := postgresSupport[odooVersion]
+	if ok && supported[1] < PostgresMajor(DefaultPostgresVersion) {
+		return strconv.Itoa(supported[1])
+	}
+	return DefaultPostgresVersion
+}
+
+// ValidatePostgresVersion checks a postgres image tag such as 16, 16.4 or
+// 16-alpine
+func ValidatePostgresVersion(version string) error {
+	if !postgresVersionPattern.MatchString(version) {
+		return fmt.Errorf("invalid Postgres version %q (expected a postgres image tag such as 16, 16.4 or 16-alpine)", version)
+	}
+	return nil
+}
+
+// PostgresMajor returns the major version of a postgres image tag, or 0
+func PostgresMajor(version string) int {
+	match := postgresVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return 0
+	}
+	major, _ := strconv.Atoi(match[1])
+	return major
+}
+
+// PostgresCompatibilityWarning describes why a Postgres version is risky for
+// an Odoo version, or returns "" when the combination is known to work or
+// the Odoo version is unknown
+func PostgresCompatibilityWarning(odooVersion, postgresVersion string) string {
+	supported, ok := postgresSupport[odooVersion]
+	if !ok {
+		return ""
+	}
+	major := PostgresMajor(postgresVersion)
+	switch {
+	case major == 0:
+		return ""
+	case major < supported[0]:
+		return fmt.Sprintf("Postgres %d is older than Odoo %s supports (%d-%d)", major, odooVersion, supported[0], supported[1])
+	case major > supported[1]:
+		return fmt.Sprintf("Postgres %d is newer than Odoo %s is known to work with (%d-%d)", major, odooVersion, supported[0], supported[1])
+	}
+	return ""
+}
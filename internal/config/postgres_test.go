@@ -0,0 +1,54 @@
+package config
+
+import "testing"
+
+func TestPostgresVersion(t *testing.T) {
+	state := &State{}
+	if got := state.PostgresImageVersion(); got != DefaultPostgresVersion {
+		t.Fatalf("PostgresImageVersion() = %q, want %q", got, DefaultPostgresVersion)
+	}
+
+	for _, version := range []string{"16", "16.4", "16-alpine", "13.12-bookworm"} {
+		if err := ValidatePostgresVersion(version); err != nil {
+			t.Errorf("ValidatePostgresVersion(%q) error = %v", version, err)
+		}
+	}
+	for _, version := range []string{"", "latest", "0", "16.", "postgres:16", "16 "} {
+		if err := ValidatePostgresVersion(version); err == nil {
+			t.Errorf("ValidatePostgresVersion(%q) error = nil, want error", version)
+		}
+	}
+	if got := PostgresMajor("16.4-alpine"); got != 16 {
+		t.Errorf("PostgresMajor() = %d, want 16", got)
+	}
+}
+
+func TestDefaultPostgresVersionFor(t *testing.T) {
+	for odoo, want := range map[string]string{"12.0": "12", "14.0": "14", "15.0": "15", "17.0": "15", "19.0": "15", "20.0": "15"} {
+		got := DefaultPostgresVersionFor(odoo)
+		if got != want {
+			t.Errorf("DefaultPostgresVersionFor(%q) = %q, want %q", odoo, got, want)
+		}
+		if warning := PostgresCompatibilityWarning(odoo, got); warning != "" {
+			t.Errorf("DefaultPostgresVersionFor(%q) = %q is unsupported: %s", odoo, got, warning)
+		}
+	}
+}
+
+func TestPostgresCompatibilityWarning(t *testing.T) {
+	tests := []struct {
+		odoo, postgres string
+		warn           bool
+	}{
+		{"17.0", "15", false},
+		{"17.0", "16-alpine", false},
+		{"12.0", "16", true},
+		{"19.0", "12", true},
+		{"20.0", "9", false},
+	}
+	for _, tt := range tests {
+		if got := PostgresCompatibilityWarning(tt.odoo, tt.postgres); (got != "") != tt.warn {
+			t.Errorf("PostgresCompatibilityWarning(%q, %q) = %q, want warning %v", tt.odoo, tt.postgres, got, tt.warn)
+		}
+	}
+}
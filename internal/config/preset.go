@@ -0,0 +1,139 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const PresetsDirName = "presets"
+
+// Preset is a named bundle of 'docker create' settings stored in
+// ~/.odooctl/presets/{name}.json. Flags passed to create override it.
+type Preset struct {
+	Name             string   `json:"name"`
+	OdooVersion      string   `json:"odoo_version,omitempty"`
+	Modules          []string `json:"modules,omitempty"`
+	PipPackages      []string `json:"pip_packages,omitempty"`
+	AddonsPaths      []string `json:"addons_paths,omitempty"`
+	ConfOptions      []string `json:"conf_options,omitempty"` // key=value entries, as passed to --conf
+	Enterprise       bool     `json:"enterprise,omitempty"`
+	WithoutDemo      bool     `json:"without_demo,omitempty"`
+	Browser          bool     `json:"browser,omitempty"`
+	AutoDiscoverDeps bool     `json:"auto_discover_deps,omitempty"`
+}
+
+// PresetsDir returns ~/.odooctl/presets
+func PresetsDir() (string, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, PresetsDirName), nil
+}
+
+// ValidatePresetName checks that name is usable as a preset file name
+func ValidatePresetName(name string) error {
+	if name == "" || SanitizeName(name) != name || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid preset name %q (use letters, digits, '-', '_' or '.')", name)
+	}
+	return nil
+}
+
+func presetPath(name string) (string, error) {
+	if err := ValidatePresetName(name); err != nil {
+		return "", err
+	}
+	dir, err := PresetsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// LoadPreset reads ~/.odooctl/presets/{name}.json
+func LoadPreset(name string) (*Preset, error) {
+	path, err := presetPath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("preset %q not found (see 'odooctl config preset list')", name)
+		}
+		return nil, err
+	}
+	var preset Preset
+	if err := json.Unmarshal(data, &preset); err != nil {
+		return nil, fmt.Errorf("failed to parse preset %q: %w", name, err)
+	}
+	preset.Name = name
+	return &preset, nil
+}
+
+// Save writes the preset to ~/.odooctl/presets/{name}.json
+func (p *Preset) Save() error {
+	path, err := presetPath(p.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := ParseConfOptions(p.ConfOptions); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ListPresets returns every saved preset, sorted by name
+func ListPresets() ([]*Preset, error) {
+	dir, err := PresetsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var presets []*Preset
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		preset, err := LoadPreset(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets, nil
+}
+
+// DeletePreset removes ~/.odooctl/presets/{name}.json
+func DeletePreset(name string) error {
+	path, err := presetPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("preset %q not found", name)
+		}
+		return err
+	}
+	return nil
+}
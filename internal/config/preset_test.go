@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPresetSaveLoadListDelete(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	presets := []*Preset{
+		{Name: "ecommerce", OdooVersion: "17.0", Modules: []string{"website_sale", "stock"}, PipPackages: []string{"stripe"}},
+		{Name: "accounting", Modules: []string{"account"}, WithoutDemo: true, ConfOptions: []string{"workers=2"}},
+	}
+	for _, preset := range presets {
+		if err := preset.Save(); err != nil {
+			t.Fatalf("Save(%s) error = %v", preset.Name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(home, ".odooctl", PresetsDirName, "ecommerce.json")); err != nil {
+		t.Fatalf("preset file not written: %v", err)
+	}
+
+	loaded, err := LoadPreset("ecommerce")
+	if err != nil {
+		t.Fatalf("LoadPreset() error = %v", err)
+	}
+	if loaded.OdooVersion != "17.0" || len(loaded.Modules) != 2 || loaded.PipPackages[0] != "stripe" {
+		t.Fatalf("LoadPreset() = %+v", loaded)
+	}
+
+	list, err := ListPresets()
+	if err != nil {
+		t.Fatalf("ListPresets() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "accounting" || list[1].Name != "ecommerce" {
+		t.Fatalf("ListPresets() = %+v, want accounting and ecommerce", list)
+	}
+
+	if err := DeletePreset("accounting"); err != nil {
+		t.Fatalf("DeletePreset() error = %v", err)
+	}
+	if _, err := LoadPreset("accounting"); err == nil {
+		t.Fatal("LoadPreset() succeeded for a deleted preset")
+	}
+	if err := DeletePreset("accounting"); err == nil {
+		t.Fatal("DeletePreset() succeeded twice")
+	}
+}
+
+func TestPresetRejectsInvalidInput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, name := range []string{"", "../escape", "a b", ".hidden"} {
+		if err := (&Preset{Name: name}).Save(); err == nil {
+			t.Errorf("Save() accepted preset name %q", name)
+		}
+	}
+	if err := (&Preset{Name: "bad", ConfOptions: []string{"no-equals"}}).Save(); err == nil {
+		t.Error("Save() accepted an invalid conf option")
+	}
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ProjectEnvironments loads every environment state stored under ~/.odooctl/{project}
+func ProjectEnvironments(projectName string) ([]*State, error) {
+	projectDir, err := ProjectDir(projectName)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var states []*State
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		state, err := loadStateFromEnvDir(filepath.Join(projectDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Branch < states[j].Branch })
+	return states, nil
+}
+
+// ValidateProjectRename checks that oldName exists and newName is a free,
+// already sanitized project name.
+func ValidateProjectRename(oldName, newName string) error {
+	if !IsValidName(newName) || IsReservedProjectName(newName) {
+		return fmt.Errorf("invalid project name %q (use letters, digits, '-', '_' or '.', e.g. %q)", newName, SanitizeName(newName))
+	}
+	if oldName == newName {
+		return fmt.Errorf("project is already named %q", newName)
+	}
+	oldDir, err := ProjectDir(oldName)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(oldDir); err != nil || !info.IsDir() || IsReservedProjectName(oldName) {
+		return fmt.Errorf("project %q not found in %s", oldName, filepath.Dir(oldDir))
+	}
+	newDir, err := ProjectDir(newName)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(newDir); err == nil {
+		return fmt.Errorf("project %q already exists", newName)
+	}
+	return nil
+}
+
+// RenameProject moves ~/.odooctl/{old} to ~/.odooctl/{new}, rewrites the
+// project name in every state file and repoints project links. It returns the
+// renamed environments.
+func RenameProject(oldName, newName string) ([]*State, error) {
+	if err := ValidateProjectRename(oldName, newName); err != nil {
+		return nil, err
+	}
+	oldDir, err := ProjectDir(oldName)
+	if err != nil {
+		return nil, err
+	}
+	newDir, err := ProjectDir(newName)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(oldDir, newDir); err != nil {
+		return nil, fmt.Errorf("failed to move %s: %w", oldDir, err)
+	}
+
+	states, err := ProjectEnvironments(newName)
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range states {
+		state.ProjectName = newName
+		// Legacy environments keep sharing one "{version}-{project}" per version
+		if state.ComposeProject != "" {
+			state.ComposeProject = NewComposeProjectName(newName, state.Branch)
+		}
+		if err := state.Save(); err != nil {
+			return nil, fmt.Errorf("failed to update state for %s: %w", state.Branch, err)
+		}
+		cleanupLegacyMarker(state.ProjectRoot)
+	}
+
+	if err := renameProjectLinks(oldName, newName, oldDir, newDir); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// ValidateEnvironmentRename checks that newBranch is a free, already
+// sanitized environment name in state's project, and that the compose project
+// the renamed environment would get isn't used by another environment.
+func ValidateEnvironmentRename(state *State, newBranch string) error {
+	if !IsValidName(newBranch) {
+		return fmt.Errorf("invalid environment name %q (use letters, digits, '-', '_' or '.', e.g. %q)", newBranch, SanitizeName(newBranch))
+	}
+	if newBranch == state.Branch {
+		return fmt.Errorf("environment is already named %q", newBranch)
+	}
+	if EnvironmentExists(state.ProjectName, newBranch) {
+		return fmt.Errorf("environment '%s/%s' already exists", state.ProjectName, newBranch)
+	}
+	if state.ComposeProject != "" {
+		return CheckComposeProjectFree(NewComposeProjectName(state.ProjectName, newBranch), state.ProjectName, state.Branch)
+	}
+	return nil
+}
+
+// RenameEnvironment moves the environment directory of state from
+// ~/.odooctl/{project}/{branch} to ~/.odooctl/{project}/{newBranch}, saves
+// the state under the new branch and repoints the project links that used it.
+// Environments with a stored compose project move to
+// NewComposeProjectName(project, newBranch); legacy ones keep the shared
+// "{version}-{project}".
+func RenameEnvironment(state *State, newBranch string) error {
+	if err := ValidateEnvironmentRename(state, newBranch); err != nil {
+		return err
+	}
+	oldDir, err := EnvironmentDir(state.ProjectName, state.Branch)
+	if err != nil {
+		return err
+	}
+	newDir, err := EnvironmentDir(state.ProjectName, newBranch)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(newDir); err == nil {
+		return fmt.Errorf("environment '%s/%s' already exists", state.ProjectName, newBranch)
+	}
+	if err := os.Rename(oldDir, newDir); err != nil {
+		return fmt.Errorf("failed to move %s: %w", oldDir, err)
+	}
+
+	state.Branch = newBranch
+	if state.ComposeProject != "" {
+		state.ComposeProject = NewComposeProjectName(state.ProjectName, newBranch)
+	}
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return repointProjectLinks(oldDir, newDir, newBranch)
+}
+
+// repointProjectLinks moves every project link to oldEnvDir over to newEnvDir
+func repointProjectLinks(oldEnvDir, newEnvDir, newBranch string) error {
+	linksDir, err := ProjectLinksDir()
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(linksDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(linksDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var link ProjectLink
+		if err := json.Unmarshal(data, &link); err != nil || filepath.Clean(link.EnvDir) != filepath.Clean(oldEnvDir) {
+			continue
+		}
+		link.EnvDir = newEnvDir
+		link.Branch = newBranch
+		data, err = json.MarshalIndent(link, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return fmt.Errorf("failed to update project link %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// renameProjectLinks rewrites every project link that pointed into the old project directory
+func renameProjectLinks(oldName, newName, oldDir, newDir string) error {
+	linksDir, err := ProjectLinksDir()
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(linksDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(linksDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var link ProjectLink
+		if err := json.Unmarshal(data, &link); err != nil || link.ProjectName != oldName {
+			continue
+		}
+		rel, err := filepath.Rel(oldDir, link.EnvDir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		link.ProjectName = newName
+		link.EnvDir = filepath.Join(newDir, rel)
+		data, err = json.MarshalIndent(link, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return fmt.Errorf("failed to update project link %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenameProjectMovesEnvironmentsAndLinks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	projectRoot := t.TempDir()
+
+	for _, branch := range []string{"main", "feature-x"} {
+		state := &State{ProjectName: "shop", Branch: branch, OdooVersion: "17.0", ProjectRoot: projectRoot}
+		if branch == "feature-x" {
+			state.ComposeProject = NewComposeProjectName("shop", branch)
+		}
+		if err := state.Save(); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := SaveProjectLink(state); err != nil {
+			t.Fatalf("SaveProjectLink() error = %v", err)
+		}
+	}
+
+	states, err := RenameProject("shop", "webshop")
+	if err != nil {
+		t.Fatalf("RenameProject() error = %v", err)
+	}
+	if len(states) != 2 {
+		t.Fatalf("RenameProject() renamed %d environments, want 2", len(states))
+	}
+
+	oldDir, _ := ProjectDir("shop")
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Fatalf("old project directory still exists: %v", err)
+	}
+	for _, branch := range []string{"main", "feature-x"} {
+		state, err := Load("webshop", branch)
+		if err != nil {
+			t.Fatalf("Load(webshop, %s) error = %v", branch, err)
+		}
+		if state.ProjectName != "webshop" {
+			t.Fatalf("ProjectName = %q, want webshop", state.ProjectName)
+		}
+		want := map[string]string{"main": "170-webshop", "feature-x": "webshop-feature-x"}[branch]
+		if state.ComposeProjectName() != want {
+			t.Fatalf("ComposeProjectName() = %q, want %q", state.ComposeProjectName(), want)
+		}
+	}
+
+	link, err := LoadProjectLink(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadProjectLink() error = %v", err)
+	}
+	newDir, _ := ProjectDir("webshop")
+	if link.ProjectName != "webshop" || filepath.Dir(link.EnvDir) != newDir {
+		t.Fatalf("link = %+v, want it to point into %s", link, newDir)
+	}
+	state, err := LoadFromDir(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadFromDir() error = %v", err)
+	}
+	if state.ProjectName != "webshop" {
+		t.Fatalf("LoadFromDir() ProjectName = %q, want webshop", state.ProjectName)
+	}
+}
+
+func TestValidateProjectRename(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, name := range []string{"shop", "other"} {
+		state := &State{ProjectName: name, Branch: "main", OdooVersion: "17.0"}
+		if err := state.Save(); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		oldName, newName string
+		wantErr          bool
+	}{
+		{"shop", "webshop", false},
+		{"shop", "web/shop", true},
+		{"shop", "", true},
+		{"shop", "shop", true},
+		{"shop", "other", true},
+		{"missing", "webshop", true},
+		{"shop", ProjectLinksDirName, true},
+		{"shop", ".", true},
+		{"shop", "..", true},
+		{"shop", "my.shop", false},
+	}
+	for _, tt := range tests {
+		err := ValidateProjectRename(tt.oldName, tt.newName)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateProjectRename(%q, %q) error = %v, wantErr %v", tt.oldName, tt.newName, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRenameEnvironmentMovesStateAndLink(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	projectRoot := t.TempDir()
+
+	other := &State{ProjectName: "shop", Branch: "taken", OdooVersion: "17.0", ProjectRoot: t.TempDir()}
+	if err := other.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	state := &State{ProjectName: "shop", Branch: "feature-x", OdooVersion: "17.0", ProjectRoot: projectRoot, ComposeProject: NewComposeProjectName("shop", "feature-x")}
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := SaveProjectLink(state); err != nil {
+		t.Fatalf("SaveProjectLink() error = %v", err)
+	}
+
+	for _, name := range []string{"taken", "feature-x", "bad/name", "", ".", ".."} {
+		if err := RenameEnvironment(state, name); err == nil {
+			t.Errorf("RenameEnvironment(%q) error = nil", name)
+		}
+	}
+
+	if err := RenameEnvironment(state, "feature-y"); err != nil {
+		t.Fatalf("RenameEnvironment() error = %v", err)
+	}
+	if EnvironmentExists("shop", "feature-x") {
+		t.Fatal("old environment still exists")
+	}
+	if loaded, err := Load("shop", "feature-y"); err != nil || loaded.Branch != "feature-y" || loaded.ComposeProjectName() != "shop-feature-y" {
+		t.Fatalf("Load(shop, feature-y) = %v, %v, want compose project shop-feature-y", loaded, err)
+	}
+	loaded, err := LoadFromDir(projectRoot)
+	if err != nil || loaded.Branch != "feature-y" {
+		t.Fatalf("LoadFromDir() = %v, %v, want the renamed environment", loaded, err)
+	}
+	if link, err := LoadProjectLink(projectRoot); err != nil || link.Branch != "feature-y" {
+		t.Fatalf("LoadProjectLink() = %+v, %v", link, err)
+	}
+}
+
+func TestCheckComposeProjectFree(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	state := &State{ProjectName: "shop", Branch: "fix-login", OdooVersion: "17.0", ComposeProject: NewComposeProjectName("shop", "fix-login")}
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := CheckComposeProjectFree(NewComposeProjectName("shop", "fix/login"), "shop", "fix/login"); err == nil {
+		t.Error("CheckComposeProjectFree() error = nil for a name another environment uses")
+	}
+	if err := CheckComposeProjectFree("shop-fix-login", "shop", "fix-login"); err != nil {
+		t.Errorf("CheckComposeProjectFree() for the environment's own name error = %v", err)
+	}
+	if err := CheckComposeProjectFree("shop-main", "shop", "main"); err != nil {
+		t.Errorf("CheckComposeProjectFree(shop-main) error = %v", err)
+	}
+
+	renamed := &State{ProjectName: "shop", Branch: "main", OdooVersion: "17.0", ComposeProject: NewComposeProjectName("shop", "main")}
+	if err := renamed.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := ValidateEnvironmentRename(renamed, "fix.login"); err == nil {
+		t.Error("ValidateEnvironmentRename(fix.login) error = nil, want its compose project shop-fix-login taken")
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -47,3 +49,155 @@ func SanitizeName(name string) string {
 
 	return name
 }
+
+// IsValidName reports whether name can be used as is for a project or
+// environment directory: already sanitized and not "." or ".." (or any other
+// name of only dots), which would resolve outside ~/.odooctl/{project}
+func IsValidName(name string) bool {
+	return name != "" && SanitizeName(name) == name && filepath.Base(name) == name && strings.Trim(name, ".") != ""
+}
+
+// IsReservedProjectName reports whether name is a directory odooctl keeps
+// next to the projects in ~/.odooctl
+func IsReservedProjectName(name string) bool {
+	return name == ProjectLinksDirName || name == PresetsDirName
+}
+
+var confKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseConfOptions parses repeated key=value entries for odoo.conf [options].
+// An entry with an empty value (key=) is kept so callers can treat it as a removal.
+func ParseConfOptions(entries []string) (map[string]string, error) {
+	options := make(map[string]string)
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok {
+			return nil, fmt.Errorf("invalid --conf entry %q: expected key=value", entry)
+		}
+		if !confKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid --conf key %q: use letters, digits, and underscores only", key)
+		}
+		value = strings.TrimSpace(value)
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("invalid --conf value for %q: must be a single line", key)
+		}
+		options[key] = value
+	}
+	return options, nil
+}
+
+// reservedEnvKeys are set by the generated compose file for the odoo service
+var reservedEnvKeys = map[string]bool{
+	"HOST": true, "PORT": true, "USER": true, "PASSWORD": true,
+	"PYTHONPATH": true, "ODOO_DEBUGPY": true,
+	"PLAYWRIGHT_BROWSERS_PATH": true, "CHROME_BIN": true,
+}
+
+// ValidateEnvVar checks an extra environment variable for the odoo service
+func ValidateEnvVar(key, value string) error {
+	if !confKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid --env name %q: use letters, digits, and underscores, not starting with a digit", key)
+	}
+	if reservedEnvKeys[key] {
+		return fmt.Errorf("invalid --env name %q: it is set by odooctl", key)
+	}
+	for _, r := range value {
+		if r < ' ' || r == 0x7f {
+			return fmt.Errorf("invalid --env value for %q: must be a single line without control characters", key)
+		}
+	}
+	return nil
+}
+
+// ParseEnvVars parses repeated KEY=VALUE entries for the odoo service's
+// environment. An entry with an empty value (KEY=) is kept so callers can
+// treat it as a removal.
+func ParseEnvVars(entries []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --env entry %q: expected KEY=VALUE", entry)
+		}
+		key = strings.TrimSpace(key)
+		if err := ValidateEnvVar(key, value); err != nil {
+			return nil, err
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// secretEnvKeyPattern matches variable names that usually hold credentials
+var secretEnvKeyPattern = regexp.MustCompile(`(?i)(KEY|TOKEN|SECRET|PASSW|PWD|CREDENTIAL|AUTH)`)
+
+// MaskEnvValue hides the value of secret-looking variables for display
+func MaskEnvValue(key, value string) string {
+	if secretEnvKeyPattern.MatchString(key) {
+		return MaskToken(value)
+	}
+	return value
+}
+
+// imageRefPattern approximates the Docker image reference grammar:
+// [registry[:port]/]path[:tag][@digest]
+var imageRefPattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?(:[0-9]+)?/)?[a-z0-9]+([._-]+[a-z0-9]+)*(/[a-z0-9]+([._-]+[a-z0-9]+)*)*(:[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+
+// ValidateImageRef checks that ref looks like a Docker image reference
+func ValidateImageRef(ref string) error {
+	if !imageRefPattern.MatchString(ref) {
+		return fmt.Errorf("invalid image reference %q (expected e.g. ghcr.io/acme/odoo-dev:17.0)", ref)
+	}
+	return nil
+}
+
+// dbNamePattern allows the characters of the default odoo-170 style names,
+// leaving out dots so the name can be used as-is in odoo.conf's dbfilter
+var dbNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// ValidateDBName checks that name is a usable PostgreSQL database name
+func ValidateDBName(name string) error {
+	if len(name) > 63 {
+		return fmt.Errorf("invalid database name %q: PostgreSQL names are at most 63 characters", name)
+	}
+	if !dbNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid database name %q: use letters, digits, underscores and hyphens, starting with a letter or underscore", name)
+	}
+	return nil
+}
+
+var networkNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidateNetworkName checks that name is a usable Docker network name
+func ValidateNetworkName(name string) error {
+	if !networkNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid network name %q: use letters, digits, underscores, dots and hyphens", name)
+	}
+	return nil
+}
+
+// ValidateParamURL checks an http(s) URL stored as a system parameter, such
+// as report.url
+func ValidateParamURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q (expected http:// or https:// and a host, e.g. http://odoo:8069)", value)
+	}
+	for _, r := range value {
+		if r <= ' ' || r > '~' || r == '"' || r == '\\' {
+			return fmt.Errorf("invalid URL %q: percent-encode spaces, quotes and non-ASCII characters", value)
+		}
+	}
+	return nil
+}
+
+var logSizePattern = regexp.MustCompile(`^[1-9][0-9]*[kmg]$`)
+
+// ValidateLogMaxSize checks a json-file max-size value such as 10m or 512k
+func ValidateLogMaxSize(size string) error {
+	if !logSizePattern.MatchString(size) {
+		return fmt.Errorf("invalid log size %q (expected a number followed by k, m or g, e.g. 10m)", size)
+	}
+	return nil
+}
@@ -104,6 +104,17 @@ func MergePackages(existing, additions []string) ([]string, []string) {
 	return merged, added
 }
 
+// Requirements returns packages as requirements.txt lines: one spec per
+// package, keeping the first spec (and its version pin) for each normalized
+// name, sorted by name for stable diffs.
+func Requirements(packages []string) []string {
+	unique, _ := MergePackages(nil, packages)
+	sort.SliceStable(unique, func(i, j int) bool {
+		return NormalizePackageName(unique[i]) < NormalizePackageName(unique[j])
+	})
+	return unique
+}
+
 // DiscoverPythonDepsForModules scans manifests and returns package -> modules requiring it.
 // If targetModules is empty, all modules in dirs are scanned.
 func DiscoverPythonDepsForModules(dirs []string, targetModules []string) map[string][]string {
@@ -31,6 +31,14 @@ func TestMergePackagesUsesNormalizedNames(t *testing.T) {
 	}
 }
 
+func TestRequirementsDeduplicatesAndSorts(t *testing.T) {
+	got := Requirements([]string{"zeep", "requests==2.31.0", " Requests>=2", "python_slugify>=8", ""})
+	want := []string{"python_slugify>=8", "requests==2.31.0", "zeep"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Requirements() = %#v, want %#v", got, want)
+	}
+}
+
 func TestDiscoverPythonDepsForModules(t *testing.T) {
 	root := t.TempDir()
 	writeManifest(t, root, "module_a", `{
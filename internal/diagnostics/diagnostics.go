@@ -99,6 +99,12 @@ func Collect(cwd string) Report {
 	report := Report{GeneratedAt: time.Now(), Status: StatusOK}
 	state, err := config.LoadFromDir(cwd)
 	if err != nil {
+		if link, ok := brokenLinkFor(cwd); ok {
+			report.add(Check{ID: "environment", Name: "Environment", Status: StatusError, Message: "The project's environment can't be loaded", Detail: link.Problem})
+			report.NextSteps = append(report.NextSteps, "Run 'odooctl docker doctor' to repair or remove the project link, or 'odooctl docker create' to start over")
+			report.finalize()
+			return report
+		}
 		report.add(Check{ID: "environment", Name: "Environment", Status: StatusError, Message: "No odooctl environment found", Detail: err.Error()})
 		report.NextSteps = append(report.NextSteps, "Run 'odooctl docker create' from the project root")
 		report.SafeCommands = append(report.SafeCommands, "odooctl docker create")
@@ -115,6 +121,12 @@ func Collect(cwd string) Report {
 		IsGitRepo:   state.IsGitRepo,
 	}
 	report.add(Check{ID: "environment", Name: "Environment", Status: StatusOK, Message: "Environment state loaded"})
+	if _, err := os.Stat(state.ProjectRoot); err != nil {
+		report.add(Check{ID: "project_root", Name: "Project directory", Status: StatusError, Message: "Project directory is not accessible", Detail: err.Error()})
+		report.NextSteps = append(report.NextSteps, "Restore the project directory, or run 'odooctl docker doctor' to remove its project link")
+	} else {
+		report.add(Check{ID: "project_root", Name: "Project directory", Status: StatusOK, Message: "Project directory exists", Detail: state.ProjectRoot})
+	}
 
 	envDir, err := config.EnvironmentDir(state.ProjectName, state.Branch)
 	if err != nil {
@@ -130,6 +142,7 @@ func Collect(cwd string) Report {
 	}
 
 	report.collectDocker(state)
+	report.checkPorts(state)
 	browserInfo := internalbrowser.StaticInfo(state)
 	report.Browser = &browserInfo
 	if state.BrowserEnabled && !browserInfo.Supported {
@@ -186,6 +199,14 @@ func (r *Report) collectDocker(state *config.State) {
 	r.Docker.CLIPath = cliPath
 	r.add(Check{ID: "docker_cli", Name: "Docker CLI", Status: StatusOK, Message: "Docker CLI found", Detail: cliPath})
 
+	composeVersion, err := commandOutput("docker", "compose", "version", "--short")
+	if err != nil {
+		r.add(Check{ID: "docker_compose", Name: "Docker Compose", Status: StatusError, Message: "The docker compose plugin was not found", Detail: err.Error()})
+		r.NextSteps = append(r.NextSteps, "Install the Docker Compose v2 plugin (docker-compose-plugin), which provides 'docker compose'")
+		return
+	}
+	r.add(Check{ID: "docker_compose", Name: "Docker Compose", Status: StatusOK, Message: "Docker Compose plugin found", Detail: composeVersion})
+
 	if context, err := commandOutput("docker", "context", "show"); err == nil {
 		r.Docker.Context = context
 	}
@@ -230,6 +251,49 @@ func (r *Report) collectDocker(state *config.State) {
 	r.add(Check{ID: "docker_services", Name: "Docker services", Status: StatusOK, Message: "Compose service status read"})
 }
 
+// checkPorts reports host ports held by something else. A running
+// environment holds its own ports, so they are only checked while it is down.
+func (r *Report) checkPorts(state *config.State) {
+	for _, svc := range r.Docker.Services {
+		if svc.State == "running" {
+			r.add(Check{ID: "ports", Name: "Ports", Status: StatusOK, Message: "Ports are held by the running environment"})
+			return
+		}
+	}
+	if available, conflicting := state.Ports.CheckPortsAvailable(); !available {
+		ports := make([]string, len(conflicting))
+		for i, port := range conflicting {
+			ports[i] = fmt.Sprint(port)
+		}
+		r.add(Check{ID: "ports", Name: "Ports", Status: StatusWarning, Message: "Ports are in use by another process", Detail: strings.Join(ports, ", ")})
+		r.NextSteps = append(r.NextSteps, "Run 'odooctl docker port-check' to see what holds them; 'odooctl docker run' moves the environment to free ports")
+		return
+	}
+	r.add(Check{ID: "ports", Name: "Ports", Status: StatusOK, Message: "Ports are available"})
+}
+
+// brokenLinkFor finds a broken project link for dir or one of its parents,
+// which explains why no environment was loaded
+func brokenLinkFor(dir string) (config.ProjectLinkStatus, bool) {
+	statuses, err := config.CheckProjectLinks()
+	if err != nil {
+		return config.ProjectLinkStatus{}, false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return config.ProjectLinkStatus{}, false
+	}
+	for _, status := range statuses {
+		if !status.Broken() || status.ProjectRoot == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(status.ProjectRoot, absDir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return status, true
+		}
+	}
+	return config.ProjectLinkStatus{}, false
+}
+
 func collectPythonDeps(state *config.State) *PythonDepsInfo {
 	dirs := []string{state.ProjectRoot}
 	dirs = append(dirs, state.AddonsPaths...)
@@ -1,8 +1,12 @@
 package diagnostics
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
 )
 
 func TestCollectWithoutEnvironment(t *testing.T) {
@@ -22,3 +26,31 @@ func TestCollectWithoutEnvironment(t *testing.T) {
 		t.Fatal("expected next steps")
 	}
 }
+
+func TestCollectExplainsUnreadableState(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	projectRoot := filepath.Join(home, "project")
+	if err := os.MkdirAll(projectRoot, 0755); err != nil {
+		t.Fatal(err)
+	}
+	state := &config.State{ProjectName: "project", Branch: "main", OdooVersion: "19.0", ProjectRoot: projectRoot}
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveProjectLink(state); err != nil {
+		t.Fatal(err)
+	}
+	envDir, _ := config.EnvironmentDir("project", "main")
+	if err := os.WriteFile(filepath.Join(envDir, config.StateFileName), []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report := Collect(projectRoot)
+	if len(report.Checks) != 1 || !strings.Contains(report.Checks[0].Detail, "environment state unreadable") {
+		t.Fatalf("unexpected checks: %#v", report.Checks)
+	}
+	if len(report.NextSteps) == 0 || !strings.Contains(report.NextSteps[0], "odooctl docker doctor") {
+		t.Fatalf("next steps = %v, want a docker doctor hint", report.NextSteps)
+	}
+}
@@ -0,0 +1,82 @@
+package diagnostics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Print writes the report as a checklist, followed by the access URLs, next
+// steps and safe commands
+func Print(title string, report Report) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	status := green("OK")
+	if report.Status == StatusWarning {
+		status = yellow("WARNING")
+	} else if report.Status == StatusError {
+		status = red("ERROR")
+	}
+	fmt.Printf("%s: %s\n\n", title, status)
+
+	if report.Project != nil {
+		fmt.Printf("Project: %s\n", cyan(report.Project.Name))
+		fmt.Printf("Odoo:    %s\n", cyan(report.Project.OdooVersion))
+		fmt.Printf("Root:    %s\n", report.Project.Root)
+		fmt.Printf("Branch:  %s\n\n", report.Project.Branch)
+	}
+
+	for _, check := range report.Checks {
+		marker := green("✓")
+		if check.Status == StatusWarning {
+			marker = yellow("!")
+		} else if check.Status == StatusError {
+			marker = red("✗")
+		}
+		fmt.Printf("%s %-24s %s\n", marker, check.Name, check.Message)
+		if check.Detail != "" {
+			fmt.Printf("  %s\n", strings.ReplaceAll(check.Detail, "\n", "\n  "))
+		}
+	}
+
+	if report.Docker.OdooURL != "" || report.Docker.MailHogURL != "" {
+		fmt.Println("\nAccess:")
+		if report.Docker.OdooURL != "" {
+			fmt.Printf("  Odoo:    %s\n", cyan(report.Docker.OdooURL))
+		}
+		if report.Docker.MailHogURL != "" {
+			fmt.Printf("  MailHog: %s\n", cyan(report.Docker.MailHogURL))
+		}
+	}
+
+	if len(report.NextSteps) > 0 {
+		fmt.Println("\nNext steps:")
+		for _, step := range uniqueStrings(report.NextSteps) {
+			fmt.Printf("  - %s\n", step)
+		}
+	}
+
+	if len(report.SafeCommands) > 0 {
+		fmt.Println("\nSafe commands for AI/automation:")
+		for _, command := range uniqueStrings(report.SafeCommands) {
+			fmt.Printf("  %s\n", cyan(command))
+		}
+	}
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, value := range values {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		unique = append(unique, value)
+	}
+	return unique
+}
@@ -1,11 +1,15 @@
 package docker
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
@@ -54,12 +58,22 @@ func formatBindMountCheckError(hostDir, output string, err error) error {
 	return fmt.Errorf("Docker cannot access files under %s%s\nEnable Docker Desktop WSL integration for this distro or fix Docker file sharing, then retry", hostDir, output)
 }
 
+// CheckNetwork verifies that a Docker network exists.
+func CheckNetwork(name string) error {
+	cmd := exec.Command("docker", "network", "inspect", "--format", "{{.Name}}", name)
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	if text := strings.TrimSpace(string(output)); text != "" {
+		return fmt.Errorf("Docker network %q not found: %s\nCreate it with 'docker network create %s'", name, text, name)
+	}
+	return fmt.Errorf("Docker network %q not found: %w\nCreate it with 'docker network create %s'", name, err, name)
+}
+
 // Compose runs docker compose commands
 func Compose(state *config.State, args ...string) error {
-	cmd, err := composeCommand(state, args...)
-	if err != nil {
-		return err
-	}
+	cmd := ComposeCommand(state, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -67,34 +81,92 @@ func Compose(state *config.State, args ...string) error {
 	return cmd.Run()
 }
 
-// ComposeCommand creates an exec.Cmd for docker compose without running it
-func ComposeCommand(state *config.State, args ...string) *exec.Cmd {
-	cmd, _ := composeCommand(state, args...)
-	return cmd
-}
-
 // ComposeOutput runs docker compose and returns output
 func ComposeOutput(state *config.State, args ...string) (string, error) {
-	cmd, err := composeCommand(state, args...)
+	output, err := ComposeCommand(state, args...).CombinedOutput()
+	return string(output), err
+}
+
+// ComposeStdout runs docker compose and returns its stdout, so warnings
+// compose prints on stderr don't end up in parsed output. On failure, the
+// error carries stderr.
+func ComposeStdout(state *config.State, args ...string) (string, error) {
+	cmd := ComposeCommand(state, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
 	if err != nil {
-		return "", err
+		if text := strings.TrimSpace(stderr.String()); text != "" {
+			return string(output), errors.New(text)
+		}
+		return string(output), err
 	}
-	output, err := cmd.CombinedOutput()
-	return string(output), err
+	return string(output), nil
 }
 
-func composeCommand(state *config.State, args ...string) (*exec.Cmd, error) {
+// ComposeCommand creates an exec.Cmd for docker compose in the environment
+// directory without running it, so callers can wire up its streams. It is
+// never nil: when the command can't be set up, running it returns the error
+// (the environment directory is missing, or the enterprise token is when the
+// command builds the image).
+func ComposeCommand(state *config.State, args ...string) *exec.Cmd {
+	// -p pins the project name even for compose files rendered without name:
+	cmd := exec.Command("docker", append([]string{"compose", "-p", state.ComposeProjectName()}, args...)...)
 	dir, err := config.EnvironmentDir(state.ProjectName, state.Branch)
 	if err != nil {
-		return nil, err
+		cmd.Err = err
+		return cmd
 	}
-
-	cmd := exec.Command("docker", append([]string{"compose"}, args...)...)
 	cmd.Dir = dir
-	if state.Enterprise && state.EnterpriseGitHubToken != "" {
-		cmd.Env = append(os.Environ(), fmt.Sprintf("GITHUB_TOKEN=%s", state.EnterpriseGitHubToken))
+
+	var env []string
+	if state.UsesEnterpriseToken() {
+		// Only building the image needs the token; ps, logs, down and the
+		// like work without it
+		token, err := state.EnterpriseToken()
+		if err == nil {
+			env = append(env, fmt.Sprintf("GITHUB_TOKEN=%s", token))
+		} else if buildsImage(args) {
+			cmd.Err = err
+			return cmd
+		}
+	}
+	if limit := composeParallelLimit(); limit != "" {
+		env = append(env, "COMPOSE_PARALLEL_LIMIT="+limit)
 	}
-	return cmd, nil
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return cmd
+}
+
+// buildsImage reports whether compose args build the image: build, or up or
+// run with --build
+func buildsImage(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "build":
+		return true
+	case "up", "run":
+		return slices.Contains(args[1:], "--build")
+	}
+	return false
+}
+
+// composeParallelLimit returns the configured COMPOSE_PARALLEL_LIMIT, which
+// caps how many services compose builds, pulls or starts at once. A value
+// already exported in the environment wins; empty keeps compose's default.
+func composeParallelLimit() string {
+	if _, ok := os.LookupEnv("COMPOSE_PARALLEL_LIMIT"); ok {
+		return ""
+	}
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil || cfg.ComposeParallelism <= 0 {
+		return ""
+	}
+	return strconv.Itoa(cfg.ComposeParallelism)
 }
 
 // IsRunning checks if containers are running
@@ -112,6 +184,7 @@ type ServiceInfo struct {
 	State  string `json:"State"`
 	Status string `json:"Status"`
 	Ports  string `json:"Ports"`
+	Health string `json:"Health"`
 }
 
 // GetServicesStatus gets detailed status of all services
@@ -137,6 +210,41 @@ func GetServicesStatus(state *config.State) ([]ServiceInfo, error) {
 	return services, nil
 }
 
+// ServiceNames lists the services defined in the environment's compose file.
+// Only stdout is parsed, so compose warnings on stderr are not mistaken for
+// services.
+func ServiceNames(state *config.State) ([]string, error) {
+	output, err := ComposeStdout(state, "config", "--services")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose services: %w", err)
+	}
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// healthLabel pads and colors a container health state. Containers without
+// a healthcheck report none and show "-".
+func healthLabel(health string) string {
+	if health == "" {
+		health = "-"
+	}
+	padded := fmt.Sprintf("%-10s", health)
+	switch health {
+	case "healthy":
+		return color.GreenString(padded)
+	case "unhealthy":
+		return color.RedString(padded)
+	case "starting":
+		return color.YellowString(padded)
+	}
+	return padded
+}
+
 // PrintStatus displays container status with rich table output
 func PrintStatus(state *config.State) error {
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -146,7 +254,11 @@ func PrintStatus(state *config.State) error {
 
 	fmt.Printf("\n%s %s\n", cyan("Project:"), state.ProjectName)
 	fmt.Printf("%s Odoo %s\n", cyan("Version:"), state.OdooVersion)
-	fmt.Printf("%s %s\n\n", cyan("Database:"), state.DBName())
+	fmt.Printf("%s %s\n", cyan("Database:"), state.DBName())
+	if state.LastStartedAt != nil {
+		fmt.Printf("%s %s\n", cyan("Last started:"), state.LastStartedAt.Format("2006-01-02 15:04"))
+	}
+	fmt.Println()
 
 	services, err := GetServicesStatus(state)
 	if err != nil || len(services) == 0 {
@@ -157,17 +269,21 @@ func PrintStatus(state *config.State) error {
 
 	// Print table header
 	fmt.Println("Docker Services Status")
-	fmt.Println(strings.Repeat("─", 60))
-	fmt.Printf("%-15s %-12s %-20s %s\n", "SERVICE", "STATE", "STATUS", "PORTS")
-	fmt.Println(strings.Repeat("─", 60))
+	fmt.Println(strings.Repeat("─", 70))
+	fmt.Printf("%-15s %-12s %-20s %-10s %s\n", "SERVICE", "STATE", "STATUS", "HEALTH", "PORTS")
+	fmt.Println(strings.Repeat("─", 70))
 
 	runningServices := make(map[string]bool)
+	odooUnhealthy := false
 	for _, svc := range services {
 		stateColor := red
 		if svc.State == "running" {
 			stateColor = green
 			runningServices[svc.Name] = true
 		}
+		if svc.Name == "odoo" && svc.State == "running" && svc.Health == "unhealthy" {
+			odooUnhealthy = true
+		}
 
 		// Format ports
 		ports := svc.Ports
@@ -175,21 +291,29 @@ func PrintStatus(state *config.State) error {
 			ports = "-"
 		}
 
-		fmt.Printf("%-15s %-12s %-20s %s\n",
+		fmt.Printf("%-15s %-12s %-20s %s %s\n",
 			cyan(svc.Name),
 			stateColor(svc.State),
 			dim(svc.Status),
+			healthLabel(svc.Health),
 			ports,
 		)
 	}
-	fmt.Println(strings.Repeat("─", 60))
+	fmt.Println(strings.Repeat("─", 70))
+	if odooUnhealthy {
+		fmt.Printf("%s Odoo is running but unhealthy. Check '%s'\n", color.YellowString("⚠️"), cyan("odooctl docker logs"))
+	}
 
 	// Print access URLs if running
 	if len(runningServices) > 0 {
 		fmt.Printf("\n%s\n", green("Access URLs:"))
 		if runningServices["odoo"] {
 			fmt.Printf("  %s Odoo:    http://localhost:%d\n", cyan("🌐"), state.Ports.Odoo)
-			fmt.Printf("  %s Debug:   localhost:%d\n", cyan("🔧"), state.Ports.Debug)
+			if state.DebugpyEnabled {
+				fmt.Printf("  %s Debug:   localhost:%d (debugpy)\n", cyan("🔧"), state.Ports.Debug)
+			} else {
+				fmt.Printf("  %s Debug:   %s\n", cyan("🔧"), dim("off (odooctl docker run --debug)"))
+			}
 		}
 		if runningServices["mailhog"] {
 			fmt.Printf("  %s MailHog: http://localhost:%d\n", cyan("📧"), state.Ports.Mailhog)
@@ -2,8 +2,13 @@ package docker
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
 )
 
 func TestFormatDaemonCheckError(t *testing.T) {
@@ -37,3 +42,168 @@ func TestFormatBindMountCheckError(t *testing.T) {
 		}
 	}
 }
+
+func TestParseLsofListen(t *testing.T) {
+	output := "COMMAND PID   USER   FD   TYPE DEVICE SIZE/OFF NODE NAME\npostgres 812 alice    7u  IPv4  20410      0t0  TCP *:9700 (LISTEN)\n"
+	holder, ok := parseLsofListen(output)
+	if !ok {
+		t.Fatal("expected a holder")
+	}
+	if holder.Command != "postgres" || holder.PID != "812" {
+		t.Fatalf("parseLsofListen() = %+v, want postgres pid 812", holder)
+	}
+	if _, ok := parseLsofListen("COMMAND PID USER FD TYPE DEVICE SIZE/OFF NODE NAME\n"); ok {
+		t.Fatal("expected no holder for header-only output")
+	}
+}
+
+func TestParseNetstatListen(t *testing.T) {
+	output := `Active Internet connections (only servers)
+Proto Recv-Q Send-Q Local Address           Foreign Address         State       PID/Program name
+tcp        0      0 0.0.0.0:19700           0.0.0.0:*               LISTEN      -
+tcp        0      0 127.0.0.1:9700          0.0.0.0:*               LISTEN      122/python3
+tcp6       0      0 :::9725                 :::*                    LISTEN      -
+`
+	holder, ok := parseNetstatListen(output, 9700)
+	if !ok || holder.Command != "python3" || holder.PID != "122" {
+		t.Fatalf("parseNetstatListen(9700) = %+v, %v, want python3 pid 122", holder, ok)
+	}
+	holder, ok = parseNetstatListen(output, 9725)
+	if !ok || holder.Command != "" {
+		t.Fatalf("parseNetstatListen(9725) = %+v, %v, want unnamed holder", holder, ok)
+	}
+	if _, ok := parseNetstatListen(output, 9778); ok {
+		t.Fatal("expected no holder for free port")
+	}
+}
+
+func TestComposeCommandSetsParallelLimit(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("COMPOSE_PARALLEL_LIMIT", "") // restored after the test
+	os.Unsetenv("COMPOSE_PARALLEL_LIMIT")
+	state := &config.State{ProjectName: "test-project", OdooVersion: "17.0", Branch: "main"}
+
+	cmd := ComposeCommand(state, "build")
+	if cmd.Env != nil {
+		t.Fatalf("ComposeCommand() Env = %v, want inherited environment", cmd.Env)
+	}
+	if want := []string{"docker", "compose", "-p", "170-test-project", "build"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Fatalf("ComposeCommand() Args = %v, want %v", cmd.Args, want)
+	}
+
+	cfg := &config.GlobalConfig{ComposeParallelism: 2}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cmd = ComposeCommand(state, "build")
+	if !containsString(cmd.Env, "COMPOSE_PARALLEL_LIMIT=2") {
+		t.Fatal("ComposeCommand() Env missing COMPOSE_PARALLEL_LIMIT=2")
+	}
+
+	t.Setenv("COMPOSE_PARALLEL_LIMIT", "8")
+	cmd = ComposeCommand(state, "build")
+	if containsString(cmd.Env, "COMPOSE_PARALLEL_LIMIT=2") {
+		t.Fatal("ComposeCommand() overrides COMPOSE_PARALLEL_LIMIT from the environment")
+	}
+}
+
+func TestComposeCommandCarriesSetupError(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	state := &config.State{ProjectName: "test-project", OdooVersion: "17.0", Branch: "main", Enterprise: true, UseGlobalEnterpriseAuth: true}
+
+	cmd := ComposeCommand(state, "build")
+	if cmd == nil {
+		t.Fatal("ComposeCommand() = nil, want a command carrying the missing token error")
+	}
+	if err := cmd.Run(); err == nil || !strings.Contains(err.Error(), "github-token") {
+		t.Fatalf("Run() error = %v, want the missing token error", err)
+	}
+	if cmd := ComposeCommand(state, "up", "-d", "--build"); cmd.Err == nil {
+		t.Fatal("ComposeCommand(up --build) carries no error, want the missing token error")
+	}
+	// Commands that don't build the image don't need the token
+	for _, args := range [][]string{{"ps"}, {"down"}, {"up", "-d"}, {"logs", "odoo"}} {
+		if cmd := ComposeCommand(state, args...); cmd.Err != nil && strings.Contains(cmd.Err.Error(), "github-token") {
+			t.Errorf("ComposeCommand(%v) error = %v, want no token error", args, cmd.Err)
+		}
+	}
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseVolumeSizes(t *testing.T) {
+	output := `Images space usage:
+
+REPOSITORY   TAG       IMAGE ID       CREATED       SIZE      SHARED SIZE   UNIQUE SIZE   CONTAINERS
+odoo-dev     17.0      0123456789ab   2 days ago    1.9GB     0B            1.9GB         2
+
+Local Volumes space usage:
+
+VOLUME NAME                        LINKS     SIZE
+170-shop_odoo-postgres-data-170    1         212.4MB
+170-shop_odoo-filestore-170        1         1.05GB
+170-shop_odoo-sessions-170         0         0B
+
+Build cache usage: 0B
+`
+	sizes := parseVolumeSizes(output)
+	if len(sizes) != 3 {
+		t.Fatalf("parseVolumeSizes() = %v, want 3 volumes", sizes)
+	}
+	if sizes["170-shop_odoo-filestore-170"] != "1.05GB" {
+		t.Fatalf("filestore size = %q, want 1.05GB", sizes["170-shop_odoo-filestore-170"])
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	cases := map[string]int64{"0B": 0, "512B": 512, "12.5kB": 12500, "212.4MB": 212400000, "1.05GB": 1050000000}
+	for size, want := range cases {
+		got, ok := ParseSize(size)
+		if !ok || got != want {
+			t.Fatalf("ParseSize(%q) = %d, %v, want %d", size, got, ok, want)
+		}
+	}
+	if _, ok := ParseSize("lots"); ok {
+		t.Fatal("expected ParseSize to reject an unknown size")
+	}
+}
+
+func TestHealthLabel(t *testing.T) {
+	for health, want := range map[string]string{"": "-", "healthy": "healthy", "unhealthy": "unhealthy", "starting": "starting"} {
+		got := healthLabel(health)
+		if !strings.Contains(got, want) || !strings.Contains(got, want+strings.Repeat(" ", 10-len(want))) {
+			t.Errorf("healthLabel(%q) = %q, want %q padded to 10 columns", health, got, want)
+		}
+	}
+}
+
+func TestComposeStdoutIgnoresStderr(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho 'WARN[0000] Found orphan containers' >&2\nprintf 'db\\nodoo\\n'\n[ \"$4\" = fail ] && exit 1\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(bin, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	state := &config.State{ProjectName: "test-project", OdooVersion: "17.0", Branch: "main"}
+	envDir, _ := config.EnvironmentDir(state.ProjectName, state.Branch)
+	if err := os.MkdirAll(envDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := ServiceNames(state)
+	if err != nil || strings.Join(names, ",") != "db,odoo" {
+		t.Fatalf("ServiceNames() = %v, %v, want [db odoo]", names, err)
+	}
+	if _, err := ComposeStdout(state, "fail"); err == nil || !strings.Contains(err.Error(), "orphan containers") {
+		t.Fatalf("ComposeStdout() error = %v, want the stderr text", err)
+	}
+}
@@ -0,0 +1,82 @@
+package docker
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PortHolder describes what is listening on a host port
+type PortHolder struct {
+	Command   string `json:"command,omitempty"`
+	PID       string `json:"pid,omitempty"`
+	Container string `json:"container,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+// String returns a short human-readable description of the holder
+func (h PortHolder) String() string {
+	switch {
+	case h.Container != "":
+		return fmt.Sprintf("Docker container %s", h.Container)
+	case h.Command != "" && h.PID != "":
+		return fmt.Sprintf("%s (pid %s)", h.Command, h.PID)
+	case h.Command != "":
+		return h.Command
+	default:
+		return "unknown process"
+	}
+}
+
+// FindPortHolder tries to identify the process listening on port. It asks
+// Docker for a container publishing the port first, then falls back to
+// lsof and netstat. Tools that are missing or lack permission are skipped.
+func FindPortHolder(port int) (PortHolder, bool) {
+	if output, err := exec.Command("docker", "ps", "--filter", fmt.Sprintf("publish=%d", port), "--format", "{{.Names}}").Output(); err == nil {
+		if name := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0]); name != "" {
+			return PortHolder{Container: name, Source: "docker"}, true
+		}
+	}
+	if output, err := exec.Command("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN").Output(); err == nil {
+		if holder, ok := parseLsofListen(string(output)); ok {
+			return holder, true
+		}
+	}
+	if output, err := exec.Command("netstat", "-ltnp").Output(); err == nil {
+		if holder, ok := parseNetstatListen(string(output), port); ok {
+			return holder, true
+		}
+	}
+	return PortHolder{}, false
+}
+
+// parseLsofListen reads the first process row of `lsof -iTCP:PORT -sTCP:LISTEN`
+func parseLsofListen(output string) (PortHolder, bool) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] == "COMMAND" {
+			continue
+		}
+		return PortHolder{Command: fields[0], PID: fields[1], Source: "lsof"}, true
+	}
+	return PortHolder{}, false
+}
+
+// parseNetstatListen finds the listener for port in `netstat -ltnp` output
+func parseNetstatListen(output string, port int) (PortHolder, bool) {
+	suffix := fmt.Sprintf(":%d", port)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 6 || !strings.HasPrefix(fields[0], "tcp") || !strings.HasSuffix(fields[3], suffix) {
+			continue
+		}
+		holder := PortHolder{Source: "netstat"}
+		if len(fields) >= 7 && fields[6] != "-" {
+			pid, command, _ := strings.Cut(fields[6], "/")
+			holder.PID = pid
+			holder.Command = command
+		}
+		return holder, true
+	}
+	return PortHolder{}, false
+}
@@ -0,0 +1,100 @@
+package docker
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Labels docker compose puts on the volumes it creates
+const (
+	composeProjectLabel = "com.docker.compose.project"
+	composeVolumeLabel  = "com.docker.compose.volume"
+)
+
+// ProjectVolumes lists the Docker volumes created for a compose project
+func ProjectVolumes(project string) ([]string, error) {
+	output, err := exec.Command("docker", "volume", "ls", "-q", "--filter", "label="+composeProjectLabel+"="+project).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %s", strings.TrimSpace(string(output)))
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// CopyProjectVolume copies a compose volume from one project to another,
+// labelling the copy so compose adopts it as its own.
+// It returns the name of the new volume.
+func CopyProjectVolume(volume, fromProject, toProject string) (string, error) {
+	key := strings.TrimPrefix(volume, fromProject+"_")
+	target := toProject + "_" + key
+
+	create := exec.Command("docker", "volume", "create",
+		"--label", composeProjectLabel+"="+toProject,
+		"--label", composeVolumeLabel+"="+key,
+		target)
+	if output, err := create.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to create volume %s: %s", target, strings.TrimSpace(string(output)))
+	}
+
+	cp := exec.Command("docker", "run", "--rm",
+		"-v", volume+":/from:ro",
+		"-v", target+":/to",
+		"alpine:latest", "cp", "-a", "/from/.", "/to/")
+	if output, err := cp.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to copy volume %s: %s", volume, strings.TrimSpace(string(output)))
+	}
+	return target, nil
+}
+
+// VolumeSizes returns the disk usage of every Docker volume by name, as
+// reported by 'docker system df -v' (e.g. "1.2GB")
+func VolumeSizes() (map[string]string, error) {
+	output, err := exec.Command("docker", "system", "df", "-v").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read volume sizes: %s", strings.TrimSpace(string(output)))
+	}
+	return parseVolumeSizes(string(output)), nil
+}
+
+// parseVolumeSizes reads the volumes table of 'docker system df -v'
+func parseVolumeSizes(output string) map[string]string {
+	sizes := make(map[string]string)
+	inVolumes := false
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "VOLUME NAME"):
+			inVolumes = true
+		case line == "" || strings.HasSuffix(line, "space usage:"):
+			inVolumes = false
+		case inVolumes:
+			fields := strings.Fields(line)
+			if len(fields) >= 3 {
+				sizes[fields[0]] = fields[len(fields)-1]
+			}
+		}
+	}
+	return sizes
+}
+
+// ParseSize converts a size printed by the docker CLI ("12.5kB", "1.2GB") to
+// bytes. Docker uses decimal units.
+func ParseSize(size string) (int64, bool) {
+	units := []struct {
+		suffix string
+		factor float64
+	}{{"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3}, {"B", 1}}
+	for _, unit := range units {
+		if number, ok := strings.CutSuffix(size, unit.suffix); ok {
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, false
+			}
+			return int64(value * unit.factor), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,74 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mart337i/odooctl/internal/config"
+)
+
+// Polling intervals of WaitForService and Retry, doubled after each attempt
+const (
+	waitInitialInterval = 500 * time.Millisecond
+	waitMaxInterval     = 4 * time.Second
+)
+
+// WaitForService blocks until a service accepts work or timeout elapses. The
+// db service is ready once pg_isready succeeds; other services once a command
+// can be executed in them.
+func WaitForService(state *config.State, service string, timeout time.Duration) error {
+	probe := []string{"exec", "-T", service, "true"}
+	if service == "db" {
+		probe = []string{"exec", "-T", "db", "pg_isready", "-U", "odoo"}
+	}
+	var lastOutput string
+	err := waitUntil(timeout, func() error {
+		output, err := ComposeOutput(state, probe...)
+		lastOutput = strings.TrimSpace(output)
+		return err
+	})
+	if err == nil {
+		return nil
+	}
+	if lastOutput != "" {
+		return fmt.Errorf("%s was not ready within %s: %s", service, timeout, lastOutput)
+	}
+	return fmt.Errorf("%s was not ready within %s", service, timeout)
+}
+
+// Retry calls fn up to attempts times, waiting between attempts with a
+// doubling delay, and returns the last error
+func Retry(attempts int, fn func() error) error {
+	var err error
+	interval := waitInitialInterval
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt < attempts {
+			time.Sleep(interval)
+			interval = min(interval*2, waitMaxInterval)
+		}
+	}
+	return err
+}
+
+// waitUntil calls probe with a doubling interval until it succeeds or
+// timeout elapses
+func waitUntil(timeout time.Duration, probe func() error) error {
+	start := time.Now()
+	interval := waitInitialInterval
+	for {
+		err := probe()
+		if err == nil {
+			return nil
+		}
+		elapsed := time.Since(start)
+		if elapsed >= timeout {
+			return err
+		}
+		time.Sleep(min(interval, timeout-elapsed))
+		interval = min(interval*2, waitMaxInterval)
+	}
+}
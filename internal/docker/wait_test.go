@@ -0,0 +1,50 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	err := Retry(5, func() error {
+		calls++
+		if calls < 2 {
+			return errors.New("db is starting")
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("Retry() = %v after %d calls, want success on the second", err, calls)
+	}
+
+	calls = 0
+	failure := errors.New("still down")
+	if err := Retry(1, func() error { calls++; return failure }); err != failure || calls != 1 {
+		t.Fatalf("Retry(1) = %v after %d calls, want the error after one call", err, calls)
+	}
+}
+
+func TestWaitUntil(t *testing.T) {
+	failure := errors.New("not ready")
+	start := time.Now()
+	if err := waitUntil(0, func() error { return failure }); err != failure {
+		t.Fatalf("waitUntil() = %v, want the probe error", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("waitUntil() with no timeout kept polling")
+	}
+
+	calls := 0
+	err := waitUntil(10*time.Second, func() error {
+		calls++
+		if calls < 2 {
+			return failure
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("waitUntil() = %v after %d calls, want success on the second", err, calls)
+	}
+}
@@ -1,8 +1,12 @@
 package git
 
 import (
+	"encoding/base64"
+	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/mart337i/odooctl/internal/odoo"
@@ -14,6 +18,10 @@ type Info struct {
 	RepoName string
 	Branch   string
 	Root     string
+	// Worktree is set when Root is a linked worktree of another checkout
+	Worktree bool
+	// Superproject is the superproject root when Root is a submodule
+	Superproject string
 }
 
 // Detect checks if the directory is a git repository
@@ -32,17 +40,77 @@ func Detect(dir string) Info {
 	info.Root = strings.TrimSpace(string(output))
 	info.RepoName = filepath.Base(info.Root)
 
+	// A linked worktree is named after the repository it belongs to
+	if common, gitDir := gitPath(dir, "--git-common-dir"), gitPath(dir, "--git-dir"); common != "" && common != gitDir {
+		info.Worktree = true
+		info.RepoName = repoNameFromGitDir(common)
+	}
+
+	cmd = exec.Command("git", "rev-parse", "--show-superproject-working-tree")
+	cmd.Dir = dir
+	if output, err := cmd.Output(); err == nil {
+		info.Superproject = strings.TrimSpace(string(output))
+	}
+
 	// Get current branch
 	cmd = exec.Command("git", "symbolic-ref", "--short", "HEAD")
 	cmd.Dir = dir
 	output, err = cmd.Output()
 	if err == nil {
 		info.Branch = strings.TrimSpace(string(output))
+	} else {
+		info.Branch = detachedName(dir)
 	}
 
 	return info
 }
 
+// gitPath returns the absolute path printed by git rev-parse <flag>
+func gitPath(dir, flag string) string {
+	cmd := exec.Command("git", "rev-parse", flag)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	path := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
+
+// repoNameFromGitDir names a repository after its git directory:
+// "/src/project/.git" and "/src/project.git" are both "project"
+func repoNameFromGitDir(gitDir string) string {
+	if filepath.Base(gitDir) == ".git" {
+		return filepath.Base(filepath.Dir(gitDir))
+	}
+	return strings.TrimSuffix(filepath.Base(gitDir), ".git")
+}
+
+// detachedName names a detached HEAD, as in CI checkouts of a tag or commit:
+// the tag pointing at HEAD, or detached-<short sha>
+func detachedName(dir string) string {
+	cmd := exec.Command("git", "describe", "--tags", "--exact-match", "HEAD")
+	cmd.Dir = dir
+	if output, err := cmd.Output(); err == nil {
+		return strings.TrimSpace(string(output))
+	}
+	cmd = exec.Command("git", "rev-parse", "--short", "HEAD")
+	cmd.Dir = dir
+	if output, err := cmd.Output(); err == nil {
+		return "detached-" + strings.TrimSpace(string(output))
+	}
+	return ""
+}
+
 // VersionFromBranch extracts Odoo version from branch name
 // e.g., "17.0" -> "17.0", "17.0-feature" -> "17.0"
 func VersionFromBranch(branch string) string {
@@ -53,3 +121,140 @@ func VersionFromBranch(branch string) string {
 	}
 	return ""
 }
+
+// CurrentBranch returns the checked out branch name ("HEAD" when detached)
+func CurrentBranch(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// HasUncommittedChanges reports whether the working tree has staged,
+// unstaged or untracked changes
+func HasUncommittedChanges(dir string) (bool, error) {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return false, err
+	}
+	return len(strings.TrimSpace(string(output))) > 0, nil
+}
+
+// Stash stashes all changes, including untracked files, under message
+func Stash(dir, message string) error {
+	return run(dir, "stash", "push", "--include-untracked", "-m", message)
+}
+
+// FindStash returns the ref (e.g. "stash@{1}") of the newest stash whose
+// message equals message
+func FindStash(dir, message string) (string, bool) {
+	cmd := exec.Command("git", "stash", "list", "--format=%gd%x00%s")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		ref, subject, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		// git prefixes the subject with "On <branch>: "
+		if subject == message || strings.HasSuffix(subject, ": "+message) {
+			return ref, true
+		}
+	}
+	return "", false
+}
+
+// StashPop applies and drops the given stash ref
+func StashPop(dir, ref string) error {
+	return run(dir, "stash", "pop", ref)
+}
+
+// Checkout switches the working tree to branch
+func Checkout(dir, branch string) error {
+	return run(dir, "checkout", branch)
+}
+
+func run(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// CloneOptions holds optional credentials for cloning private repositories
+type CloneOptions struct {
+	Branch     string
+	SSHKeyPath string // used for SSH remotes (git@host:… or ssh://…)
+	Token      string // GitHub token used for https://github.com remotes
+}
+
+// Clone clones url into dir. Credentials are passed through the environment,
+// never on the command line or in the remote URL, so other users can't read
+// them from the process list.
+func Clone(url, dir string, opts CloneOptions) error {
+	args := []string{"clone"}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	args = append(args, url, dir)
+
+	cmd := exec.Command("git", args...)
+	// Progress goes to stderr so callers can keep stdout for JSON
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if opts.Token != "" && strings.HasPrefix(url, "https://github.com/") {
+		basic := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + opts.Token))
+		cmd.Env = append(cmd.Env, gitConfigEnv(os.Getenv("GIT_CONFIG_COUNT"), "http.https://github.com/.extraheader", "AUTHORIZATION: basic "+basic)...)
+	}
+	if opts.SSHKeyPath != "" && IsSSHURL(url) {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %q -o IdentitiesOnly=yes", opts.SSHKeyPath))
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git clone %s failed: %w", url, err)
+	}
+	return nil
+}
+
+// IsSSHURL reports whether url uses the SSH transport
+func IsSSHURL(url string) bool {
+	if strings.HasPrefix(url, "ssh://") {
+		return true
+	}
+	return !strings.Contains(url, "://") && strings.Contains(url, "@") && strings.Contains(url, ":")
+}
+
+// RepoNameFromURL returns the directory name git clone would use for url
+func RepoNameFromURL(url string) string {
+	name := strings.TrimRight(url, "/")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, ".git")
+}
+
+// gitConfigEnv returns the environment variables that add key=value to git's
+// configuration, after the count entries the environment already sets
+func gitConfigEnv(count, key, value string) []string {
+	index, err := strconv.Atoi(count)
+	if err != nil || index < 0 {
+		index = 0
+	}
+	return []string{
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", index+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", index, key),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", index, value),
+	}
+}
@@ -0,0 +1,197 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStashCheckoutRoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v error = %v\n%s", args, err, output)
+		}
+	}
+	gitRun("init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	gitRun("add", ".")
+	gitRun("commit", "-q", "-m", "init")
+	gitRun("branch", "feature")
+
+	if err := os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	dirty, err := HasUncommittedChanges(dir)
+	if err != nil || !dirty {
+		t.Fatalf("HasUncommittedChanges() = %v, %v, want true, nil", dirty, err)
+	}
+
+	if err := Stash(dir, "odooctl goto: main"); err != nil {
+		t.Fatalf("Stash() error = %v", err)
+	}
+	if err := Checkout(dir, "feature"); err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+	if branch, _ := CurrentBranch(dir); branch != "feature" {
+		t.Fatalf("CurrentBranch() = %q, want feature", branch)
+	}
+	if _, ok := FindStash(dir, "odooctl goto: feature"); ok {
+		t.Fatalf("FindStash(feature) found a stash, want none")
+	}
+
+	ref, ok := FindStash(dir, "odooctl goto: main")
+	if !ok || ref != "stash@{0}" {
+		t.Fatalf("FindStash(main) = %q, %v, want stash@{0}, true", ref, ok)
+	}
+	if err := Checkout(dir, "main"); err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+	if err := StashPop(dir, ref); err != nil {
+		t.Fatalf("StashPop() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b.txt")); err != nil {
+		t.Fatalf("b.txt not restored: %v", err)
+	}
+}
+
+func TestRepoNameFromURL(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/acme/odoo-addons.git": "odoo-addons",
+		"https://github.com/acme/odoo-addons/":    "odoo-addons",
+		"git@github.com:acme/sale_custom.git":     "sale_custom",
+		"git@github.com:project.git":              "project",
+		"ssh://git@example.com:2222/acme/hr.git":  "hr",
+	}
+	for url, want := range cases {
+		if got := RepoNameFromURL(url); got != want {
+			t.Fatalf("RepoNameFromURL(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestGitConfigEnv(t *testing.T) {
+	got := gitConfigEnv("", "http.extraheader", "AUTHORIZATION: basic x")
+	want := []string{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=http.extraheader", "GIT_CONFIG_VALUE_0=AUTHORIZATION: basic x"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("gitConfigEnv() = %v, want %v", got, want)
+	}
+	if got := gitConfigEnv("2", "k", "v"); got[0] != "GIT_CONFIG_COUNT=3" || got[1] != "GIT_CONFIG_KEY_2=k" {
+		t.Errorf("gitConfigEnv() after 2 entries = %v", got)
+	}
+}
+
+func TestIsSSHURL(t *testing.T) {
+	cases := map[string]bool{
+		"git@github.com:acme/repo.git":      true,
+		"ssh://git@example.com/acme/repo":   true,
+		"https://github.com/acme/repo.git":  false,
+		"https://user@github.com/acme/repo": false,
+	}
+	for url, want := range cases {
+		if got := IsSSHURL(url); got != want {
+			t.Fatalf("IsSSHURL(%q) = %v, want %v", url, got, want)
+		}
+	}
+}
+
+func TestDetectBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitOutput := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v error = %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	// A fresh repository has no commits yet, but HEAD names its branch
+	gitOutput("init", "-q", "-b", "17.0-main")
+	if info := Detect(dir); !info.IsRepo || info.Branch != "17.0-main" {
+		t.Fatalf("Detect(no commits) = %+v, want branch 17.0-main", info)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitOutput("add", ".")
+	gitOutput("commit", "-q", "-m", "init")
+	gitOutput("commit", "-q", "--allow-empty", "-m", "second")
+	sha := gitOutput("rev-parse", "--short", "HEAD~1")
+	gitOutput("tag", "17.0.1", "HEAD")
+
+	gitOutput("checkout", "-q", "--detach", "17.0.1")
+	if info := Detect(dir); info.Branch != "17.0.1" {
+		t.Fatalf("Detect(tag checkout) branch = %q, want 17.0.1", info.Branch)
+	}
+	gitOutput("checkout", "-q", "--detach", "HEAD~1")
+	if info := Detect(dir); info.Branch != "detached-"+sha {
+		t.Fatalf("Detect(commit checkout) branch = %q, want detached-%s", info.Branch, sha)
+	}
+}
+
+func TestDetectWorktreeAndSubmodule(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := t.TempDir()
+	gitRun := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v error = %v\n%s", args, err, output)
+		}
+	}
+	newRepo := func(name, branch string) string {
+		t.Helper()
+		dir := filepath.Join(base, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		gitRun(dir, "init", "-q", "-b", branch)
+		gitRun(dir, "commit", "-q", "--allow-empty", "-m", "init")
+		return dir
+	}
+
+	repo := newRepo("project", "17.0")
+	if info := Detect(repo); info.RepoName != "project" || info.Worktree {
+		t.Fatalf("Detect(main checkout) = %+v, want RepoName project, not a worktree", info)
+	}
+
+	worktree := filepath.Join(base, "project-fix")
+	gitRun(repo, "worktree", "add", "-q", "-b", "17.0-fix", worktree)
+	info := Detect(worktree)
+	if !info.Worktree || info.RepoName != "project" || info.Branch != "17.0-fix" {
+		t.Fatalf("Detect(worktree) = %+v, want worktree of project on 17.0-fix", info)
+	}
+	if filepath.Base(info.Root) != "project-fix" {
+		t.Fatalf("Detect(worktree) root = %q, want the worktree path", info.Root)
+	}
+
+	module := newRepo("module", "main")
+	gitRun(repo, "-c", "protocol.file.allow=always", "submodule", "add", "-q", module, "addons/module")
+	info = Detect(filepath.Join(repo, "addons", "module"))
+	if info.RepoName != "module" || info.Superproject == "" || filepath.Base(info.Superproject) != "project" {
+		t.Fatalf("Detect(submodule) = %+v, want module inside project", info)
+	}
+}
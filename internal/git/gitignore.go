@@ -0,0 +1,60 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnsureIgnored appends the entries missing from dir/.gitignore, keeping its
+// content as is. Without a .gitignore, one is created only when create is
+// set. It returns the entries it added.
+func EnsureIgnored(dir string, create bool, entries []string) ([]string, error) {
+	path := filepath.Join(dir, ".gitignore")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !create {
+		return nil, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	listed := map[string]bool{}
+	for _, line := range strings.Split(string(data), "\n") {
+		listed[normalizeIgnoreEntry(line)] = true
+	}
+	var added []string
+	for _, entry := range entries {
+		key := normalizeIgnoreEntry(entry)
+		if key == "" || listed[key] {
+			continue
+		}
+		listed[key] = true
+		added = append(added, entry)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	content += "# odooctl\n" + strings.Join(added, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// normalizeIgnoreEntry treats "name", "/name" and "name/" as the same entry
+func normalizeIgnoreEntry(entry string) string {
+	entry = strings.TrimSpace(entry)
+	if strings.HasPrefix(entry, "#") {
+		return ""
+	}
+	return strings.Trim(entry, "/")
+}
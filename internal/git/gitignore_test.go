@@ -0,0 +1,46 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEnsureIgnored(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".gitignore")
+	entries := []string{"/.odooctl", "/coverage.xml"}
+
+	if added, err := EnsureIgnored(dir, false, entries); err != nil || added != nil {
+		t.Fatalf("EnsureIgnored(no .gitignore) = %v, %v, want nothing", added, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf(".gitignore created without create: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("*.pyc\n.odooctl/"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	added, err := EnsureIgnored(dir, false, entries)
+	if err != nil || !reflect.DeepEqual(added, []string{"/coverage.xml"}) {
+		t.Fatalf("EnsureIgnored() = %v, %v, want [/coverage.xml]", added, err)
+	}
+	data, _ := os.ReadFile(path)
+	if want := "*.pyc\n.odooctl/\n\n# odooctl\n/coverage.xml\n"; string(data) != want {
+		t.Fatalf(".gitignore = %q, want %q", data, want)
+	}
+
+	if added, err := EnsureIgnored(dir, true, entries); err != nil || added != nil {
+		t.Fatalf("second EnsureIgnored() = %v, %v, want nothing", added, err)
+	}
+
+	other := t.TempDir()
+	if added, err := EnsureIgnored(other, true, entries); err != nil || len(added) != 2 {
+		t.Fatalf("EnsureIgnored(create) = %v, %v", added, err)
+	}
+	data, _ = os.ReadFile(filepath.Join(other, ".gitignore"))
+	if want := "# odooctl\n/.odooctl\n/coverage.xml\n"; string(data) != want {
+		t.Fatalf("new .gitignore = %q, want %q", data, want)
+	}
+}
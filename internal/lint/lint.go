@@ -0,0 +1,311 @@
+// Package lint checks a generated environment's odoo.conf and
+// docker-compose.yml for misconfigurations, typically introduced by manual
+// edits, that only show up once the containers run.
+package lint
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mart337i/odooctl/internal/config"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a single problem found in a file
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Check    string   `json:"check"`
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Paths that exist in the Odoo image without being mounted
+const (
+	imageAddonsPath      = "/usr/lib/python3/dist-packages/odoo/addons"
+	enterpriseAddonsPath = "/mnt/enterprise"
+	defaultLongpolling   = 8072
+)
+
+// confOption is one key = value line of odoo.conf
+type confOption struct {
+	Value string
+	Line  int
+}
+
+// Conf is a parsed odoo.conf [options] section
+type Conf struct {
+	Options    map[string]confOption
+	Duplicates []Finding
+}
+
+// ParseConf reads the [options] section of an odoo.conf
+func ParseConf(text string) Conf {
+	conf := Conf{Options: make(map[string]confOption)}
+	inOptions := false
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			inOptions = line == "[options]"
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !inOptions || !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if previous, ok := conf.Options[key]; ok {
+			conf.Duplicates = append(conf.Duplicates, Finding{
+				Severity: SeverityError,
+				Check:    "duplicate-option",
+				Line:     lineNo,
+				Message:  fmt.Sprintf("%s is set again (first on line %d); Odoo refuses to start with duplicate options", key, previous.Line),
+			})
+		}
+		conf.Options[key] = confOption{Value: value, Line: lineNo}
+	}
+	return conf
+}
+
+// Compose holds the parts of docker-compose.yml the checks need. It is read
+// line by line, which is enough for the files odooctl generates.
+type Compose struct {
+	Services map[string]int // service name -> line
+	// Mounts maps container paths to host paths (or volume names)
+	Mounts map[string]string
+	// Published maps container ports of the odoo service to host ports
+	Published map[int]int
+	// InitModules is the -i list of the odoo-init service
+	InitModules     []string
+	InitModulesLine int
+}
+
+var (
+	composeServicePattern = regexp.MustCompile(`^  ([A-Za-z0-9._-]+):\s*$`)
+	composeVolumePattern  = regexp.MustCompile(`^\s+- "?([^":]+):(/[^":]*)(:ro|:rw)?"?\s*$`)
+	composePortPattern    = regexp.MustCompile(`^\s+- "?(\d+):(\d+)"?\s*$`)
+	composeInitPattern    = regexp.MustCompile(`"-i",\s*"([^"]*)"`)
+)
+
+// ParseCompose extracts services, mounts, odoo ports and init modules
+func ParseCompose(text string) Compose {
+	compose := Compose{Services: make(map[string]int), Mounts: make(map[string]string), Published: make(map[int]int)}
+	section, service := "", ""
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "#") {
+			section, service = strings.TrimSuffix(strings.TrimSpace(line), ":"), ""
+			continue
+		}
+		if section == "services" {
+			if match := composeServicePattern.FindStringSubmatch(line); match != nil {
+				service = match[1]
+				compose.Services[service] = lineNo
+				continue
+			}
+		}
+		if match := composeVolumePattern.FindStringSubmatch(line); match != nil {
+			compose.Mounts[match[2]] = match[1]
+			continue
+		}
+		if match := composePortPattern.FindStringSubmatch(line); match != nil && service == "odoo" {
+			host, _ := strconv.Atoi(match[1])
+			container, _ := strconv.Atoi(match[2])
+			compose.Published[container] = host
+			continue
+		}
+		if match := composeInitPattern.FindStringSubmatch(line); match != nil && service == "odoo-init" {
+			compose.InitModules = strings.Split(match[1], ",")
+			compose.InitModulesLine = lineNo
+		}
+	}
+	return compose
+}
+
+// Environment lints the odoo.conf and docker-compose.yml of an environment
+func Environment(state *config.State) ([]Finding, error) {
+	envDir, err := config.EnvironmentDir(state.ProjectName, state.Branch)
+	if err != nil {
+		return nil, err
+	}
+	confText, err := os.ReadFile(filepath.Join(envDir, "odoo.conf"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read odoo.conf: %w", err)
+	}
+	composeText, err := os.ReadFile(filepath.Join(envDir, "docker-compose.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker-compose.yml: %w", err)
+	}
+	return Check(state, ParseConf(string(confText)), ParseCompose(string(composeText)), pathExists), nil
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Check runs every check. hostPathExists reports whether a bind mount
+// source exists on the host.
+func Check(state *config.State, conf Conf, compose Compose, hostPathExists func(string) bool) []Finding {
+	var findings []Finding
+	for _, f := range conf.Duplicates {
+		f.File = "odoo.conf"
+		findings = append(findings, f)
+	}
+	findings = append(findings, checkAddonsPath(state, conf, compose, hostPathExists)...)
+	findings = append(findings, checkDBHost(conf, compose)...)
+	findings = append(findings, checkWorkers(state, conf, compose)...)
+	findings = append(findings, checkModules(conf, compose)...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity == SeverityError
+		}
+		return false
+	})
+	return findings
+}
+
+func checkAddonsPath(state *config.State, conf Conf, compose Compose, hostPathExists func(string) bool) []Finding {
+	option, ok := conf.Options["addons_path"]
+	if !ok {
+		return []Finding{{Severity: SeverityError, Check: "addons-path", File: "odoo.conf", Message: "addons_path is not set"}}
+	}
+
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, path := range strings.Split(option.Value, ",") {
+		path = strings.TrimRight(strings.TrimSpace(path), "/")
+		if path == "" {
+			continue
+		}
+		if seen[path] {
+			findings = append(findings, Finding{Severity: SeverityWarning, Check: "addons-path", File: "odoo.conf", Line: option.Line, Message: fmt.Sprintf("%s is listed twice in addons_path", path)})
+			continue
+		}
+		seen[path] = true
+
+		switch {
+		case path == imageAddonsPath:
+		case path == enterpriseAddonsPath:
+			if !state.Enterprise {
+				findings = append(findings, Finding{Severity: SeverityError, Check: "addons-path", File: "odoo.conf", Line: option.Line, Message: "/mnt/enterprise is in addons_path but this environment is not built with Enterprise"})
+			}
+		default:
+			source, mounted := mountSource(compose, path)
+			if !mounted {
+				findings = append(findings, Finding{Severity: SeverityError, Check: "addons-path", File: "odoo.conf", Line: option.Line, Message: fmt.Sprintf("%s does not exist in the container: no volume in docker-compose.yml mounts it", path)})
+			} else if filepath.IsAbs(source) && !hostPathExists(source) {
+				findings = append(findings, Finding{Severity: SeverityError, Check: "addons-path", File: "docker-compose.yml", Message: fmt.Sprintf("%s is mounted from %s, which does not exist on this machine", path, source)})
+			}
+		}
+	}
+	return findings
+}
+
+// mountSource finds the mount that provides path in the container
+func mountSource(compose Compose, path string) (string, bool) {
+	for target, source := range compose.Mounts {
+		if path == target || strings.HasPrefix(path, target+"/") {
+			return source, true
+		}
+	}
+	return "", false
+}
+
+func checkDBHost(conf Conf, compose Compose) []Finding {
+	var findings []Finding
+	host, ok := conf.Options["db_host"]
+	if !ok || host.Value == "" || host.Value == "False" {
+		findings = append(findings, Finding{Severity: SeverityError, Check: "db-host", File: "odoo.conf", Message: "db_host is not set; Odoo will look for PostgreSQL on a local socket inside the container"})
+	} else if _, isService := compose.Services[host.Value]; !isService {
+		findings = append(findings, Finding{Severity: SeverityError, Check: "db-host", File: "odoo.conf", Line: host.Line, Message: fmt.Sprintf("db_host %q is not a service in docker-compose.yml (expected db)", host.Value)})
+	}
+	if port, ok := conf.Options["db_port"]; ok && port.Value != "5432" && port.Value != "False" {
+		findings = append(findings, Finding{Severity: SeverityWarning, Check: "db-host", File: "odoo.conf", Line: port.Line, Message: fmt.Sprintf("db_port is %s but the db service listens on 5432", port.Value)})
+	}
+	return findings
+}
+
+func checkWorkers(state *config.State, conf Conf, compose Compose) []Finding {
+	workers, ok := conf.Options["workers"]
+	if !ok {
+		return nil
+	}
+	count, err := strconv.Atoi(workers.Value)
+	if err != nil {
+		return []Finding{{Severity: SeverityError, Check: "workers", File: "odoo.conf", Line: workers.Line, Message: fmt.Sprintf("workers must be a number, got %q", workers.Value)}}
+	}
+	if count == 0 {
+		return nil
+	}
+
+	// Odoo 16 renamed longpolling_port to gevent_port
+	key, legacy := "gevent_port", "longpolling_port"
+	var major int
+	fmt.Sscanf(state.OdooVersion, "%d", &major)
+	if major > 0 && major < 16 {
+		key, legacy = legacy, key
+	}
+
+	var findings []Finding
+	port := defaultLongpolling
+	if option, ok := conf.Options[legacy]; ok {
+		findings = append(findings, Finding{Severity: SeverityWarning, Check: "workers", File: "odoo.conf", Line: option.Line, Message: fmt.Sprintf("%s is not used by Odoo %s; use %s", legacy, state.OdooVersion, key)})
+	}
+	if option, ok := conf.Options[key]; ok {
+		if value, err := strconv.Atoi(option.Value); err == nil {
+			port = value
+		}
+	}
+	if _, published := compose.Published[port]; !published {
+		findings = append(findings, Finding{Severity: SeverityWarning, Check: "workers", File: "docker-compose.yml", Message: fmt.Sprintf("workers = %d runs the bus on %s %d, which the odoo service does not publish; live updates (chat, notifications) will not work. Publish it or set workers = 0", count, key, port)})
+	}
+	return findings
+}
+
+func checkModules(conf Conf, compose Compose) []Finding {
+	var findings []Finding
+	if option, ok := conf.Options["server_wide_modules"]; ok {
+		for _, module := range duplicates(strings.Split(option.Value, ",")) {
+			findings = append(findings, Finding{Severity: SeverityWarning, Check: "modules", File: "odoo.conf", Line: option.Line, Message: fmt.Sprintf("%s is listed more than once in server_wide_modules", module)})
+		}
+	}
+	for _, module := range duplicates(compose.InitModules) {
+		findings = append(findings, Finding{Severity: SeverityWarning, Check: "modules", File: "docker-compose.yml", Line: compose.InitModulesLine, Message: fmt.Sprintf("%s is listed more than once in the odoo-init -i list", module)})
+	}
+	return findings
+}
+
+func duplicates(items []string) []string {
+	var result []string
+	count := make(map[string]int)
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		count[item]++
+		if count[item] == 2 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
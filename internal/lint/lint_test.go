@@ -0,0 +1,129 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/templates"
+)
+
+func TestEnvironmentGeneratedFilesAreClean(t *testing.T) {
+	for _, version := range []string{"12.0", "17.0", "19.0"} {
+		t.Run(version, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			addons := filepath.Join(home, "extra")
+			if err := os.Mkdir(addons, 0755); err != nil {
+				t.Fatal(err)
+			}
+			state := &config.State{
+				ProjectName: "lint-project",
+				OdooVersion: version,
+				Branch:      "main",
+				ProjectRoot: home,
+				Modules:     []string{"sale"},
+				AddonsPaths: []string{addons},
+				Enterprise:  version == "17.0",
+				Ports:       config.CalculatePorts(version),
+			}
+			if err := templates.Render(state); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			findings, err := Environment(state)
+			if err != nil {
+				t.Fatalf("Environment() error = %v", err)
+			}
+			if len(findings) > 0 {
+				t.Fatalf("generated files have findings: %+v", findings)
+			}
+		})
+	}
+}
+
+const lintCompose = `name: 170-demo
+x-odoo-common: &odoo-common
+  volumes:
+    - /home/me/project:/mnt/extra-addons
+    - ./odoo.conf:/etc/odoo/odoo.conf:ro
+    - odoo-filestore-170:/var/lib/odoo/filestore
+    - /home/me/gone:/mnt/custom-addons-0:ro
+
+services:
+  db:
+    image: postgres:15
+  odoo-init:
+    <<: *odoo-common
+    command: ["-c", "/etc/odoo/odoo.conf", "-d", "demo", "-i", "base,sale,sale", "--stop-after-init"]
+  odoo:
+    <<: *odoo-common
+    ports:
+      - "9700:8069"
+      - "5778:5678"
+`
+
+func TestCheckFindsMisconfigurations(t *testing.T) {
+	conf := ParseConf(`[options]
+db_host = postgres
+db_port = 5433
+addons_path = /usr/lib/python3/dist-packages/odoo/addons,/mnt/extra-addons,/mnt/custom-addons-0,/mnt/other,/mnt/enterprise
+workers = 2
+longpolling_port = 8072
+server_wide_modules = base,web,base
+workers = 4
+`)
+	compose := ParseCompose(lintCompose)
+	state := &config.State{OdooVersion: "17.0"}
+	exists := func(path string) bool { return path != "/home/me/gone" }
+
+	findings := Check(state, conf, compose, exists)
+
+	want := []struct {
+		severity Severity
+		check    string
+		message  string
+	}{
+		{SeverityError, "duplicate-option", "workers is set again (first on line 5)"},
+		{SeverityError, "addons-path", "mounted from /home/me/gone"},
+		{SeverityError, "addons-path", "/mnt/other does not exist in the container"},
+		{SeverityError, "addons-path", "/mnt/enterprise is in addons_path"},
+		{SeverityError, "db-host", `db_host "postgres" is not a service`},
+		{SeverityWarning, "db-host", "db_port is 5433"},
+		{SeverityWarning, "workers", "longpolling_port is not used by Odoo 17.0"},
+		{SeverityWarning, "workers", "gevent_port 8072, which the odoo service does not publish"},
+		{SeverityWarning, "modules", "base is listed more than once in server_wide_modules"},
+		{SeverityWarning, "modules", "sale is listed more than once in the odoo-init -i list"},
+	}
+	for _, w := range want {
+		found := false
+		for _, f := range findings {
+			if f.Severity == w.severity && f.Check == w.check && strings.Contains(f.Message, w.message) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing %s %s finding %q in %+v", w.severity, w.check, w.message, findings)
+		}
+	}
+	if len(findings) != len(want) {
+		t.Errorf("got %d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+	for i := 1; i < len(findings); i++ {
+		if findings[i-1].Severity == SeverityWarning && findings[i].Severity == SeverityError {
+			t.Fatalf("errors are not listed before warnings: %+v", findings)
+		}
+	}
+}
+
+func TestCheckWorkersBeforeOdoo16UsesLongpollingPort(t *testing.T) {
+	conf := ParseConf("[options]\nworkers = 2\nlongpolling_port = 8072\n")
+	compose := ParseCompose(strings.Replace(lintCompose, `- "5778:5678"`, `- "9772:8072"`, 1))
+
+	for _, f := range checkWorkers(&config.State{OdooVersion: "15.0"}, conf, compose) {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+}
@@ -0,0 +1,120 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is an optional file at the module root listing extra
+// gitignore-style patterns to exclude from Hash, one per line. Blank lines
+// and lines starting with # are skipped, a leading ! re-includes matching
+// paths, and the last matching pattern wins. Patterns use filepath.Match
+// syntax and match the path relative to the module root or one of its
+// parent directories; patterns without a slash also match a file or
+// directory name at any depth, as in .gitignore.
+const IgnoreFileName = ".odooctlignore"
+
+type excludeRule struct {
+	pattern  string
+	negate   bool
+	anyDepth bool
+}
+
+// excludeRules decides which module files are left out of change detection
+type excludeRules []excludeRule
+
+var defaultExcludeRules = newExcludeRules(nil)
+
+// newExcludeRules combines DefaultExcludePatterns with patterns parsed from
+// an ignore file, which take precedence
+func newExcludeRules(ignorePatterns []string) excludeRules {
+	rules := make(excludeRules, 0, len(DefaultExcludePatterns)+len(ignorePatterns))
+	for _, pattern := range DefaultExcludePatterns {
+		rules = append(rules, excludeRule{pattern: pattern})
+	}
+	for _, line := range ignorePatterns {
+		rule := excludeRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimSuffix(line, "/")
+		if strings.HasPrefix(line, "/") {
+			line = strings.TrimPrefix(line, "/")
+		} else if !strings.Contains(line, "/") {
+			rule.anyDepth = true
+		}
+		if line == "" {
+			continue
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// loadExcludeRules reads the module's ignore file, if any, and returns the
+// rules together with the patterns it contained
+func loadExcludeRules(moduleDir string) (excludeRules, []string, error) {
+	data, err := os.ReadFile(filepath.Join(moduleDir, IgnoreFileName))
+	if os.IsNotExist(err) {
+		return defaultExcludeRules, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	patterns := parseIgnorePatterns(string(data))
+	return newExcludeRules(patterns), patterns, nil
+}
+
+func parseIgnorePatterns(text string) []string {
+	var patterns []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
+
+// excludes reports whether relPath is left out of the hash
+func (rules excludeRules) excludes(relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	excluded := false
+	for _, rule := range rules {
+		if rule.matches(relPath) {
+			excluded = !rule.negate
+		}
+	}
+	return excluded
+}
+
+// reincludes reports whether any rule re-includes paths, in which case an
+// excluded directory may still contain files that count
+func (rules excludeRules) reincludes() bool {
+	for _, rule := range rules {
+		if rule.negate {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule excludeRule) matches(relPath string) bool {
+	// Check the path and each of its parent directories
+	parts := strings.Split(relPath, "/")
+	for i := range parts {
+		if matched, _ := filepath.Match(rule.pattern, strings.Join(parts[:i+1], "/")); matched {
+			return true
+		}
+		if rule.anyDepth {
+			if matched, _ := filepath.Match(rule.pattern, parts[i]); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExcludeRules(t *testing.T) {
+	rules := newExcludeRules(parseIgnorePatterns(`
+# generated fixtures
+*.xlsx
+/data/generated/
+!static/description/icon.png
+`))
+
+	for path, want := range map[string]bool{
+		"models/model.py":             false,
+		"tests/test_x.py":             true,
+		"static/src/app.js":           true,
+		"static/description/icon.png": false,
+		"report.xlsx":                 true,
+		"data/fixtures/report.xlsx":   true,
+		"data/generated/out.xml":      true,
+		"data/demo.xml":               false,
+		"sub/data/generated/out.xml":  false,
+		"i18n/my_module.pot":          true,
+		"i18n/fr.po":                  false,
+	} {
+		if got := rules.excludes(path); got != want {
+			t.Errorf("excludes(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestHashRespectsIgnoreFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hash := func() string {
+		t.Helper()
+		h, err := Hash(dir)
+		if err != nil {
+			t.Fatalf("Hash() error = %v", err)
+		}
+		return h
+	}
+
+	write("__manifest__.py", "{}")
+	write("data/fixture.xlsx", "v1")
+	initial := hash()
+
+	write(IgnoreFileName, "*.xlsx\n")
+	ignored := hash()
+	if ignored == initial {
+		t.Fatal("Hash() did not change when the ignore file was added")
+	}
+
+	write("data/fixture.xlsx", "v2")
+	if got := hash(); got != ignored {
+		t.Fatal("Hash() changed for an ignored file")
+	}
+
+	write(IgnoreFileName, "*.xlsx\n!data/fixture.xlsx\n")
+	reincluded := hash()
+	write("data/fixture.xlsx", "v3")
+	if got := hash(); got == reincluded {
+		t.Fatal("Hash() did not change for a re-included file")
+	}
+}
@@ -13,6 +13,8 @@ type ManifestInfo struct {
 	Name           string   `json:"name"`
 	Version        string   `json:"version"`
 	Depends        []string `json:"depends"`
+	Data           []string `json:"data,omitempty"`
+	Demo           []string `json:"demo,omitempty"`
 	ExternalPython []string `json:"external_python"`
 	Installable    bool     `json:"installable"`
 	Application    bool     `json:"application"`
@@ -31,6 +33,8 @@ func ParseManifest(moduleDir string) (ManifestInfo, error) {
 		Name:        parseStringField(text, "name"),
 		Version:     parseStringField(text, "version"),
 		Depends:     parseListField(text, "depends"),
+		Data:        parseListField(text, "data"),
+		Demo:        parseListField(text, "demo"),
 		Installable: true,
 	}
 	info.ExternalPython = parseExternalPython(text)
@@ -43,6 +47,15 @@ func ParseManifest(moduleDir string) (ManifestInfo, error) {
 	return info, nil
 }
 
+// ParseDepends reads the depends list of a __manifest__.py file
+func ParseDepends(manifestPath string) ([]string, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseListField(string(data), "depends"), nil
+}
+
 func parseStringField(text, key string) string {
 	re := regexp.MustCompile(`["']` + regexp.QuoteMeta(key) + `["']\s*:\s*["']([^"']*)["']`)
 	matches := re.FindStringSubmatch(text)
@@ -80,7 +93,7 @@ func parseExternalPython(text string) []string {
 
 func parsePythonStringList(listContent string) []string {
 	re := regexp.MustCompile(`["']([^"']+)["']`)
-	matches := re.FindAllStringSubmatch(listContent, -1)
+	matches := re.FindAllStringSubmatch(stripPythonComments(listContent), -1)
 	values := make([]string, 0, len(matches))
 	seen := make(map[string]bool)
 	for _, match := range matches {
@@ -96,3 +109,31 @@ func parsePythonStringList(listContent string) []string {
 	}
 	return values
 }
+
+// stripPythonComments drops # comments, so commented-out list entries are
+// not read as values
+func stripPythonComments(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = line[:commentIndex(line)]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commentIndex returns where a # comment starts on a line, or its length
+func commentIndex(line string) int {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return i
+		}
+	}
+	return len(line)
+}
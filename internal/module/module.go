@@ -3,11 +3,14 @@ package module
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 // DefaultExcludePatterns are patterns to exclude from hash calculation
@@ -77,12 +80,22 @@ func ExpandPatterns(patterns []string, available []string) []string {
 	return result
 }
 
-// Hash calculates SHA256 hash of an Odoo module directory
+// Hash calculates SHA256 hash of an Odoo module directory. Files matching
+// DefaultExcludePatterns or the module's IgnoreFileName are left out.
 func Hash(moduleDir string) (string, error) {
 	hasher := sha256.New()
 
+	rules, ignorePatterns, err := loadExcludeRules(moduleDir)
+	if err != nil {
+		return "", err
+	}
+	// Editing the ignore file changes what is hashed, so it changes the hash
+	for _, pattern := range ignorePatterns {
+		hasher.Write([]byte(pattern + "\n"))
+	}
+
 	var files []string
-	err := filepath.Walk(moduleDir, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(moduleDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -93,7 +106,7 @@ func Hash(moduleDir string) (string, error) {
 		relPath, _ := filepath.Rel(moduleDir, path)
 
 		// Check exclusions
-		if shouldExclude(relPath) {
+		if rules.excludes(relPath) {
 			return nil
 		}
 
@@ -129,27 +142,76 @@ func Hash(moduleDir string) (string, error) {
 	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
-func shouldExclude(relPath string) bool {
-	// Normalize path separators
-	relPath = filepath.ToSlash(relPath)
+// errModified stops the walk in ModifiedSince at the first newer entry
+var errModified = errors.New("modified")
 
-	for _, pattern := range DefaultExcludePatterns {
-		// Check if path matches pattern
-		matched, _ := filepath.Match(pattern, relPath)
-		if matched {
-			return true
+// ModifiedSince reports whether any file or directory of the module has a
+// modification time after t. Directories are included so deleted and renamed
+// files count as changes. It is a cheap pre-filter for Hash: a false result
+// means the contents can be assumed unchanged, a true result needs hashing.
+func ModifiedSince(moduleDir string, t time.Time) (bool, error) {
+	rules, _, err := loadExcludeRules(moduleDir)
+	if err != nil {
+		return false, err
+	}
+	reincludes := rules.reincludes()
+	err = filepath.Walk(moduleDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
 		}
-
-		// Check if any parent directory matches
-		parts := strings.Split(relPath, "/")
-		for i := range parts {
-			partial := strings.Join(parts[:i+1], "/")
-			matched, _ = filepath.Match(pattern, partial)
-			if matched {
-				return true
+		relPath, _ := filepath.Rel(moduleDir, path)
+		if relPath != "." && rules.excludes(relPath) {
+			if info.IsDir() && !reincludes {
+				return filepath.SkipDir
 			}
+			return nil
 		}
+		if info.ModTime().After(t) {
+			return errModified
+		}
+		return nil
+	})
+	if errors.Is(err, errModified) {
+		return true, nil
+	}
+	return false, err
+}
+
+// HashResult is the outcome of hashing one module with HashModules
+type HashResult struct {
+	Module string
+	Hash   string
+	Err    error
+}
+
+// HashModules hashes the modules under root with up to workers goroutines.
+// Results are returned in the order of modules; a failing module does not
+// stop the others.
+func HashModules(root string, modules []string, workers int) []HashResult {
+	results := make([]HashResult, len(modules))
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(modules) {
+		workers = len(modules)
 	}
 
-	return false
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				hash, err := Hash(filepath.Join(root, modules[i]))
+				results[i] = HashResult{Module: modules[i], Hash: hash, Err: err}
+			}
+		}()
+	}
+	for i := range modules {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
 }
@@ -0,0 +1,119 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestModifiedSince(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{"models", "__pycache__", "static/src"} {
+		if err := os.MkdirAll(filepath.Join(dir, path), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"__manifest__.py", "models/model.py", "__pycache__/x.pyc", "static/src/app.js"} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Age everything so only later touches count
+	old := time.Now().Add(-time.Hour)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := old.Add(time.Minute)
+
+	assertModified := func(want bool) {
+		t.Helper()
+		got, err := ModifiedSince(dir, since)
+		if err != nil {
+			t.Fatalf("ModifiedSince() error = %v", err)
+		}
+		if got != want {
+			t.Fatalf("ModifiedSince() = %v, want %v", got, want)
+		}
+	}
+	assertModified(false)
+
+	// Excluded files don't count, like in Hash
+	now := time.Now()
+	for _, file := range []string{"__pycache__/x.pyc", "static/src/app.js"} {
+		if err := os.Chtimes(filepath.Join(dir, file), now, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertModified(false)
+
+	if err := os.Chtimes(filepath.Join(dir, "models", "model.py"), now, now); err != nil {
+		t.Fatal(err)
+	}
+	assertModified(true)
+}
+
+func TestModifiedSinceCountsDirectories(t *testing.T) {
+	dir := t.TempDir()
+	models := filepath.Join(dir, "models")
+	if err := os.Mkdir(models, 0755); err != nil {
+		t.Fatal(err)
+	}
+	since := time.Now().Add(-time.Minute)
+	old := since.Add(-time.Hour)
+	if err := os.Chtimes(dir, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleting or renaming a file only changes its directory's mtime
+	if err := os.Chtimes(models, time.Now(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if modified, err := ModifiedSince(dir, since); err != nil || !modified {
+		t.Fatalf("ModifiedSince() = %v, %v; want true for a changed directory", modified, err)
+	}
+}
+
+func TestHashModules(t *testing.T) {
+	root := t.TempDir()
+	var modules []string
+	for i := 0; i < 6; i++ {
+		name := fmt.Sprintf("mod_%d", i)
+		modules = append(modules, name)
+		if err := os.MkdirAll(filepath.Join(root, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name, "__manifest__.py"), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	modules = append(modules, "missing")
+
+	results := HashModules(root, modules, 3)
+	if len(results) != len(modules) {
+		t.Fatalf("HashModules() returned %d results, want %d", len(results), len(modules))
+	}
+	for i, result := range results {
+		if result.Module != modules[i] {
+			t.Fatalf("results[%d].Module = %q, want %q", i, result.Module, modules[i])
+		}
+		if result.Module == "missing" {
+			if result.Err == nil {
+				t.Fatal("HashModules() error = nil for a missing module")
+			}
+			continue
+		}
+		want, err := Hash(filepath.Join(root, result.Module))
+		if err != nil || result.Err != nil || result.Hash != want {
+			t.Fatalf("results[%d] = %+v, want hash %s", i, result, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package module
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// InstallBatches groups targets into batches so that every module comes
+// after the targets it depends on. Dependencies outside targets are
+// ignored. Modules caught in a dependency cycle are returned together in a
+// final batch and left for Odoo to resolve.
+func InstallBatches(targets []string, depends map[string][]string) [][]string {
+	targetSet := make(map[string]bool, len(targets))
+	for _, mod := range targets {
+		targetSet[mod] = true
+	}
+
+	pending := make(map[string]int)
+	dependents := make(map[string][]string)
+	for mod := range targetSet {
+		pending[mod] = 0
+		seen := make(map[string]bool)
+		for _, dep := range depends[mod] {
+			if !targetSet[dep] || dep == mod || seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			pending[mod]++
+			dependents[dep] = append(dependents[dep], mod)
+		}
+	}
+
+	var batches [][]string
+	for len(pending) > 0 {
+		var batch []string
+		for mod, count := range pending {
+			if count == 0 {
+				batch = append(batch, mod)
+			}
+		}
+		if len(batch) == 0 {
+			// Only cycles remain
+			for mod := range pending {
+				batch = append(batch, mod)
+			}
+			sort.Strings(batch)
+			return append(batches, batch)
+		}
+		sort.Strings(batch)
+		for _, mod := range batch {
+			delete(pending, mod)
+			for _, dependent := range dependents[mod] {
+				if _, ok := pending[dependent]; ok {
+					pending[dependent]--
+				}
+			}
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// CycleError reports local modules that depend on each other in a loop
+type CycleError struct {
+	// Cycle lists the modules in dependency order, ending with the first
+	Cycle []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle between local modules: %s", strings.Join(e.Cycle, " -> "))
+}
+
+// InstallOrder sorts targets so that every module comes after the targets it
+// depends on, keeping independent modules in alphabetical order.
+// Dependencies outside targets are ignored. A dependency cycle is returned
+// as a *CycleError.
+func InstallOrder(targets []string, depends map[string][]string) ([]string, error) {
+	targetSet := make(map[string]bool, len(targets))
+	for _, mod := range targets {
+		targetSet[mod] = true
+	}
+	sorted := make([]string, 0, len(targetSet))
+	for mod := range targetSet {
+		sorted = append(sorted, mod)
+	}
+	sort.Strings(sorted)
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	marks := make(map[string]int, len(sorted))
+	order := make([]string, 0, len(sorted))
+	var path []string
+	var visit func(mod string) error
+	visit = func(mod string) error {
+		switch marks[mod] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for i, entry := range path {
+				if entry == mod {
+					start = i
+				}
+			}
+			cycle := append(append([]string{}, path[start:]...), mod)
+			return &CycleError{Cycle: cycle}
+		}
+		marks[mod] = visiting
+		path = append(path, mod)
+		deps := append([]string{}, depends[mod]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if !targetSet[dep] {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[mod] = done
+		order = append(order, mod)
+		return nil
+	}
+	for _, mod := range sorted {
+		if err := visit(mod); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,96 @@
+package module
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInstallBatchesOrdersDependencyChain(t *testing.T) {
+	depends := map[string][]string{
+		"sale_extra":   {"sale", "sale_base"},
+		"sale_base":    {"base", "core_tools"},
+		"core_tools":   {"base"},
+		"stock_report": {"stock"},
+	}
+	got := InstallBatches([]string{"sale_extra", "stock_report", "core_tools", "sale_base"}, depends)
+	want := [][]string{{"core_tools", "stock_report"}, {"sale_base"}, {"sale_extra"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("InstallBatches() = %v, want %v", got, want)
+	}
+}
+
+func TestInstallBatchesIgnoresNonTargetDependencies(t *testing.T) {
+	depends := map[string][]string{"b": {"a"}, "c": {"a"}}
+	got := InstallBatches([]string{"c", "b"}, depends)
+	want := [][]string{{"b", "c"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("InstallBatches() = %v, want %v", got, want)
+	}
+}
+
+func TestInstallBatchesKeepsCyclesInFinalBatch(t *testing.T) {
+	depends := map[string][]string{"a": {"b"}, "b": {"a"}, "c": {"a"}, "d": nil}
+	got := InstallBatches([]string{"a", "b", "c", "d"}, depends)
+	want := [][]string{{"d"}, {"a", "b", "c"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("InstallBatches() = %v, want %v", got, want)
+	}
+}
+
+func TestInstallOrder(t *testing.T) {
+	depends := map[string][]string{
+		"sale_extra":   {"sale", "sale_base"},
+		"sale_base":    {"base", "core_tools"},
+		"core_tools":   {"base"},
+		"stock_report": {"stock"},
+	}
+	got, err := InstallOrder([]string{"sale_extra", "stock_report", "core_tools", "sale_base"}, depends)
+	if err != nil {
+		t.Fatalf("InstallOrder() error = %v", err)
+	}
+	want := []string{"core_tools", "sale_base", "sale_extra", "stock_report"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("InstallOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestInstallOrderReportsCycle(t *testing.T) {
+	depends := map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}, "d": nil}
+	_, err := InstallOrder([]string{"d", "c", "b", "a"}, depends)
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("InstallOrder() error = %v, want a *CycleError", err)
+	}
+	want := []string{"a", "b", "c", "a"}
+	if !reflect.DeepEqual(cycleErr.Cycle, want) {
+		t.Fatalf("CycleError.Cycle = %v, want %v", cycleErr.Cycle, want)
+	}
+}
+
+func TestParseDepends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "__manifest__.py")
+	manifest := `{
+    'name': 'Sale Extra',
+    'depends': [
+        'sale',
+        "sale_base",  # local
+    ],
+    'data': ['views/sale.xml'],
+}`
+	if err := os.WriteFile(path, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseDepends(path)
+	if err != nil {
+		t.Fatalf("ParseDepends() error = %v", err)
+	}
+	if want := []string{"sale", "sale_base"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseDepends() = %v, want %v", got, want)
+	}
+	if _, err := ParseDepends(filepath.Join(t.TempDir(), "__manifest__.py")); err == nil {
+		t.Fatal("ParseDepends() error = nil for a missing manifest")
+	}
+}
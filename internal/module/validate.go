@@ -0,0 +1,137 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Severities of validation issues
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+var (
+	manifestVersionPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*$`)
+	moduleNamePattern      = regexp.MustCompile(`^[a-z0-9_]+$`)
+	pythonPackagePattern   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+// dataFileExtensions are the file types Odoo loads from data and demo
+var dataFileExtensions = map[string]bool{".xml": true, ".csv": true, ".sql": true, ".yml": true}
+
+// ValidationIssue is a problem found in a module manifest
+type ValidationIssue struct {
+	Severity string `json:"severity"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+// ValidationResult holds the issues found in one module
+type ValidationResult struct {
+	Module string            `json:"module"`
+	Path   string            `json:"path"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// Errors counts the issues that make the module fail validation
+func (r ValidationResult) Errors() int {
+	return r.count(SeverityError)
+}
+
+// Warnings counts the issues that are reported but don't fail validation
+func (r ValidationResult) Warnings() int {
+	return r.count(SeverityWarning)
+}
+
+func (r ValidationResult) count(severity string) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// Validate lints a module's __manifest__.py: required keys, the version
+// format, depends names, external Python dependencies, and whether the data
+// and demo files it lists exist.
+func Validate(moduleDir string) (ValidationResult, error) {
+	manifest, err := ParseManifest(moduleDir)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	result := ValidationResult{Module: manifest.Module, Path: manifest.Path, Issues: []ValidationIssue{}}
+	add := func(severity, field, format string, args ...any) {
+		result.Issues = append(result.Issues, ValidationIssue{Severity: severity, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(manifest.Name) == "" {
+		add(SeverityError, "name", "name is missing or empty")
+	}
+
+	switch version := manifest.Version; {
+	case version == "":
+		add(SeverityWarning, "version", "version is missing; Odoo falls back to 1.0")
+	case !manifestVersionPattern.MatchString(version):
+		add(SeverityError, "version", "version %q is not a dotted number like 17.0.1.0.0 or 1.0.0", version)
+	default:
+		parts := strings.Split(version, ".")
+		if len(parts) == 4 || len(parts) > 5 || (len(parts) == 5 && parts[1] != "0") {
+			add(SeverityWarning, "version", "version %q should be <odoo series>.x.y.z (e.g. 17.0.1.0.0) or x.y.z", version)
+		}
+	}
+
+	if len(manifest.Depends) == 0 {
+		add(SeverityWarning, "depends", "depends is empty; list at least base")
+	}
+	for _, dep := range manifest.Depends {
+		switch {
+		case dep == manifest.Module:
+			add(SeverityError, "depends", "%s depends on itself", dep)
+		case !moduleNamePattern.MatchString(dep):
+			add(SeverityError, "depends", "%q is not a valid module name (lowercase letters, numbers and underscores)", dep)
+		}
+	}
+
+	for _, pkg := range manifest.ExternalPython {
+		if !pythonPackagePattern.MatchString(pkg) {
+			add(SeverityWarning, "external_dependencies", "%q is not a plain package name; Odoo checks it by name, so version specifiers break the check", pkg)
+		}
+	}
+
+	listed := make(map[string]bool)
+	for _, field := range []struct {
+		name  string
+		files []string
+	}{{"data", manifest.Data}, {"demo", manifest.Demo}} {
+		for _, file := range field.files {
+			listed[path.Clean(file)] = true
+			info, err := os.Stat(filepath.Join(moduleDir, filepath.FromSlash(file)))
+			switch {
+			case err != nil:
+				add(SeverityError, field.name, "%s does not exist", file)
+			case info.IsDir():
+				add(SeverityError, field.name, "%s is a directory", file)
+			case !dataFileExtensions[strings.ToLower(filepath.Ext(file))]:
+				add(SeverityWarning, field.name, "%s is not a file type Odoo loads (.xml, .csv, .sql)", file)
+			}
+		}
+	}
+
+	// Access rights that are never loaded leave models inaccessible
+	securityFiles, _ := filepath.Glob(filepath.Join(moduleDir, "security", "*.csv"))
+	for _, file := range securityFiles {
+		rel := "security/" + filepath.Base(file)
+		if !listed[rel] {
+			add(SeverityWarning, "data", "%s exists but is not listed in data", rel)
+		}
+	}
+
+	return result, nil
+}
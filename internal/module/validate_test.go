@@ -0,0 +1,87 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my_module")
+	writeTestFile(t, filepath.Join(dir, "__manifest__.py"), `{
+    'version': '17.0.1.0',
+    'depends': ['base', 'Sale-Extra', 'my_module'],
+    'external_dependencies': {'python': ['requests>=2']},
+    'data': [
+        'views/views.xml',
+        'views/missing.xml',
+        # 'views/old.xml',
+        'README.md',
+    ],
+}`)
+	writeTestFile(t, filepath.Join(dir, "views", "views.xml"), "<odoo/>")
+	writeTestFile(t, filepath.Join(dir, "README.md"), "")
+	writeTestFile(t, filepath.Join(dir, "security", "ir.model.access.csv"), "id\n")
+
+	result, err := Validate(dir)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.Severity+" "+issue.Field+": "+issue.Message)
+	}
+	got := strings.Join(messages, "\n")
+	for _, want := range []string{
+		"error name: name is missing",
+		`warning version: version "17.0.1.0"`,
+		`error depends: "Sale-Extra" is not a valid module name`,
+		"error depends: my_module depends on itself",
+		`warning external_dependencies: "requests>=2"`,
+		"error data: views/missing.xml does not exist",
+		"warning data: README.md is not a file type",
+		"warning data: security/ir.model.access.csv exists but is not listed in data",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("issues missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "old.xml") || strings.Contains(got, "views/views.xml") {
+		t.Errorf("issues reported a valid or commented-out file:\n%s", got)
+	}
+	if result.Errors() != 4 || result.Warnings() != 4 {
+		t.Errorf("Errors() = %d, Warnings() = %d, want 4 and 4", result.Errors(), result.Warnings())
+	}
+}
+
+func TestValidateCleanModule(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my_module")
+	writeTestFile(t, filepath.Join(dir, "__manifest__.py"), `{
+    "name": "My Module",
+    "version": "17.0.1.0.0",
+    "depends": ["base"],
+    "data": ["security/ir.model.access.csv"],
+    "demo": ["demo/demo.xml"],
+}`)
+	writeTestFile(t, filepath.Join(dir, "security", "ir.model.access.csv"), "id\n")
+	writeTestFile(t, filepath.Join(dir, "demo", "demo.xml"), "<odoo/>")
+
+	result, err := Validate(dir)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(result.Issues) != 0 {
+		t.Fatalf("Validate() issues = %+v, want none", result.Issues)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
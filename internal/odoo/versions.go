@@ -1,6 +1,9 @@
 package odoo
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
 var OdooVersions = []string{
 	"19.0",
@@ -15,6 +18,32 @@ var OdooVersions = []string{
 
 var DefaultOdooVersion = "19.0"
 
+var versionPattern = regexp.MustCompile(`^[1-9][0-9]*(\.0)?$`)
+
+// NormalizeVersion trims a version and completes a bare major ("17" becomes
+// "17.0"). It reports whether the result is a supported version; anything
+// that doesn't look like a version is returned trimmed but unchanged.
+func NormalizeVersion(version string) (string, bool) {
+	version = strings.TrimSpace(version)
+	if !versionPattern.MatchString(version) {
+		return version, false
+	}
+	if !strings.HasSuffix(version, ".0") {
+		version += ".0"
+	}
+	return version, IsValidVersion(version)
+}
+
+// IsValidVersion reports whether version is one of OdooVersions
+func IsValidVersion(version string) bool {
+	for _, supported := range OdooVersions {
+		if version == supported {
+			return true
+		}
+	}
+	return false
+}
+
 // VersionsString returns a comma-separated list of supported versions
 func VersionsString() string {
 	return strings.Join(OdooVersions, ", ")
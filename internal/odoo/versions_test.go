@@ -0,0 +1,36 @@
+package odoo
+
+import "testing"
+
+func TestIsValidVersion(t *testing.T) {
+	for _, version := range OdooVersions {
+		if !IsValidVersion(version) {
+			t.Errorf("IsValidVersion(%q) = false", version)
+		}
+	}
+	for _, version := range []string{"", "17", "17.0 ", "11.0", "abc"} {
+		if IsValidVersion(version) {
+			t.Errorf("IsValidVersion(%q) = true", version)
+		}
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"17.0", "17.0", true},
+		{"17.0\n", "17.0", true},
+		{" 17 ", "17.0", true},
+		{"25", "25.0", false},
+		{"17.1", "17.1", false},
+		{"saas-17.2", "saas-17.2", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := NormalizeVersion(tt.in); got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeVersion(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
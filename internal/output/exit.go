@@ -0,0 +1,10 @@
+package output
+
+// ExitCodeError is returned by commands whose exit status carries meaning
+// beyond success or failure.
+type ExitCodeError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitCodeError) Error() string { return e.Message }
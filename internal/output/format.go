@@ -0,0 +1,90 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// Format selects how list-style commands print their results.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatCSV   Format = "csv"
+	FormatJSON  Format = "json"
+)
+
+// FormatFlagUsage is the shared help text for --output flags.
+const FormatFlagUsage = "Output format: table, csv or json"
+
+// ParseFormat validates an --output value. An empty value means table.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatTable:
+		return FormatTable, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q (supported: table, csv, json)", value)
+	}
+}
+
+// ResolveFormat combines an --output value with a legacy --json flag.
+func ResolveFormat(value string, jsonFlag bool) (Format, error) {
+	if jsonFlag {
+		return FormatJSON, nil
+	}
+	return ParseFormat(value)
+}
+
+// Table is tabular data printed as an aligned table or CSV.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Print writes data in the given format to stdout. JSON output uses value,
+// table and CSV output use table.
+func Print(format Format, table Table, value any) error {
+	switch format {
+	case FormatJSON:
+		return PrintJSON(value)
+	case FormatCSV:
+		return WriteCSV(os.Stdout, table)
+	default:
+		return WriteTable(os.Stdout, table)
+	}
+}
+
+// WriteTable writes an aligned table with an upper-case header row.
+func WriteTable(w io.Writer, table Table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(table.Headers))
+	for i, header := range table.Headers {
+		headers[i] = strings.ToUpper(header)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range table.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// WriteCSV writes RFC 4180 CSV, quoting fields that contain commas,
+// quotes or newlines.
+func WriteCSV(w io.Writer, table Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Headers); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"": FormatTable, "table": FormatTable, "CSV": FormatCSV, "json": FormatJSON} {
+		got, err := ParseFormat(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseFormat(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Fatal("ParseFormat(yaml) error = nil, want error")
+	}
+}
+
+func TestWriteCSVEscapesCommas(t *testing.T) {
+	var buf bytes.Buffer
+	table := Table{
+		Headers: []string{"module", "depends", "path"},
+		Rows:    [][]string{{"sale_custom", "base,sale", `/home/dev/odoo, "work"/sale_custom`}},
+	}
+	if err := WriteCSV(&buf, table); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "module,depends,path\nsale_custom,\"base,sale\",\"/home/dev/odoo, \"\"work\"\"/sale_custom\"\n"
+	if buf.String() != want {
+		t.Fatalf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteTableAlignsColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := Table{Headers: []string{"module", "version"}, Rows: [][]string{{"sale_custom", "17.0.1.0.0"}, {"web_x", "-"}}}
+	if err := WriteTable(&buf, table); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	want := "MODULE       VERSION\nsale_custom  17.0.1.0.0\nweb_x        -\n"
+	if buf.String() != want {
+		t.Fatalf("WriteTable() = %q, want %q", buf.String(), want)
+	}
+}
@@ -1,11 +1,13 @@
 package project
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/mart337i/odooctl/internal/config"
 	"github.com/mart337i/odooctl/internal/git"
+	"github.com/mart337i/odooctl/internal/odoo"
 )
 
 // Context holds all project detection results
@@ -15,6 +17,8 @@ type Context struct {
 	Branch      string
 	IsGitRepo   bool
 	Root        string
+	// Warning explains why a version source was ignored
+	Warning string
 }
 
 // Detect analyzes the current directory
@@ -31,22 +35,54 @@ func Detect(dir string) Context {
 	if gitInfo.IsRepo {
 		ctx.IsGitRepo = true
 		ctx.Name = config.SanitizeName(gitInfo.RepoName)
-		ctx.Branch = config.SanitizeName(gitInfo.Branch)
+		if branch := config.SanitizeName(gitInfo.Branch); branch != "" {
+			ctx.Branch = branch
+		}
 		ctx.Root = gitInfo.Root
 		ctx.OdooVersion = git.VersionFromBranch(gitInfo.Branch)
+
+		// A submodule may follow the superproject's version branch
+		if ctx.OdooVersion == "" && gitInfo.Superproject != "" {
+			ctx.OdooVersion = git.VersionFromBranch(git.Detect(gitInfo.Superproject).Branch)
+		}
 	}
 
-	// Check for .odooversion file
+	// Check for .odooversion file, in the project root or any parent
 	if ctx.OdooVersion == "" {
-		if data, err := os.ReadFile(filepath.Join(ctx.Root, ".odooversion")); err == nil {
-			ctx.OdooVersion = string(data)
+		if data, err := readVersionFile(ctx.Root); err == nil {
+			if version, ok := odoo.NormalizeVersion(string(data)); ok {
+				ctx.OdooVersion = version
+			} else {
+				ctx.Warning = fmt.Sprintf("ignoring .odooversion: %q is not a supported Odoo version (%s)", version, odoo.VersionsString())
+			}
 		}
 	}
 
 	// Check ODOO_VERSION env var
 	if ctx.OdooVersion == "" {
-		ctx.OdooVersion = os.Getenv("ODOO_VERSION")
+		if env := os.Getenv("ODOO_VERSION"); env != "" {
+			if version, ok := odoo.NormalizeVersion(env); ok {
+				ctx.OdooVersion = version
+			} else {
+				ctx.Warning = fmt.Sprintf("ignoring ODOO_VERSION: %q is not a supported Odoo version (%s)", version, odoo.VersionsString())
+			}
+		}
 	}
 
 	return ctx
 }
+
+// readVersionFile reads the nearest .odooversion in dir or its parents
+func readVersionFile(dir string) ([]byte, error) {
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, ".odooversion"))
+		if err == nil || !os.IsNotExist(err) {
+			return data, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, err
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,24 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectReadsParentOdooVersion(t *testing.T) {
+	t.Setenv("ODOO_VERSION", "")
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "addons", "my_project")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(parent, ".odooversion"), []byte("17\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := Detect(dir)
+	if ctx.OdooVersion != "17.0" || ctx.Name != "my_project" {
+		t.Fatalf("Detect() = %+v, want my_project on 17.0 from the parent .odooversion", ctx)
+	}
+}
@@ -0,0 +1,91 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// CIProviders lists the CI systems generate-ci has templates for
+var CIProviders = []string{"github", "gitlab"}
+
+// installScriptURL is the odooctl install script from the README
+const installScriptURL = "https://raw.githubusercontent.com/mart337i/odooctl/main/install.sh"
+
+// CIConfig describes the pipeline to generate
+type CIConfig struct {
+	Provider    string
+	OdooVersion string
+	Modules     []string // modules to install on create and test
+	Enterprise  bool
+}
+
+// ciTemplateData is passed to the CI templates
+type ciTemplateData struct {
+	OdooVersion   string
+	Enterprise    bool
+	InstallURL    string
+	CreateCommand string
+	TestCommand   string
+}
+
+// DefaultCIPath returns where a provider expects its pipeline file
+func DefaultCIPath(provider string) string {
+	if provider == "gitlab" {
+		return ".gitlab-ci.yml"
+	}
+	return ".github/workflows/odoo.yml"
+}
+
+// RenderCI renders the pipeline that creates an environment, initializes
+// the database, installs the modules and runs their tests
+func RenderCI(config CIConfig) (string, error) {
+	if !isCIProvider(config.Provider) {
+		return "", fmt.Errorf("unsupported CI provider %q (supported: %s)", config.Provider, strings.Join(CIProviders, ", "))
+	}
+	if config.OdooVersion == "" {
+		return "", fmt.Errorf("an Odoo version is required")
+	}
+
+	create := "odooctl --no-interaction docker create --odoo-version " + config.OdooVersion
+	if config.Enterprise {
+		create += " --enterprise"
+	}
+	test := "odooctl docker test"
+	if len(config.Modules) > 0 {
+		modules := strings.Join(config.Modules, ",")
+		create += " --modules " + modules
+		test += " --modules " + modules
+	}
+	data := ciTemplateData{
+		OdooVersion:   config.OdooVersion,
+		Enterprise:    config.Enterprise,
+		InstallURL:    installScriptURL,
+		CreateCommand: create,
+		TestCommand:   test,
+	}
+
+	content, err := templateFS.ReadFile("files/ci/" + config.Provider + ".yml.tmpl")
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(config.Provider).Parse(string(content))
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func isCIProvider(provider string) bool {
+	for _, p := range CIProviders {
+		if provider == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package scaffold
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderCI(t *testing.T) {
+	cases := []struct {
+		provider string
+		want     []string
+	}{
+		{"github", []string{"runs-on: ubuntu-latest", "- uses: actions/checkout@v4", "run: odooctl --no-interaction docker create --odoo-version 17.0 --modules sale_extra,stock_extra", "run: odooctl docker run --init --no-prompt", "run: odooctl docker install all", "run: odooctl docker test --modules sale_extra,stock_extra"}},
+		{"gitlab", []string{"odoo-tests:", "- odooctl --no-interaction docker create --odoo-version 17.0 --modules sale_extra,stock_extra", "- odooctl docker test --modules sale_extra,stock_extra"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.provider, func(t *testing.T) {
+			text, err := RenderCI(CIConfig{Provider: tc.provider, OdooVersion: "17.0", Modules: []string{"sale_extra", "stock_extra"}})
+			if err != nil {
+				t.Fatalf("RenderCI() error = %v", err)
+			}
+			for _, want := range append(tc.want, installScriptURL) {
+				if !strings.Contains(text, want) {
+					t.Fatalf("pipeline missing %q:\n%s", want, text)
+				}
+			}
+			if strings.Contains(text, "ODOO_ENTERPRISE_TOKEN") {
+				t.Fatalf("community pipeline mentions the enterprise token:\n%s", text)
+			}
+		})
+	}
+}
+
+func TestRenderCIEnterprise(t *testing.T) {
+	text, err := RenderCI(CIConfig{Provider: "github", OdooVersion: "18.0", Enterprise: true})
+	if err != nil {
+		t.Fatalf("RenderCI() error = %v", err)
+	}
+	for _, want := range []string{"ODOO_ENTERPRISE_TOKEN: ${{ secrets.ODOO_ENTERPRISE_TOKEN }}", `odooctl config set github-token "$ODOO_ENTERPRISE_TOKEN"`, "docker create --odoo-version 18.0 --enterprise\n"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("pipeline missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestRenderCIRejectsUnknownProvider(t *testing.T) {
+	if _, err := RenderCI(CIConfig{Provider: "jenkins", OdooVersion: "17.0"}); err == nil {
+		t.Fatal("expected an error for an unsupported provider")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 )
@@ -20,6 +21,20 @@ type ModuleConfig struct {
 	Depends     []string
 	Description string
 	WithModel   bool
+	Website     bool // controller, QWeb page and frontend assets
+	Mixin       bool // AbstractModel mixin, inherited by the model or an example model
+	// MultiCompany adds company_id and a company record rule to the
+	// generated model (the --model one, or the mixin's example model)
+	MultiCompany bool
+	// WithWizard adds a transient model with a form view and an action,
+	// bound to the generated model when there is one
+	WithWizard bool
+	// WithReport adds a QWeb PDF report on the generated model
+	WithReport bool
+	// I18n adds an i18n/<module>.pot translation template
+	I18n bool
+	// PythonVersion overrides the minimum Python version implied by Version
+	PythonVersion string
 }
 
 // TemplateData is passed to templates
@@ -33,10 +48,52 @@ type TemplateData struct {
 	Description string
 	HasModels   bool
 	UseListTag  bool // true for Odoo 18+
+
+	// AbstractModel mixin, named after the model (my.module.mixin)
+	HasMixin  bool
+	MixinName string
+
+	// company_id field and record rule on the generated model
+	HasMultiCompany bool
+	UseCompanyIDs   bool // Odoo 13+ has env.company and company_ids in rule domains
+
+	// Model the wizard and report work on (the --model one, or the mixin's
+	// example model) and its XML ID; empty when there is none
+	TargetModel    string
+	TargetModelRef string
+
+	// Wizard and report skeletons
+	HasWizard       bool
+	HasWizardAccess bool // Odoo 14+ requires access rights on transient models
+	HasReport       bool
+
+	// Generated files listed in the manifest, in load order
+	DataFiles []string
+	DemoFiles []string
+
+	// Website skeleton
+	HasWebsite   bool
+	WebsiteRoute string
+	UseAssetsKey bool // Odoo 15+ declares bundles in the manifest 'assets' key
+	UseESModules bool // Odoo 17+ frontend JS is an ES module
+
+	// Feature level derived from the Odoo and Python versions
+	OdooMajor      int
+	PythonVersion  string
+	UseAPIMulti    bool // Odoo 12 still decorates recordset methods with @api.multi
+	UseDisplayName bool // Odoo 17+ computes display_name instead of name_get
+	UseTypeHints   bool // Python 3.10+ return annotations
 }
 
 // CreateModule creates a new Odoo module directory with files
 func CreateModule(dir string, config ModuleConfig) error {
+	if config.MultiCompany && !config.WithModel && !config.Mixin {
+		return fmt.Errorf("multi-company needs a model to add company_id to (use --model or --mixin)")
+	}
+	if config.WithReport && !config.WithModel && !config.Mixin {
+		return fmt.Errorf("a report needs a model to print (use --model or --mixin)")
+	}
+
 	// Create directory structure
 	dirs := []string{
 		dir,
@@ -49,6 +106,26 @@ func CreateModule(dir string, config ModuleConfig) error {
 		dirs = append(dirs, filepath.Join(dir, "models"))
 		dirs = append(dirs, filepath.Join(dir, "views"))
 	}
+	if config.Mixin {
+		dirs = append(dirs, filepath.Join(dir, "models"))
+	}
+	if config.Website {
+		dirs = append(dirs,
+			filepath.Join(dir, "controllers"),
+			filepath.Join(dir, "views"),
+			filepath.Join(dir, "static", "src", "scss"),
+			filepath.Join(dir, "static", "src", "js"),
+		)
+	}
+	if config.WithWizard {
+		dirs = append(dirs, filepath.Join(dir, "wizard"))
+	}
+	if config.WithReport {
+		dirs = append(dirs, filepath.Join(dir, "report"))
+	}
+	if config.I18n {
+		dirs = append(dirs, filepath.Join(dir, "i18n"))
+	}
 
 	for _, d := range dirs {
 		if err := os.MkdirAll(d, 0755); err != nil {
@@ -75,6 +152,31 @@ func CreateModule(dir string, config ModuleConfig) error {
 		Description: config.Description,
 		HasModels:   config.WithModel,
 		UseListTag:  isVersion18OrHigher(config.Version),
+		HasWebsite:  config.Website,
+		HasMixin:    config.Mixin,
+	}
+	applyFeatureLevel(&data, config.Version, config.PythonVersion)
+	if config.MultiCompany {
+		data.HasMultiCompany = true
+		data.UseCompanyIDs = data.OdooMajor >= 13
+	}
+	if config.Mixin {
+		data.MixinName = data.ModelName + ".mixin"
+	}
+	if config.WithModel {
+		data.TargetModel = data.ModelName
+		data.TargetModelRef = "model_" + config.Name
+	} else if config.Mixin {
+		data.TargetModel = data.ModelName + ".example"
+		data.TargetModelRef = "model_" + config.Name + "_example"
+	}
+	data.HasWizard = config.WithWizard
+	data.HasWizardAccess = config.WithWizard && data.OdooMajor >= 14
+	data.HasReport = config.WithReport
+	if config.Website {
+		data.WebsiteRoute = "/" + strings.ReplaceAll(config.Name, "_", "-")
+		data.UseAssetsKey = data.OdooMajor >= 15
+		data.UseESModules = data.OdooMajor >= 17
 	}
 
 	// Generate files
@@ -89,6 +191,48 @@ func CreateModule(dir string, config ModuleConfig) error {
 		files["views/"+config.Name+"_views.xml"] = "files/views.xml.tmpl"
 		files["security/ir.model.access.csv"] = "files/security.csv.tmpl"
 	}
+	if config.Mixin {
+		files["models/__init__.py"] = "files/models_init.py.tmpl"
+		files["models/"+config.Name+"_mixin.py"] = "files/mixin.py.tmpl"
+		// Without --model, an example model shows how to inherit the mixin
+		if !config.WithModel {
+			files["models/"+config.Name+"_example.py"] = "files/model_example.py.tmpl"
+			files["security/ir.model.access.csv"] = "files/security.csv.tmpl"
+		}
+	}
+	if config.MultiCompany {
+		files["security/"+config.Name+"_security.xml"] = "files/security.xml.tmpl"
+	}
+	if config.Website {
+		files["controllers/__init__.py"] = "files/controllers_init.py.tmpl"
+		files["controllers/main.py"] = "files/controller.py.tmpl"
+		files["views/templates.xml"] = "files/website_templates.xml.tmpl"
+		files["static/src/scss/"+config.Name+".scss"] = "files/website.scss.tmpl"
+		files["static/src/js/"+config.Name+".js"] = "files/website.js.tmpl"
+		if !data.UseAssetsKey {
+			files["views/assets.xml"] = "files/website_assets.xml.tmpl"
+		}
+	}
+	if config.WithWizard {
+		files["wizard/__init__.py"] = "files/wizard_init.py.tmpl"
+		files["wizard/"+config.Name+"_wizard.py"] = "files/wizard.py.tmpl"
+		files["wizard/"+config.Name+"_wizard_views.xml"] = "files/wizard_views.xml.tmpl"
+		if data.HasWizardAccess {
+			files["security/ir.model.access.csv"] = "files/security.csv.tmpl"
+		}
+	}
+	if config.WithReport {
+		files["report/"+config.Name+"_report.xml"] = "files/report.xml.tmpl"
+	}
+	if config.I18n {
+		files["i18n/"+config.Name+".pot"] = "files/i18n.pot.tmpl"
+	}
+
+	outFiles := make([]string, 0, len(files))
+	for outFile := range files {
+		outFiles = append(outFiles, outFile)
+	}
+	data.DataFiles, data.DemoFiles = manifestDataFiles(outFiles)
 
 	for outFile, tmplPath := range files {
 		if err := renderFile(dir, outFile, tmplPath, data); err != nil {
@@ -99,6 +243,39 @@ func CreateModule(dir string, config ModuleConfig) error {
 	return nil
 }
 
+// manifestDataFiles picks the generated files Odoo has to load and orders
+// them: security first, then data records, then views and templates.
+// Files under demo/ go to the demo list.
+func manifestDataFiles(outFiles []string) (dataFiles, demoFiles []string) {
+	rank := map[string]int{"security": 0, "data": 1, "views": 2, "report": 3, "wizard": 4}
+	for _, file := range outFiles {
+		ext := filepath.Ext(file)
+		if ext != ".xml" && ext != ".csv" {
+			continue
+		}
+		dir := strings.SplitN(filepath.ToSlash(file), "/", 2)[0]
+		if dir == "demo" {
+			demoFiles = append(demoFiles, file)
+		} else if _, ok := rank[dir]; ok {
+			dataFiles = append(dataFiles, file)
+		}
+	}
+	sort.Slice(dataFiles, func(i, j int) bool {
+		a, b := dataFiles[i], dataFiles[j]
+		ra, rb := rank[strings.SplitN(a, "/", 2)[0]], rank[strings.SplitN(b, "/", 2)[0]]
+		if ra != rb {
+			return ra < rb
+		}
+		// Group definitions (XML) load before the access rules that use them
+		if (filepath.Ext(a) == ".csv") != (filepath.Ext(b) == ".csv") {
+			return filepath.Ext(b) == ".csv"
+		}
+		return a < b
+	})
+	sort.Strings(demoFiles)
+	return dataFiles, demoFiles
+}
+
 func renderFile(dir, outFile, tmplPath string, data TemplateData) error {
 	content, err := templateFS.ReadFile(tmplPath)
 	if err != nil {
@@ -139,12 +316,51 @@ func formatDepends(deps []string) string {
 }
 
 func isVersion18OrHigher(version string) bool {
-	if version == "" {
-		return true // default to modern
-	}
+	return odooMajor(version) >= 18
+}
+
+// odooMajor parses the major Odoo version, defaulting to the newest
+// supported one when the version is empty or unparsable.
+func odooMajor(version string) int {
 	var major int
 	if _, err := fmt.Sscanf(version, "%d", &major); err != nil {
-		return true // default to modern on parse error
+		return 19
+	}
+	return major
+}
+
+// minPythonVersions maps Odoo major versions to their minimum Python
+var minPythonVersions = map[int]string{
+	12: "3.5",
+	13: "3.6",
+	14: "3.6",
+	15: "3.7",
+	16: "3.7",
+	17: "3.10",
+	18: "3.10",
+	19: "3.10",
+}
+
+// applyFeatureLevel fills the version-dependent template switches
+func applyFeatureLevel(data *TemplateData, version, pythonVersion string) {
+	major := odooMajor(version)
+	if pythonVersion == "" {
+		pythonVersion = minPythonVersions[major]
+		if pythonVersion == "" {
+			pythonVersion = "3.10"
+		}
+	}
+	data.OdooMajor = major
+	data.PythonVersion = pythonVersion
+	data.UseAPIMulti = major <= 12
+	data.UseDisplayName = major >= 17
+	data.UseTypeHints = pythonAtLeast(pythonVersion, 3, 10)
+}
+
+func pythonAtLeast(version string, major, minor int) bool {
+	var gotMajor, gotMinor int
+	if _, err := fmt.Sscanf(version, "%d.%d", &gotMajor, &gotMinor); err != nil {
+		return false
 	}
-	return major >= 18
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
 }
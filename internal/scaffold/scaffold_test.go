@@ -0,0 +1,426 @@
+package scaffold
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCreateModuleModelIsVersionAware(t *testing.T) {
+	cases := []struct {
+		version string
+		python  string
+		want    []string
+		notWant []string
+	}{
+		{"12.0", "", []string{"from odoo import api, fields, models", "@api.multi", "def name_get(self):"}, []string{"_compute_display_name", "->"}},
+		{"16.0", "", []string{"from odoo import fields, models", "def name_get(self):"}, []string{"@api", "->"}},
+		{"17.0", "", []string{"@api.depends('name')", "def _compute_display_name(self) -> None:", "_description = 'Demo'"}, []string{"name_get"}},
+		{"16.0", "3.11", []string{"def name_get(self) -> list[tuple[int, str]]:"}, []string{"@api.multi"}},
+	}
+	for _, tc := range cases {
+		dir := filepath.Join(t.TempDir(), "demo_module")
+		config := ModuleConfig{Name: "demo_module", Author: "Me", Version: tc.version, Depends: []string{"base"}, Description: "Demo", WithModel: true, PythonVersion: tc.python}
+		if err := CreateModule(dir, config); err != nil {
+			t.Fatalf("CreateModule(%s) error = %v", tc.version, err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "models", "demo_module.py"))
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		content := string(data)
+		for _, want := range tc.want {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s/%s model missing %q:\n%s", tc.version, tc.python, want, content)
+			}
+		}
+		for _, notWant := range tc.notWant {
+			if strings.Contains(content, notWant) {
+				t.Fatalf("%s/%s model unexpectedly contains %q:\n%s", tc.version, tc.python, notWant, content)
+			}
+		}
+	}
+}
+
+func TestCreateModuleWebsiteRegistersAssetsPerVersion(t *testing.T) {
+	cases := []struct {
+		version     string
+		manifest    []string
+		notManifest []string
+		js          string
+		assetsXML   bool
+	}{
+		{"14.0", []string{"'views/templates.xml'", "'views/assets.xml'"}, []string{"'assets':"}, "require('web.public.widget')", true},
+		{"16.0", []string{"'assets': {", "'web.assets_frontend': [", "'demo_site/static/src/js/demo_site.js'"}, []string{"views/assets.xml"}, "require('web.public.widget')", false},
+		{"17.0", []string{"'assets': {", "'demo_site/static/src/scss/demo_site.scss'"}, []string{"views/assets.xml"}, `import publicWidget from "@web/legacy/js/public/public_widget";`, false},
+	}
+	for _, tc := range cases {
+		dir := filepath.Join(t.TempDir(), "demo_site")
+		config := ModuleConfig{Name: "demo_site", Author: "Me", Version: tc.version, Depends: []string{"website"}, Description: "Demo", Website: true}
+		if err := CreateModule(dir, config); err != nil {
+			t.Fatalf("CreateModule(%s) error = %v", tc.version, err)
+		}
+
+		manifest := readFile(t, filepath.Join(dir, "__manifest__.py"))
+		for _, want := range tc.manifest {
+			if !strings.Contains(manifest, want) {
+				t.Fatalf("%s manifest missing %q:\n%s", tc.version, want, manifest)
+			}
+		}
+		for _, notWant := range tc.notManifest {
+			if strings.Contains(manifest, notWant) {
+				t.Fatalf("%s manifest unexpectedly contains %q:\n%s", tc.version, notWant, manifest)
+			}
+		}
+		if js := readFile(t, filepath.Join(dir, "static", "src", "js", "demo_site.js")); !strings.Contains(js, tc.js) {
+			t.Fatalf("%s js missing %q:\n%s", tc.version, tc.js, js)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "views", "assets.xml")); (err == nil) != tc.assetsXML {
+			t.Fatalf("%s views/assets.xml exists = %v, want %v", tc.version, err == nil, tc.assetsXML)
+		}
+
+		controller := readFile(t, filepath.Join(dir, "controllers", "main.py"))
+		for _, want := range []string{"@http.route('/demo-site'", "website=True", "request.render('demo_site.demo_site_page'"} {
+			if !strings.Contains(controller, want) {
+				t.Fatalf("%s controller missing %q:\n%s", tc.version, want, controller)
+			}
+		}
+		if init := readFile(t, filepath.Join(dir, "__init__.py")); !strings.Contains(init, "from . import controllers") {
+			t.Fatalf("%s __init__.py does not import controllers:\n%s", tc.version, init)
+		}
+		if page := readFile(t, filepath.Join(dir, "views", "templates.xml")); !strings.Contains(page, `<t t-call="website.layout">`) {
+			t.Fatalf("%s templates.xml missing website layout:\n%s", tc.version, page)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error = %v", path, err)
+	}
+	return string(data)
+}
+
+func TestCreateModuleManifestListsGeneratedDataFiles(t *testing.T) {
+	cases := []struct {
+		name   string
+		config ModuleConfig
+		want   []string
+	}{
+		{"plain", ModuleConfig{Version: "17.0"}, nil},
+		{"model", ModuleConfig{Version: "17.0", WithModel: true}, []string{"security/ir.model.access.csv", "views/demo_module_views.xml"}},
+		{"website 14.0", ModuleConfig{Version: "14.0", Website: true}, []string{"views/assets.xml", "views/templates.xml"}},
+		{"model and website", ModuleConfig{Version: "17.0", WithModel: true, Website: true}, []string{"security/ir.model.access.csv", "views/demo_module_views.xml", "views/templates.xml"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "demo_module")
+			config := tc.config
+			config.Name, config.Author, config.Description, config.Depends = "demo_module", "Me", "Demo", []string{"base"}
+			if err := CreateModule(dir, config); err != nil {
+				t.Fatalf("CreateModule() error = %v", err)
+			}
+
+			manifest := readFile(t, filepath.Join(dir, "__manifest__.py"))
+			got := manifestList(t, manifest, "data")
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("manifest data = %v, want %v", got, tc.want)
+			}
+
+			// Every XML/CSV file on disk is loaded by the manifest
+			listed := make(map[string]bool)
+			for _, file := range got {
+				listed[file] = true
+			}
+			err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				rel, _ := filepath.Rel(dir, path)
+				if ext := filepath.Ext(rel); (ext == ".xml" || ext == ".csv") && !listed[filepath.ToSlash(rel)] {
+					t.Errorf("%s is generated but not listed in the manifest", rel)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestCreateModuleModelInstalls installs a scaffolded --model module with a
+// local odoo-bin, so broken views or access rules fail the test. It needs
+// Odoo and a PostgreSQL server, and ODOOCTL_TEST_ODOO_DB naming a scratch
+// database that may be created and modified. Source checkouts also set
+// ODOOCTL_TEST_ODOO_ADDONS_PATH to their addons directories.
+func TestCreateModuleModelInstalls(t *testing.T) {
+	db := os.Getenv("ODOOCTL_TEST_ODOO_DB")
+	if db == "" {
+		t.Skip("ODOOCTL_TEST_ODOO_DB not set")
+	}
+	odooBin, err := exec.LookPath("odoo")
+	if err != nil {
+		t.Skip("odoo not installed")
+	}
+	out, err := exec.Command(odooBin, "--version").Output()
+	if err != nil {
+		t.Fatalf("odoo --version error = %v", err)
+	}
+	version := regexp.MustCompile(`\d+\.\d+`).FindString(string(out))
+	if version == "" {
+		t.Fatalf("odoo --version = %q, want a version", out)
+	}
+
+	addons := t.TempDir()
+	config := ModuleConfig{Name: "demo_module", Version: version, Author: "Me", Description: "Demo", Depends: []string{"base"}, WithModel: true}
+	if err := CreateModule(filepath.Join(addons, "demo_module"), config); err != nil {
+		t.Fatalf("CreateModule() error = %v", err)
+	}
+	addonsPath := addons
+	if extra := os.Getenv("ODOOCTL_TEST_ODOO_ADDONS_PATH"); extra != "" {
+		addonsPath += "," + extra
+	}
+	cmd := exec.Command(odooBin, "-d", db, "-i", "demo_module", "--addons-path", addonsPath, "--stop-after-init", "--without-demo=all", "--log-level=warn")
+	if output, err := cmd.CombinedOutput(); err != nil || regexp.MustCompile(` (ERROR|CRITICAL) `).Match(output) {
+		t.Fatalf("installing the scaffolded module failed: %v\n%s", err, output)
+	}
+}
+
+func TestManifestDataFilesLoadOrder(t *testing.T) {
+	data, demo := manifestDataFiles([]string{
+		"views/menu.xml",
+		"security/ir.model.access.csv",
+		"demo/demo.xml",
+		"data/sequence.xml",
+		"security/security.xml",
+		"static/src/js/x.js",
+		"models/x.py",
+	})
+	want := []string{"security/security.xml", "security/ir.model.access.csv", "data/sequence.xml", "views/menu.xml"}
+	if strings.Join(data, ",") != strings.Join(want, ",") {
+		t.Fatalf("manifestDataFiles() data = %v, want %v", data, want)
+	}
+	if len(demo) != 1 || demo[0] != "demo/demo.xml" {
+		t.Fatalf("manifestDataFiles() demo = %v, want [demo/demo.xml]", demo)
+	}
+}
+
+// manifestList extracts the quoted entries of a list key from a manifest
+func manifestList(t *testing.T, manifest, key string) []string {
+	t.Helper()
+	re := regexp.MustCompile(`(?s)'` + key + `': \[(.*?)\]`)
+	match := re.FindStringSubmatch(manifest)
+	if match == nil {
+		t.Fatalf("manifest has no %q list:\n%s", key, manifest)
+	}
+	var entries []string
+	for _, m := range regexp.MustCompile(`'([^']+)'`).FindAllStringSubmatch(match[1], -1) {
+		entries = append(entries, m[1])
+	}
+	return entries
+}
+
+func TestCreateModuleMixin(t *testing.T) {
+	cases := []struct {
+		name        string
+		withModel   bool
+		version     string
+		inheritedBy string
+		wantFiles   []string
+		noFiles     []string
+		mixin       []string
+	}{
+		{"example model", false, "17.0", "models/demo_module_example.py", []string{"models/demo_module_example.py"}, []string{"models/demo_module.py", "views/demo_module_views.xml"}, []string{"def _demo_module_compute_reference(self) -> str:"}},
+		{"with model", true, "17.0", "models/demo_module.py", []string{"models/demo_module.py"}, []string{"models/demo_module_example.py"}, nil},
+		{"12.0", false, "12.0", "models/demo_module_example.py", nil, nil, []string{"from odoo import api, fields, models", "@api.multi"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "demo_module")
+			config := ModuleConfig{Name: "demo_module", Author: "Me", Version: tc.version, Depends: []string{"base"}, Description: "Demo", WithModel: tc.withModel, Mixin: true}
+			if err := CreateModule(dir, config); err != nil {
+				t.Fatalf("CreateModule() error = %v", err)
+			}
+
+			mixin := readFile(t, filepath.Join(dir, "models", "demo_module_mixin.py"))
+			for _, want := range append([]string{"class DemoModuleMixin(models.AbstractModel):", "_name = 'demo.module.mixin'", "_description = 'Demo Mixin'"}, tc.mixin...) {
+				if !strings.Contains(mixin, want) {
+					t.Fatalf("mixin missing %q:\n%s", want, mixin)
+				}
+			}
+			if inheriting := readFile(t, filepath.Join(dir, tc.inheritedBy)); !strings.Contains(inheriting, "_inherit = ['demo.module.mixin']") {
+				t.Fatalf("%s does not inherit the mixin:\n%s", tc.inheritedBy, inheriting)
+			}
+			for _, file := range tc.wantFiles {
+				if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+					t.Fatalf("%s not generated: %v", file, err)
+				}
+			}
+			for _, file := range tc.noFiles {
+				if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+					t.Fatalf("%s unexpectedly generated", file)
+				}
+			}
+
+			// The mixin has to be registered before the models inheriting it
+			init := readFile(t, filepath.Join(dir, "models", "__init__.py"))
+			if !strings.HasPrefix(init, "from . import demo_module_mixin\n") {
+				t.Fatalf("models/__init__.py does not import the mixin first:\n%s", init)
+			}
+			if !strings.Contains(readFile(t, filepath.Join(dir, "__init__.py")), "from . import models") {
+				t.Fatal("__init__.py does not import models")
+			}
+			access := readFile(t, filepath.Join(dir, "security", "ir.model.access.csv"))
+			if strings.Contains(access, "mixin") || strings.Count(access, "\n") != 2 {
+				t.Fatalf("access rules should cover only the concrete model:\n%s", access)
+			}
+		})
+	}
+}
+
+func TestCreateModuleMultiCompany(t *testing.T) {
+	cases := []struct {
+		name      string
+		withModel bool
+		version   string
+		modelFile string
+		want      []string
+		rule      []string
+	}{
+		{"model", true, "17.0", "models/demo_module.py", []string{"default=lambda self: self.env.company,"}, []string{`ref="model_demo_module"`, "('company_id', 'in', company_ids)"}},
+		{"mixin example", false, "17.0", "models/demo_module_example.py", []string{"'res.company'"}, []string{`ref="model_demo_module_example"`}},
+		{"12.0", true, "12.0", "models/demo_module.py", []string{"default=lambda self: self.env.user.company_id,"}, []string{"[user.company_id.id]"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "demo_module")
+			config := ModuleConfig{Name: "demo_module", Author: "Me", Version: tc.version, Depends: []string{"base"}, Description: "Demo", WithModel: tc.withModel, Mixin: !tc.withModel, MultiCompany: true}
+			if err := CreateModule(dir, config); err != nil {
+				t.Fatalf("CreateModule() error = %v", err)
+			}
+			model := readFile(t, filepath.Join(dir, tc.modelFile))
+			for _, want := range append([]string{"company_id = fields.Many2one("}, tc.want...) {
+				if !strings.Contains(model, want) {
+					t.Fatalf("%s missing %q:\n%s", tc.modelFile, want, model)
+				}
+			}
+			rule := readFile(t, filepath.Join(dir, "security", "demo_module_security.xml"))
+			for _, want := range tc.rule {
+				if !strings.Contains(rule, want) {
+					t.Fatalf("record rule missing %q:\n%s", want, rule)
+				}
+			}
+			if data := manifestList(t, readFile(t, filepath.Join(dir, "__manifest__.py")), "data"); len(data) == 0 || data[0] != "security/demo_module_security.xml" {
+				t.Fatalf("manifest data = %v, want the record rule loaded first", data)
+			}
+		})
+	}
+
+	err := CreateModule(filepath.Join(t.TempDir(), "demo_module"), ModuleConfig{Name: "demo_module", Version: "17.0", MultiCompany: true})
+	if err == nil {
+		t.Fatal("expected an error for --multi-company without a model")
+	}
+}
+
+func TestCreateModuleWizardAndReport(t *testing.T) {
+	cases := []struct {
+		version   string
+		listTag   string
+		wizardACL bool
+		viewTypes bool
+		apiMulti  bool
+	}{
+		{"12.0", "<tree string=\"Records\">", false, false, true},
+		{"16.0", "<tree string=\"Records\">", true, true, false},
+		{"18.0", "<list string=\"Records\">", true, true, false},
+	}
+	for _, tc := range cases {
+		dir := filepath.Join(t.TempDir(), "demo_module")
+		config := ModuleConfig{Name: "demo_module", Author: "Me", Version: tc.version, Depends: []string{"web"}, Description: "Demo", WithModel: true, WithWizard: true, WithReport: true}
+		if err := CreateModule(dir, config); err != nil {
+			t.Fatalf("CreateModule(%s) error = %v", tc.version, err)
+		}
+		read := func(name string) string {
+			t.Helper()
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatalf("ReadFile(%s) error = %v", name, err)
+			}
+			return string(data)
+		}
+
+		if init := read("__init__.py"); !strings.Contains(init, "from . import wizard\n") {
+			t.Fatalf("%s __init__.py does not import wizard:\n%s", tc.version, init)
+		}
+		if got := read("wizard/__init__.py"); got != "from . import demo_module_wizard\n" {
+			t.Fatalf("%s wizard/__init__.py = %q", tc.version, got)
+		}
+		wizard := read("wizard/demo_module_wizard.py")
+		if !strings.Contains(wizard, "class DemoModuleWizard(models.TransientModel):") || !strings.Contains(wizard, "'demo.module', string='Records'") {
+			t.Fatalf("%s wizard model:\n%s", tc.version, wizard)
+		}
+		if strings.Contains(wizard, "@api.multi") != tc.apiMulti {
+			t.Fatalf("%s wizard @api.multi = %v, want %v", tc.version, !tc.apiMulti, tc.apiMulti)
+		}
+		views := read("wizard/demo_module_wizard_views.xml")
+		if !strings.Contains(views, tc.listTag) || !strings.Contains(views, `<field name="binding_model_id" ref="model_demo_module"/>`) {
+			t.Fatalf("%s wizard views:\n%s", tc.version, views)
+		}
+		if strings.Contains(views, "binding_view_types") != tc.viewTypes {
+			t.Fatalf("%s wizard binding_view_types = %v, want %v", tc.version, !tc.viewTypes, tc.viewTypes)
+		}
+		if strings.Contains(read("security/ir.model.access.csv"), "model_demo_module_wizard") != tc.wizardACL {
+			t.Fatalf("%s wizard access rule = %v, want %v", tc.version, !tc.wizardACL, tc.wizardACL)
+		}
+
+		report := read("report/demo_module_report.xml")
+		for _, want := range []string{`<field name="model">demo.module</field>`, `<field name="report_name">demo_module.report_demo_module</field>`, `<template id="report_demo_module">`} {
+			if !strings.Contains(report, want) {
+				t.Fatalf("%s report missing %q:\n%s", tc.version, want, report)
+			}
+		}
+
+		manifest := read("__manifest__.py")
+		if !regexp.MustCompile(`(?s)'views/demo_module_views.xml',\s*'report/demo_module_report.xml',\s*'wizard/demo_module_wizard_views.xml'`).MatchString(manifest) {
+			t.Fatalf("%s manifest data order:\n%s", tc.version, manifest)
+		}
+	}
+
+	// A wizard works on its own; a report needs a model to print
+	dir := filepath.Join(t.TempDir(), "demo_tool")
+	if err := CreateModule(dir, ModuleConfig{Name: "demo_tool", Version: "17.0", Depends: []string{"base"}, Description: "Tool", WithWizard: true}); err != nil {
+		t.Fatalf("CreateModule(wizard only) error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "wizard", "demo_tool_wizard.py")); strings.Contains(string(data), "record_ids") {
+		t.Fatal("standalone wizard has record_ids without a model to select")
+	}
+	if err := CreateModule(filepath.Join(t.TempDir(), "demo_print"), ModuleConfig{Name: "demo_print", Version: "17.0", WithReport: true}); err == nil {
+		t.Fatal("CreateModule(report without model) error = nil, want error")
+	}
+}
+
+func TestCreateModuleI18nTemplate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo_module")
+	config := ModuleConfig{Name: "demo_module", Author: "Me", Version: "17.0", Depends: []string{"base"}, Description: "Demo", I18n: true}
+	if err := CreateModule(dir, config); err != nil {
+		t.Fatalf("CreateModule() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "i18n", "demo_module.pot"))
+	if err != nil {
+		t.Fatalf("ReadFile(i18n/demo_module.pot) error = %v", err)
+	}
+	for _, want := range []string{"# \t* demo_module\n", `"Project-Id-Version: Odoo Server 17.0\n"`, "msgid \"\"\nmsgstr \"\"\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("demo_module.pot missing %q:\n%s", want, data)
+		}
+	}
+	if manifest, _ := os.ReadFile(filepath.Join(dir, "__manifest__.py")); strings.Contains(string(manifest), "i18n") {
+		t.Fatalf("manifest lists the translation template:\n%s", manifest)
+	}
+}
@@ -1,6 +1,7 @@
 package templates
 
 import (
+	"bytes"
 	"embed"
 	"fmt"
 	"os"
@@ -9,56 +10,128 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/fatih/color"
 	"github.com/mart337i/odooctl/internal/config"
+	"github.com/mart337i/odooctl/internal/odoo"
 )
 
 //go:embed files/* files/12.0/* files/13.0/* files/14.0/* files/15.0/* files/16.0/* files/17.0/* files/19.0/*
 var templateFS embed.FS
 
+// baseTemplateVersion is the Odoo version the templates directly in files/
+// are written for (Ubuntu noble, demo data unless --without-demo). It has no
+// directory of its own; other versions override files in files/<version>/.
+const baseTemplateVersion = "18.0"
+
+// newestTemplateVersion has the newest version-specific templates. Later
+// majors reuse them, since demo data became opt-in in 19.0.
+const newestTemplateVersion = "19.0"
+
 // Data holds template rendering context
 type Data struct {
 	ProjectName           string
 	OdooVersion           string
 	VersionSuffix         string
+	ComposeProjectName    string
 	DBName                string
+	PostgresVersion       string
 	ProjectRoot           string
 	InitModules           string
 	WithoutDemo           bool
+	WithoutDemoModules    string
 	Enterprise            bool
-	EnterpriseGitHubToken string
+	EnterpriseTokenAuth   bool // the build clones enterprise with the GITHUB_TOKEN secret
 	EnterpriseSSHKeyPath  string
+	EnterpriseRepoHTTPS   string // host/path, cloned as https://<token>@host/path
+	EnterpriseRepoSSH     string
+	EnterpriseRepoKeyscan string // ssh-keyscan arguments for the SSH host
+	EnterpriseTokenUser   string // user before the token in HTTPS URLs, "oauth2:" outside GitHub
 	AddonsPaths           []string
+	ExtraConfOptions      map[string]string
+	CacheFrom             string
+	LogMaxSize            string
+	LogMaxFile            int
+	DebugpyEnabled        bool
 	Ports                 config.Ports
 	BrowserEnabled        bool
 	BrowserProvider       string
+	ExternalNetwork       string
+	FilestoreBindPath     string
+	ExtraEnv              map[string]string // values quoted for YAML, with $ escaped from compose
+	ReportURL             string
+	WebBaseURL            string
 }
 
 // NewData creates template data from state
 func NewData(state *config.State) Data {
 	versionSuffix := strings.Replace(state.OdooVersion, ".", "", 1)
-	dbName := "odoo-" + versionSuffix
+	dbName := state.DBName()
 
 	modules := []string{"base", "web"}
 	modules = append(modules, state.Modules...)
 
+	// Render validates the URL; fall back to Odoo's repository here
+	enterpriseRepo, err := state.EnterpriseRepo()
+	if err != nil {
+		enterpriseRepo, _ = config.ParseEnterpriseRepo(config.DefaultEnterpriseRepoURL)
+	}
+	keyscan := enterpriseRepo.Host
+	if enterpriseRepo.SSHPort != "" {
+		keyscan = "-p " + enterpriseRepo.SSHPort + " " + keyscan
+	}
+	tokenUser := ""
+	if !enterpriseRepo.IsGitHub() {
+		tokenUser = "oauth2:"
+	}
+
 	return Data{
 		ProjectName:           state.ProjectName,
 		OdooVersion:           state.OdooVersion,
 		VersionSuffix:         versionSuffix,
+		ComposeProjectName:    state.ComposeProjectName(),
 		DBName:                dbName,
+		PostgresVersion:       state.PostgresImageVersion(),
 		ProjectRoot:           state.ProjectRoot,
 		InitModules:           strings.Join(modules, ","),
 		WithoutDemo:           state.WithoutDemo,
+		WithoutDemoModules:    strings.Join(state.WithoutDemoModules, ","),
 		Enterprise:            state.Enterprise,
-		EnterpriseGitHubToken: state.EnterpriseGitHubToken,
+		EnterpriseTokenAuth:   state.UsesEnterpriseToken(),
 		EnterpriseSSHKeyPath:  state.EnterpriseSSHKeyPath,
+		EnterpriseRepoHTTPS:   enterpriseRepo.HTTPSHost + "/" + enterpriseRepo.Path,
+		EnterpriseRepoSSH:     enterpriseRepo.SSHURL(),
+		EnterpriseRepoKeyscan: keyscan,
+		EnterpriseTokenUser:   tokenUser,
 		AddonsPaths:           state.AddonsPaths,
+		ExtraConfOptions:      state.ExtraConfOptions,
+		CacheFrom:             state.CacheFromRef(),
+		LogMaxSize:            state.LoggingMaxSize(),
+		LogMaxFile:            state.LoggingMaxFile(),
+		DebugpyEnabled:        state.DebugpyEnabled,
 		Ports:                 state.Ports,
 		BrowserEnabled:        state.BrowserEnabled,
 		BrowserProvider:       state.BrowserProvider,
+		ExternalNetwork:       state.ExternalNetwork,
+		FilestoreBindPath:     state.FilestoreBindPath,
+		ExtraEnv:              composeEnv(state.ExtraEnv),
+		ReportURL:             state.ReportURLParam(),
+		WebBaseURL:            state.WebBaseURL,
 	}
 }
 
+// composeEnv quotes environment values for the compose file, keeping
+// compose from interpolating $ in them
+func composeEnv(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	quoted := make(map[string]string, len(env))
+	for key, value := range env {
+		quoted[key] = strconv.Quote(strings.ReplaceAll(value, "$", "$$"))
+	}
+	return quoted
+}
+
 // getTemplatePath returns the version-specific template path if it exists,
 // otherwise returns the base template path. For v19+, it falls back to 19.0 templates
 // to ensure proper demo data handling (inverted behavior in v19+).
@@ -70,8 +143,8 @@ func getTemplatePath(version, filename string) string {
 	}
 
 	// For v19+, fall back to 19.0 template if it exists (handles demo inversion)
-	if isVersion19OrHigher(version) {
-		v19Path := fmt.Sprintf("files/19.0/%s", filename)
+	if majorVersion(version) >= 19 {
+		v19Path := fmt.Sprintf("files/%s/%s", newestTemplateVersion, filename)
 		if _, err := templateFS.ReadFile(v19Path); err == nil {
 			return v19Path
 		}
@@ -81,18 +154,33 @@ func getTemplatePath(version, filename string) string {
 	return fmt.Sprintf("files/%s", filename)
 }
 
-// isVersion19OrHigher checks if the version is 19.0 or higher
-func isVersion19OrHigher(version string) bool {
-	// Extract major version (e.g., "19.0" -> 19, "20.0" -> 20)
-	parts := strings.Split(version, ".")
-	if len(parts) == 0 {
-		return false
+// CheckVersion reports whether there are templates for version. Supported
+// versions have them; newer majors reuse the newest ones and get a warning,
+// since the base templates would get demo data and the base image wrong.
+// Anything else is an error.
+func CheckVersion(version string) (warning string, err error) {
+	if odoo.IsValidVersion(version) {
+		return "", nil
 	}
-	major, err := strconv.Atoi(parts[0])
+	if majorVersion(version) > majorVersion(newestTemplateVersion) {
+		return fmt.Sprintf("Odoo %s is newer than the versions odooctl supports (%s); generating files from the %s templates", version, odoo.VersionsString(), newestTemplateVersion), nil
+	}
+	return "", fmt.Errorf("no templates for Odoo version %q (supported: %s)", version, odoo.VersionsString())
+}
+
+// SupportsWithoutDemoModules reports whether version can skip demo data for
+// selected modules. From 19.0 on, demo data is one switch for the database.
+func SupportsWithoutDemoModules(version string) bool {
+	return majorVersion(version) < 19
+}
+
+// majorVersion extracts the major version ("19.0" -> 19), or 0
+func majorVersion(version string) int {
+	major, err := strconv.Atoi(strings.Split(version, ".")[0])
 	if err != nil {
-		return false
+		return 0
 	}
-	return major >= 19
+	return major
 }
 
 // Render generates all Docker files to the environment directory
@@ -102,10 +190,53 @@ func Render(state *config.State) error {
 		return err
 	}
 
+	warning, err := CheckVersion(state.OdooVersion)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "%s %s\n", color.YellowString("⚠️"), warning)
+	}
+
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
+	// Templates only know whether token auth is in use; the token itself
+	// reaches the build as a secret through the environment
+	var enterpriseToken string
+	if state.UsesEnterpriseToken() {
+		if enterpriseToken, err = state.EnterpriseToken(); err != nil {
+			return err
+		}
+	}
+	if state.Enterprise {
+		if _, err := state.EnterpriseRepo(); err != nil {
+			return err
+		}
+	}
+	if err := config.ValidatePostgresVersion(state.PostgresImageVersion()); err != nil {
+		return err
+	}
+	if state.ExternalNetwork != "" {
+		if err := config.ValidateNetworkName(state.ExternalNetwork); err != nil {
+			return err
+		}
+	}
+	for key, value := range state.ExtraEnv {
+		if err := config.ValidateEnvVar(key, value); err != nil {
+			return err
+		}
+	}
+	// The URLs are written into init-params.py as Python strings
+	for _, value := range []string{state.ReportURL, state.WebBaseURL} {
+		if value == "" {
+			continue
+		}
+		if err := config.ValidateParamURL(value); err != nil {
+			return err
+		}
+	}
 	data := NewData(state)
 
 	// Map of output filename to template filename
@@ -115,6 +246,7 @@ func Render(state *config.State) error {
 		"odoo.conf.tmpl",
 		"entrypoint.sh.tmpl",
 		"wait-for-psql.py.tmpl",
+		"init-params.py.tmpl",
 		".env.tmpl",
 		".dockerignore.tmpl",
 	}
@@ -124,7 +256,7 @@ func Render(state *config.State) error {
 		tmplPath := getTemplatePath(state.OdooVersion, tmplFilename)
 		// Output filename removes .tmpl suffix
 		outputName := strings.TrimSuffix(tmplFilename, ".tmpl")
-		if err := renderFile(dir, outputName, tmplPath, data); err != nil {
+		if err := renderFile(dir, outputName, tmplPath, data, enterpriseToken); err != nil {
 			return err
 		}
 	}
@@ -132,7 +264,7 @@ func Render(state *config.State) error {
 	return nil
 }
 
-func renderFile(dir, outputName, tmplPath string, data Data) error {
+func renderFile(dir, outputName, tmplPath string, data Data, secret string) error {
 	content, err := templateFS.ReadFile(tmplPath)
 	if err != nil {
 		return err
@@ -143,14 +275,22 @@ func renderFile(dir, outputName, tmplPath string, data Data) error {
 		return err
 	}
 
-	outputPath := filepath.Join(dir, outputName)
-	f, err := os.Create(outputPath)
-	if err != nil {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
 		return err
 	}
-	defer f.Close()
 
-	if err := tmpl.Execute(f, data); err != nil {
+	rendered := buf.String()
+	// The token reaches the build only as a BuildKit secret
+	if secret != "" && strings.Contains(rendered, secret) {
+		return fmt.Errorf("refusing to write %s: it would contain the enterprise token", outputName)
+	}
+	if outputName == "odoo.conf" {
+		rendered = dedupeConfOptions(rendered)
+	}
+
+	outputPath := filepath.Join(dir, outputName)
+	if err := os.WriteFile(outputPath, []byte(rendered), 0644); err != nil {
 		return err
 	}
 
@@ -163,3 +303,37 @@ func renderFile(dir, outputName, tmplPath string, data Data) error {
 
 	return nil
 }
+
+// dedupeConfOptions drops earlier assignments of a key when a later line sets
+// it again, so extra conf options override the template defaults instead of
+// producing duplicate keys that Odoo's config parser rejects.
+func dedupeConfOptions(content string) string {
+	lines := strings.Split(content, "\n")
+	lastIndex := make(map[string]int)
+	for i, line := range lines {
+		if key, ok := confLineKey(line); ok {
+			lastIndex[key] = i
+		}
+	}
+
+	kept := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if key, ok := confLineKey(line); ok && lastIndex[key] != i {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+func confLineKey(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+	key, _, ok := strings.Cut(trimmed, "=")
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(key), true
+}
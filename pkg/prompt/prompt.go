@@ -1,26 +1,75 @@
 package prompt
 
 import (
+	"fmt"
+
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/mart337i/odooctl/internal/odoo"
 )
 
+// nonInteractive makes every prompt return its default answer without
+// reading from the terminal.
+var nonInteractive bool
+
+// SetNonInteractive enables or disables non-interactive mode
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// NonInteractive reports whether prompts are answered with their defaults
+func NonInteractive() bool {
+	return nonInteractive
+}
+
 // SelectVersion prompts user to select an Odoo version
 func SelectVersion() (string, error) {
-	var selected string
+	return Select("Select Odoo version:", odoo.OdooVersions, odoo.DefaultOdooVersion)
+}
+
+// Select prompts for one of options. Without a default it fails in
+// non-interactive mode.
+func Select(message string, options []string, def string) (string, error) {
+	if nonInteractive {
+		if def == "" {
+			return "", fmt.Errorf("cannot prompt for %q in non-interactive mode", message)
+		}
+		return def, nil
+	}
 
+	var selected string
 	prompt := &survey.Select{
-		Message: "Select Odoo version:",
-		Options: odoo.OdooVersions,
-		Default: odoo.DefaultOdooVersion,
+		Message: message,
+		Options: options,
+	}
+	if def != "" {
+		prompt.Default = def
 	}
+	err := survey.AskOne(prompt, &selected)
+	return selected, err
+}
 
+// MultiSelect prompts for any number of options, with defaults preselected
+func MultiSelect(message string, options, defaults []string) ([]string, error) {
+	if nonInteractive {
+		return defaults, nil
+	}
+
+	var selected []string
+	prompt := &survey.MultiSelect{
+		Message: message,
+		Options: options,
+		Default: defaults,
+	}
 	err := survey.AskOne(prompt, &selected)
 	return selected, err
 }
 
 // InputString prompts for text input
 func InputString(message, defaultVal string) (string, error) {
+	if nonInteractive {
+		return defaultVal, nil
+	}
+
 	var result string
 	prompt := &survey.Input{
 		Message: message,
@@ -32,6 +81,10 @@ func InputString(message, defaultVal string) (string, error) {
 
 // Confirm prompts for yes/no
 func Confirm(message string, defaultVal bool) (bool, error) {
+	if nonInteractive {
+		return defaultVal, nil
+	}
+
 	var result bool
 	prompt := &survey.Confirm{
 		Message: message,
@@ -41,8 +94,13 @@ func Confirm(message string, defaultVal bool) (bool, error) {
 	return result, err
 }
 
-// InputPassword prompts for password/token input (hidden)
+// InputPassword prompts for password/token input (hidden).
+// There is no default for secrets, so it fails in non-interactive mode.
 func InputPassword(message string) (string, error) {
+	if nonInteractive {
+		return "", fmt.Errorf("cannot prompt for %q in non-interactive mode", message)
+	}
+
 	var result string
 	prompt := &survey.Password{
 		Message: message,
@@ -0,0 +1,34 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/mart337i/odooctl/internal/odoo"
+)
+
+func TestNonInteractiveReturnsDefaults(t *testing.T) {
+	SetNonInteractive(true)
+	t.Cleanup(func() { SetNonInteractive(false) })
+
+	if got, err := Confirm("Continue?", true); err != nil || !got {
+		t.Fatalf("Confirm() = %v, %v, want true, nil", got, err)
+	}
+	if got, err := InputString("Name:", "odoo"); err != nil || got != "odoo" {
+		t.Fatalf("InputString() = %q, %v, want %q, nil", got, err, "odoo")
+	}
+	if got, err := SelectVersion(); err != nil || got != odoo.DefaultOdooVersion {
+		t.Fatalf("SelectVersion() = %q, %v, want the default version", got, err)
+	}
+	if got, err := Select("Service:", []string{"odoo", "db"}, "db"); err != nil || got != "db" {
+		t.Fatalf("Select() = %q, %v, want %q, nil", got, err, "db")
+	}
+	if _, err := Select("Service:", []string{"odoo", "db"}, ""); err == nil {
+		t.Fatalf("Select() without default error = nil, want error in non-interactive mode")
+	}
+	if got, err := MultiSelect("Modules:", []string{"a", "b", "c"}, []string{"b"}); err != nil || len(got) != 1 || got[0] != "b" {
+		t.Fatalf("MultiSelect() = %v, %v, want [b], nil", got, err)
+	}
+	if _, err := InputPassword("Token:"); err == nil {
+		t.Fatalf("InputPassword() error = nil, want error in non-interactive mode")
+	}
+}